@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+const (
+	defaultCircuitBreakerThreshold = 5
+	defaultCircuitBreakerCooldown  = time.Minute
+)
+
+// errCircuitOpen is returned for OVH API calls skipped because the circuit
+// breaker is open.
+var errCircuitOpen = errors.New("OVH API circuit breaker is open")
+
+// circuitBreaker stops calling the OVH API for a cooldown once threshold
+// consecutive calls failed because the API was unreachable or answered with a
+// server error, so that a platform incident fails applies quickly instead of
+// having every operation retry until its timeout. It is shared by all
+// operations of a provider instance.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// newCircuitBreaker returns a breaker opening after threshold consecutive
+// failures, or nil, which never opens, when threshold is not positive.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns an error wrapping errCircuitOpen while the breaker is open.
+// Once the cooldown has passed calls go through again, and the first one
+// failing reopens the breaker.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.now().Before(b.openUntil) {
+		return fmt.Errorf("%w after %d consecutive failed calls, skipping OVH API calls until %s. "+
+			"OVH may be having an incident, check https://public-cloud.status-ovhcloud.com/ before running Terraform again",
+			errCircuitOpen, b.failures, b.openUntil.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a call.
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isOVHUnavailable(err) {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// isOVHUnavailable reports whether err means the OVH API could not serve a
+// call, as opposed to rejecting it.
+func isOVHUnavailable(err error) bool {
+	var apiErr *ovh.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
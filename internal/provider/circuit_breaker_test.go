@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker_opensAfterConsecutiveFailures checks that server errors
+// open the breaker for the cooldown while client errors reset the count
+func TestCircuitBreaker_opensAfterConsecutiveFailures(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(503, `{"message": "Service Unavailable"}`, nil)
+	mock.AddResponse(404, `{"message": "Not Found"}`, nil)
+	mock.AddResponse(503, `{"message": "Service Unavailable"}`, nil)
+	mock.AddResponse(500, `{"message": "Internal Server Error"}`, nil)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	config := mock.NewConfig(t)
+	config.OVHClient.breaker = breaker
+
+	for i := 0; i < 4; i++ {
+		if err := config.OVHClient.Get("/cloud/project/vault/cluster", nil); err == nil || errors.Is(err, errCircuitOpen) {
+			t.Fatalf("call %d: expected the API error, got %v", i, err)
+		}
+	}
+
+	err := config.OVHClient.Get("/cloud/project/vault/cluster", nil)
+	if !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("expected the breaker to be open, got %v", err)
+	}
+	if got := mock.GetRequestCount(); got != 4 {
+		t.Errorf("expected the open breaker to skip the call, got %d requests", got)
+	}
+
+	now = now.Add(time.Minute)
+	if err := config.OVHClient.Get("/cloud/project/vault/cluster", nil); err != nil {
+		t.Fatalf("expected calls to go through after the cooldown, got %v", err)
+	}
+}
+
+func TestNewCircuitBreaker_disabled(t *testing.T) {
+	breaker := newCircuitBreaker(0, time.Minute)
+	if breaker != nil {
+		t.Fatal("expected a threshold of 0 to disable the breaker")
+	}
+
+	breaker.record(errors.New("failure"))
+	if err := breaker.allow(); err != nil {
+		t.Errorf("expected a disabled breaker to allow calls, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterConfigSecretFields are the fields of cluster create and update
+// payloads holding secrets, which config_json redacts. user_data may embed
+// secrets too, and its changes show in user_data_hash instead.
+var clusterConfigSecretFields = []string{"userData", "vaultToken", "consulToken"}
+
+// clusterConfigActionFields are the fields of update payloads that request
+// an action rather than set the configuration, left out of config_json.
+var clusterConfigActionFields = []string{"removedNodes"}
+
+// redactedConfigValue replaces the secrets of config_json.
+const redactedConfigValue = "REDACTED"
+
+func configJsonSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Configuration sent to OVH for the cluster as JSON, with sorted keys and secrets redacted: the create payload, with the fields of later updates merged in. Empty for imported clusters",
+	}
+}
+
+// setConfigJson sets config_json to the create payload of a cluster.
+func setConfigJson(d *schema.ResourceData, clusterConfig map[string]interface{}) {
+	d.Set("config_json", clusterConfigJson(map[string]interface{}{}, clusterConfig))
+}
+
+// mergeConfigJson merges the fields of an update payload of a cluster into
+// config_json, so that it holds what a create would now send. It stays empty
+// when the create payload is unknown, as for an imported cluster.
+func mergeConfigJson(d *schema.ResourceData, updateConfig map[string]interface{}) {
+	current := d.Get("config_json").(string)
+	if current == "" {
+		return
+	}
+
+	var merged map[string]interface{}
+	if err := json.Unmarshal([]byte(current), &merged); err != nil {
+		return
+	}
+	d.Set("config_json", clusterConfigJson(merged, updateConfig))
+}
+
+// clusterConfigJson returns base with the fields of payload set, or removed
+// when nil, secrets redacted and action fields left out, as JSON.
+// encoding/json sorts the keys of maps, so equal configurations give equal
+// strings.
+func clusterConfigJson(base, payload map[string]interface{}) string {
+	for key, value := range payload {
+		if value == nil {
+			delete(base, key)
+			continue
+		}
+		base[key] = value
+	}
+	for _, key := range clusterConfigSecretFields {
+		if value, ok := base[key]; ok && value != "" {
+			base[key] = redactedConfigValue
+		}
+	}
+	for _, key := range clusterConfigActionFields {
+		delete(base, key)
+	}
+
+	data, err := json.Marshal(base)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// planConfigJson plans config_json of an existing cluster as changing when
+// its tags or one of keys, the other attributes its updates send, change.
+func planConfigJson(d *schema.ResourceDiff, keys ...string) error {
+	if d.Id() == "" || d.Get("config_json").(string) == "" || !(d.HasChange("tags") || d.HasChanges(keys...)) {
+		return nil
+	}
+	return d.SetNewComputed("config_json")
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestVaultClusterCreate_configJson checks that config_json holds the create
+// payload sent to OVH, with user_data redacted
+func TestVaultClusterCreate_configJson(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["user_data"] = "#cloud-config\nwrite_files:\n  - content: secret\n"
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+	if diags := resourceVaultClusterCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var sent, got map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[1]), &sent); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if err := json.Unmarshal([]byte(d.Get("config_json").(string)), &got); err != nil {
+		t.Fatalf("failed to decode config_json: %s", err)
+	}
+
+	if got["userData"] != redactedConfigValue {
+		t.Errorf("expected userData to be redacted, got %v", got["userData"])
+	}
+	delete(sent, "userData")
+	delete(got, "userData")
+	sentJson, _ := json.Marshal(sent)
+	gotJson, _ := json.Marshal(got)
+	if string(sentJson) != string(gotJson) {
+		t.Errorf("expected config_json to be the create payload\n%s\ngot\n%s", sentJson, gotJson)
+	}
+}
+
+// TestMergeConfigJson checks that update payloads are merged into
+// config_json without their secrets and action fields
+func TestMergeConfigJson(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, map[string]interface{}{})
+
+	mergeConfigJson(d, map[string]interface{}{"serverCount": 5})
+	if got := d.Get("config_json").(string); got != "" {
+		t.Fatalf("expected config_json of an imported cluster to stay empty, got %s", got)
+	}
+
+	d.Set("config_json", `{"name":"test-nomad","serverCount":3,"vaultToken":"REDACTED"}`)
+	mergeConfigJson(d, map[string]interface{}{
+		"serverCount":  5,
+		"consulToken":  "s.consul",
+		"removedNodes": []string{"node-1"},
+	})
+
+	expected := `{"consulToken":"REDACTED","name":"test-nomad","serverCount":5,"vaultToken":"REDACTED"}`
+	if got := d.Get("config_json").(string); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func connectionInfoSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "Non-sensitive connection details of the cluster, to pass around as a single module output: address, ui_url, datacenter, region and version",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// setConnectionInfo sets connection_info from an API object of a service
+// cluster. Every key is always present, empty when it does not apply, so
+// that modules can index it unconditionally.
+func setConnectionInfo(d *schema.ResourceData, service string, cluster map[string]interface{}) {
+	d.Set("connection_info", map[string]string{
+		"address":    clusterAddress(service, cluster),
+		"ui_url":     getString(cluster, "uiUrl"),
+		"datacenter": getString(cluster, "datacenter"),
+		"region":     getString(cluster, "region"),
+		"version":    getString(cluster, "version"),
+	})
+}
+
+// clusterAddress returns the address clients connect to of an API object of
+// a service cluster: its custom endpoint when custom_domain is set, else the
+// primary endpoint of the service.
+func clusterAddress(service string, cluster map[string]interface{}) string {
+	if customEndpoint := getString(cluster, "customEndpoint"); customEndpoint != "" {
+		return customEndpoint
+	}
+
+	switch service {
+	case "vault":
+		return getString(cluster, "clusterUrl")
+	case "boundary":
+		return firstEndpoint(cluster, "controllerEndpoints")
+	default:
+		return firstEndpoint(cluster, "serverEndpoints")
+	}
+}
+
+// firstEndpoint returns the first endpoint in the string list field of an
+// API cluster object, or an empty string when there is none.
+func firstEndpoint(cluster map[string]interface{}, field string) string {
+	if endpoints := getStringList(cluster, field); len(endpoints) > 0 {
+		return endpoints[0]
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestConsulClusterRead_connectionInfo checks that connection_info gathers
+// the connection details of the cluster, without its secrets
+func TestConsulClusterRead_connectionInfo(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "region": "GRA", "datacenter": "dc1", "version": "1.17.1", "serverEndpoints": ["10.0.0.1:8500", "10.0.0.2:8500"], "uiUrl": "https://consul-123.ovh.net/ui", "masterToken": "secret"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	if diags := resourceConsulClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := map[string]interface{}{
+		"address":    "10.0.0.1:8500",
+		"ui_url":     "https://consul-123.ovh.net/ui",
+		"datacenter": "dc1",
+		"region":     "GRA",
+		"version":    "1.17.1",
+	}
+	if got := d.Get("connection_info").(map[string]interface{}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected connection_info %v, got %v", expected, got)
+	}
+}
+
+// TestVaultClusterRead_connectionInfoCustomEndpoint checks that the address
+// is the endpoint under custom_domain when one is set
+func TestVaultClusterRead_connectionInfoCustomEndpoint(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY", "region": "GRA", "clusterUrl": "https://vault-123.ovh.net:8200", "customEndpoint": "https://vault.internal.example.com:8200"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, testVaultClusterRawConfig())
+	d.SetId("vault-123")
+
+	if diags := resourceVaultClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	info := d.Get("connection_info").(map[string]interface{})
+	if got := info["address"]; got != "https://vault.internal.example.com:8200" {
+		t.Errorf("expected address https://vault.internal.example.com:8200, got %v", got)
+	}
+	if got, ok := info["datacenter"]; !ok || got != "" {
+		t.Errorf("expected an empty datacenter, got %v", got)
+	}
+}
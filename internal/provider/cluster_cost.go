@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// catalogPriceUnit is the number of catalog price units in one unit of
+// currency, as the OVH order catalog expresses prices in hundred-millionths.
+const catalogPriceUnit = 100000000
+
+// catalogSubsidiaries maps the endpoints selling public cloud instances to the
+// OVH subsidiary whose catalog is used for cost estimates.
+var catalogSubsidiaries = map[string]string{
+	"ovh-eu": "FR",
+	"ovh-ca": "CA",
+	"ovh-us": "US",
+}
+
+// flavorPrices holds the monthly price of each instance flavor in the public
+// cloud catalog.
+type flavorPrices struct {
+	currency string
+	monthly  map[string]float64
+}
+
+func estimatedMonthlyCostSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeFloat,
+		Computed:    true,
+		Description: "Rough monthly cost of the cluster nodes from the OVH public catalog, excluding storage, traffic and taxes. Null when pricing is unavailable",
+	}
+}
+
+func estimatedMonthlyCostCurrencySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Currency of estimated_monthly_cost, such as EUR",
+	}
+}
+
+// setEstimatedMonthlyCost sets estimated_monthly_cost for a cluster whose
+// node count is the sum of countKeys. Pricing failures clear the estimate
+// rather than failing the read.
+func setEstimatedMonthlyCost(d *schema.ResourceData, config *Config, countKeys ...string) {
+	nodes := 0
+	for _, key := range countKeys {
+		nodes += d.Get(key).(int)
+	}
+
+	cost, currency, err := config.estimateMonthlyCost(d.Get("instance_type").(string), nodes)
+	if err != nil {
+		d.Set("estimated_monthly_cost", nil)
+		d.Set("estimated_monthly_cost_currency", nil)
+		return
+	}
+	d.Set("estimated_monthly_cost", cost)
+	d.Set("estimated_monthly_cost_currency", currency)
+}
+
+// planEstimatedMonthlyCost shows the cost of a new cluster, or of a change of
+// its instance type or countKeys, in the plan. When pricing is unavailable the
+// estimate is left unknown and cleared by the next read.
+func planEstimatedMonthlyCost(d *schema.ResourceDiff, meta interface{}, countKeys ...string) error {
+	config, ok := meta.(*Config)
+	if !ok {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("instance_type") && !d.HasChanges(countKeys...) {
+		return nil
+	}
+
+	nodes := 0
+	for _, key := range countKeys {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("estimated_monthly_cost")
+		}
+		nodes += d.Get(key).(int)
+	}
+
+	instanceType, err := config.instanceType(d.Get("instance_type").(string))
+	if err != nil {
+		return nil
+	}
+	cost, currency, err := config.estimateMonthlyCost(instanceType, nodes)
+	if err != nil {
+		return d.SetNewComputed("estimated_monthly_cost")
+	}
+	if err := d.SetNew("estimated_monthly_cost", cost); err != nil {
+		return err
+	}
+	return d.SetNew("estimated_monthly_cost_currency", currency)
+}
+
+// estimateMonthlyCost returns the monthly price of nodes instances of
+// instanceType, rounded to the cent, and its currency.
+func (c *Config) estimateMonthlyCost(instanceType string, nodes int) (float64, string, error) {
+	prices, err := c.flavorPrices()
+	if err != nil {
+		return 0, "", err
+	}
+	price, ok := prices.monthly[instanceType]
+	if !ok {
+		return 0, "", fmt.Errorf("no monthly price for instance type %q in the OVH catalog", instanceType)
+	}
+	return math.Round(price*float64(nodes)*100) / 100, prices.currency, nil
+}
+
+// flavorPrices returns the public cloud flavor prices of the catalog of the
+// configured endpoint. A successful fetch is cached, as the catalog is large.
+func (c *Config) flavorPrices() (*flavorPrices, error) {
+	c.pricesMu.Lock()
+	defer c.pricesMu.Unlock()
+
+	if c.prices != nil {
+		return c.prices, nil
+	}
+
+	subsidiary, ok := catalogSubsidiaries[c.Endpoint]
+	if !ok {
+		return nil, fmt.Errorf("no public cloud catalog for endpoint %q", c.Endpoint)
+	}
+
+	var catalog map[string]interface{}
+	err := c.OVHClient.Get(fmt.Sprintf("/order/catalog/public/cloud?ovhSubsidiary=%s", subsidiary), &catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the OVH public cloud catalog: %w", err)
+	}
+
+	prices := &flavorPrices{monthly: map[string]float64{}}
+	if locale, ok := catalog["locale"].(map[string]interface{}); ok {
+		prices.currency = getString(locale, "currencyCode")
+	}
+
+	addons, _ := catalog["addons"].([]interface{})
+	for _, raw := range addons {
+		addon, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// Monthly plans are named "<flavor>.monthly.postpaid".
+		flavor, ok := strings.CutSuffix(getString(addon, "planCode"), ".monthly.postpaid")
+		if !ok {
+			continue
+		}
+		pricings, _ := addon["pricings"].([]interface{})
+		for _, raw := range pricings {
+			pricing, ok := raw.(map[string]interface{})
+			if !ok || getString(pricing, "intervalUnit") != "month" {
+				continue
+			}
+			prices.monthly[flavor] = float64(getInt(pricing, "price")) / catalogPriceUnit
+		}
+	}
+
+	c.prices = prices
+	return prices, nil
+}
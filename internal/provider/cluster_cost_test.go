@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testPublicCloudCatalog = `{
+  "locale": {"currencyCode": "EUR", "subsidiary": "FR"},
+  "addons": [
+    {"planCode": "c2-15.monthly.postpaid", "pricings": [{"intervalUnit": "month", "price": 6200000000}]},
+    {"planCode": "c2-15.consumption", "pricings": [{"intervalUnit": "none", "price": 9000000}]},
+    {"planCode": "b2-7.monthly.postpaid", "pricings": [{"intervalUnit": "month", "price": 2290000000}]}
+  ]
+}`
+
+func TestConfigEstimateMonthlyCost(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, testPublicCloudCatalog, nil)
+
+	config := mock.NewConfig(t)
+	config.Endpoint = "ovh-eu"
+
+	cost, currency, err := config.estimateMonthlyCost("c2-15", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cost != 186 || currency != "EUR" {
+		t.Errorf("expected 186 EUR, got %v %s", cost, currency)
+	}
+
+	if _, _, err := config.estimateMonthlyCost("t1-45", 1); err == nil {
+		t.Error("expected an error for an instance type missing from the catalog")
+	}
+
+	req := mock.GetLastRequest()
+	if req.URL.Path != "/order/catalog/public/cloud" || req.URL.Query().Get("ovhSubsidiary") != "FR" {
+		t.Errorf("unexpected request %s", req.URL)
+	}
+	if got := mock.GetRequestCount(); got != 1 {
+		t.Errorf("expected the catalog to be fetched once, got %d requests", got)
+	}
+}
+
+// TestVaultClusterRead_estimatedMonthlyCost checks that the estimate is set
+// from the catalog and left null when pricing is unavailable
+func TestVaultClusterRead_estimatedMonthlyCost(t *testing.T) {
+	cases := map[string]struct {
+		catalogStatus int
+		catalogBody   string
+		expectCost    bool
+	}{
+		"priced":      {catalogStatus: 200, catalogBody: testPublicCloudCatalog, expectCost: true},
+		"unavailable": {catalogStatus: 503, catalogBody: `{"message": "Service Unavailable"}`},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY", "nodeCount": 3, "instanceType": "c2-15"}`, nil)
+			mock.AddResponse(tc.catalogStatus, tc.catalogBody, nil)
+
+			d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, testVaultClusterRawConfig())
+			d.SetId("vault-123")
+
+			config := mock.NewConfig(t)
+			config.Endpoint = "ovh-eu"
+			if diags := resourceVaultClusterRead(context.Background(), d, config); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			cost, ok := d.GetOk("estimated_monthly_cost")
+			if ok != tc.expectCost {
+				t.Fatalf("expected estimated_monthly_cost set=%t, got %v", tc.expectCost, cost)
+			}
+			if tc.expectCost && (cost.(float64) != 186 || d.Get("estimated_monthly_cost_currency").(string) != "EUR") {
+				t.Errorf("expected 186 EUR, got %v %s", cost, d.Get("estimated_monthly_cost_currency"))
+			}
+		})
+	}
+}
+
+// TestVaultCluster_planEstimatedMonthlyCost checks that a node count change
+// shows the new estimate in the plan
+func TestVaultCluster_planEstimatedMonthlyCost(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, testPublicCloudCatalog, nil)
+
+	config := mock.NewConfig(t)
+	config.Endpoint = "ovh-eu"
+
+	state := &sdkterraform.InstanceState{
+		ID: "vault-123",
+		Attributes: map[string]string{
+			"id":                              "vault-123",
+			"auto_unseal":                     "true",
+			"name":                            "test-vault",
+			"region":                          "GRA",
+			"node_count":                      "3",
+			"instance_type":                   "c2-15",
+			"estimated_monthly_cost":          "186",
+			"estimated_monthly_cost_currency": "EUR",
+		},
+	}
+	raw := testVaultClusterRawConfig()
+	raw["node_count"] = 5
+
+	diff, err := resourceVaultCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	attr, ok := diff.Attributes["estimated_monthly_cost"]
+	if !ok || attr.New != "310" {
+		t.Errorf("expected estimated_monthly_cost to be planned as 310, got %+v", attr)
+	}
+}
+
+// TestNomadCluster_planEstimatedMonthlyCost checks that the cost of a Nomad
+// cluster without autoscaling is planned when its node counts change
+func TestNomadCluster_planEstimatedMonthlyCost(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, testPublicCloudCatalog, nil)
+
+	config := mock.NewConfig(t)
+	config.Endpoint = "ovh-eu"
+
+	state := &sdkterraform.InstanceState{
+		ID: "nomad-123",
+		Attributes: map[string]string{
+			"id":                              "nomad-123",
+			"name":                            "test-nomad",
+			"region":                          "GRA",
+			"server_count":                    "3",
+			"client_count":                    "0",
+			"instance_type":                   "c2-15",
+			"datacenter":                      "gra",
+			"estimated_monthly_cost":          "186",
+			"estimated_monthly_cost_currency": "EUR",
+		},
+	}
+	raw := testNomadClusterUpdateRaw()
+	raw["server_count"] = 5
+	raw["client_count"] = 0
+
+	diff, err := resourceNomadCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	attr, ok := diff.Attributes["estimated_monthly_cost"]
+	if !ok || attr.New != "310" {
+		t.Errorf("expected estimated_monthly_cost to be planned as 310, got %+v", attr)
+	}
+}
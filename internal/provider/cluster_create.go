@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func pendingCreateStepsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Steps of the create that failed after the cluster was created, which the next apply resumes",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// createCluster POSTs clusterConfig to create a service cluster, retrying
+// while OVH is unreachable or answers with a server error, until the create
+// timeout. A POST that timed out may still have created the cluster, so every
+// attempt sends the same idempotency key. As OVH may not honor it, a retry
+// first looks the cluster up by name, and adopts it rather than creating a
+// duplicate. That lookup needs error_on_existing, whose check before the
+// first attempt guarantees that a cluster with the name was not already
+// there, so it is skipped for blue_green, where the replaced one still is.
+func createCluster(ctx context.Context, config *Config, d *schema.ResourceData, service, projectId string, clusterConfig map[string]interface{}) (map[string]interface{}, error) {
+	key, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate an idempotency key: %w", err)
+	}
+	name := d.Get("name").(string)
+
+	var result map[string]interface{}
+	attempts := 0
+	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		if attempts > 0 && d.Get("error_on_existing").(bool) && !isBlueGreen(d) {
+			cluster, err := findClusterByName(config, service, name, projectId)
+			if err == nil && cluster != nil {
+				tflog.Info(ctx, "Found the cluster created by a failed attempt", map[string]any{
+					"name": name,
+					"id":   getString(cluster, "id"),
+				})
+				result = cluster
+				return nil
+			}
+		}
+		attempts++
+
+		result = nil
+		err := config.OVHClient.PostIdempotent(fmt.Sprintf("/cloud/project/%s/cluster", service), key, clusterConfig, &result)
+		if isOVHUnavailable(err) {
+			tflog.Warn(ctx, "Retrying cluster create", map[string]any{
+				"name":    name,
+				"attempt": attempts,
+				"error":   err.Error(),
+			})
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// createdClusterId returns the ID of a cluster returned by a create call, or
+// an error when there is none, before anything was stored in state.
+func createdClusterId(kind string, result map[string]interface{}) (string, error) {
+	clusterId := getString(result, "id")
+	if clusterId == "" {
+		return "", fmt.Errorf("failed to create %s cluster: no cluster ID returned", kind)
+	}
+	return clusterId, nil
+}
+
+// readAfterCreate reads a cluster that was just created. Read forgets a
+// cluster it cannot read, which here would orphan it, so a failed read keeps
+// the cluster in state with a warning and the next refresh reads it again.
+func readAfterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, kind string, read schema.ReadContextFunc) diag.Diagnostics {
+	clusterId := d.Id()
+
+	diags := read(ctx, d, meta)
+	if !diags.HasError() {
+		return diags
+	}
+
+	d.SetId(clusterId)
+	summaries := make([]string, 0, len(diags))
+	for _, diagnostic := range diags {
+		summaries = append(summaries, diagnostic.Summary)
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s cluster was created but could not be read", kind),
+			Detail:   fmt.Sprintf("%s cluster %s was created but reading it failed: %s. It has been kept in state and is read again by the next plan or apply.", kind, clusterId, strings.Join(summaries, "; ")),
+		},
+	}
+}
+
+// resumeCreateLater records step as pending on a cluster whose create failed
+// after the cluster itself was created. An error would taint the cluster and
+// the next apply would replace it, so it is kept in state with a warning and
+// planPendingCreateSteps plans an update, which runs the step again.
+func resumeCreateLater(d *schema.ResourceData, kind, step string, err error) diag.Diagnostics {
+	d.Set("pending_create_steps", []string{step})
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s cluster was created but not fully configured", kind),
+			Detail:   fmt.Sprintf("%s cluster %s was created but its %s step failed: %s. It has been kept in state, run terraform apply again to resume its configuration.", kind, d.Id(), step, err),
+		},
+	}
+}
+
+// planPendingCreateSteps plans an update of a cluster with pending create
+// steps, so that the next apply resumes them.
+func planPendingCreateSteps(d *schema.ResourceDiff) error {
+	if d.Id() == "" || len(d.Get("pending_create_steps").([]interface{})) == 0 {
+		return nil
+	}
+	return d.SetNew("pending_create_steps", []string{})
+}
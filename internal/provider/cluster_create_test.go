@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestConsulClusterCreate_readFailure checks that a cluster which cannot be
+// read right after its create is kept in state with a warning
+func TestConsulClusterCreate_readFailure(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "consul-123"}`, nil)
+	mock.AddResponse(500, `{"message": "Internal server error"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+
+	diags := resourceConsulClusterCreate(context.Background(), d, mock.NewConfig(t))
+	if diags.HasError() {
+		t.Fatalf("expected only a warning, got %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning, got %v", diags)
+	}
+	if d.Id() != "consul-123" {
+		t.Errorf("expected the cluster to stay in state, got ID %q", d.Id())
+	}
+}
+
+// TestConsulClusterCreate_noClusterId checks that a create response without
+// a cluster ID fails cleanly
+func TestConsulClusterCreate_noClusterId(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+
+	if diags := resourceConsulClusterCreate(context.Background(), d, mock.NewConfig(t)); !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no ID, got %q", d.Id())
+	}
+}
+
+// TestConsulClusterCreate_dryRun checks that a dry run still checks the
+// cluster against OVH but sends no request that changes anything, and
+// describes the create it skipped
+func TestConsulClusterCreate_dryRun(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	config := mock.NewConfig(t)
+	config.OVHClient.dryRun = true
+
+	diags := resourceConsulClusterCreate(context.Background(), d, config)
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if got := diags[0].Summary; got != "Consul cluster not created in a dry run" {
+		t.Errorf("expected a dry run error, got %q", got)
+	}
+	if !strings.Contains(diags[0].Detail, "POST /cloud/project/consul/cluster") || !strings.Contains(diags[0].Detail, `"name": "test-consul"`) {
+		t.Errorf("expected the skipped create to be described, got %q", diags[0].Detail)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected nothing in state, got ID %q", d.Id())
+	}
+
+	if mock.GetRequestCount() == 0 {
+		t.Error("expected the existing cluster check to be run")
+	}
+	for _, r := range mock.Requests {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected only reads, got %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+// TestNomadClusterCreate_resumeIntegrationTokens checks that a failed token
+// request keeps the cluster in state with the step pending, and that the next
+// apply plans an update which requests the token again
+func TestNomadClusterCreate_resumeIntegrationTokens(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "operationId": "op-1"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(503, `{"message": "Service unavailable"}`, nil)
+
+	raw := map[string]interface{}{
+		"name":               "test-nomad",
+		"region":             "GRA",
+		"server_count":       3,
+		"client_count":       3,
+		"instance_type":      "c2-15",
+		"vault_integration":  false,
+		"consul_integration": true,
+	}
+	r := resourceNomadCluster()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+	config := mock.NewConfig(t)
+	config.PollInterval = time.Millisecond
+	diags := resourceNomadClusterCreate(context.Background(), d, config)
+	if diags.HasError() {
+		t.Fatalf("expected only a warning, got %v", diags)
+	}
+	if d.Id() != "nomad-123" {
+		t.Fatalf("expected the cluster to stay in state, got ID %q", d.Id())
+	}
+	if got := d.Get("pending_create_steps").([]interface{}); len(got) != 1 || got[0] != nomadStepIntegrationTokens {
+		t.Fatalf("expected the token step to be pending, got %v", got)
+	}
+
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if diff == nil || diff.Empty() || diff.RequiresNew() {
+		t.Fatalf("expected an in-place update to resume the create, got %#v", diff)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	mock.AddResponse(200, `{"id": "nomad-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"token": "consul-token"}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "name": "test-nomad", "status": "READY", "consulIntegration": true}`, nil)
+
+	if diags := resourceNomadClusterUpdate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("consul_token").(string); got != "consul-token" {
+		t.Errorf("expected consul_token consul-token, got %q", got)
+	}
+	if got := d.Get("pending_create_steps").([]interface{}); len(got) != 0 {
+		t.Errorf("expected no pending steps, got %v", got)
+	}
+}
+
+// countPosts returns the create POSTs received by mock and their idempotency
+// keys.
+func countPosts(mock *MockHTTPServer, path string) (int, []string) {
+	var keys []string
+	for _, r := range mock.Requests {
+		if r.Method == http.MethodPost && r.URL.Path == path {
+			keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		}
+	}
+	return len(keys), keys
+}
+
+// TestVaultClusterCreate_postTimeout checks that a create POST which timed
+// out client-side but succeeded server-side is not sent again, and that the
+// cluster it created is found by name instead
+func TestVaultClusterCreate_postTimeout(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddDelayedResponse(200, `{"id": "vault-123", "name": "test-vault"}`, 500*time.Millisecond)
+	mock.AddResponse(200, `[{"id": "vault-123", "name": "test-vault", "status": "CREATING"}]`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["ui_allowed_cidrs"] = []interface{}{"10.0.0.0/8"}
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+	config := mock.NewConfig(t)
+	config.OVHClient.client.Timeout = 100 * time.Millisecond
+	if diags := resourceVaultClusterCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "vault-123" {
+		t.Errorf("expected the cluster created by the first attempt, got ID %q", d.Id())
+	}
+
+	count, keys := countPosts(mock, "/cloud/project/vault/cluster")
+	if count != 1 {
+		t.Fatalf("expected a single create POST, got %d", count)
+	}
+	if keys[0] == "" {
+		t.Errorf("expected the create POST to carry an idempotency key")
+	}
+}
+
+// TestVaultClusterCreate_retrySameKey checks that a create POST failing with
+// a server error is retried with the same idempotency key
+func TestVaultClusterCreate_retrySameKey(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(503, `{"message": "Service unavailable"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["ui_allowed_cidrs"] = []interface{}{"10.0.0.0/8"}
+	raw["error_on_existing"] = false
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+	if diags := resourceVaultClusterCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "vault-123" {
+		t.Errorf("expected ID vault-123, got %q", d.Id())
+	}
+
+	count, keys := countPosts(mock, "/cloud/project/vault/cluster")
+	if count != 2 {
+		t.Fatalf("expected the create POST to be retried once, got %d POSTs", count)
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected both attempts to carry the same idempotency key, got %v", keys)
+	}
+}
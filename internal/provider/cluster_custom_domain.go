@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fqdnLabelPattern matches one label of a domain name: letters, digits and
+// inner hyphens, at most 63 characters.
+var fqdnLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func customDomainSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "Domain name to serve the cluster endpoint under, such as vault.internal.example.com. Its DNS zone must be delegated to OVH",
+		ValidateFunc: validateFQDN,
+	}
+}
+
+func customEndpointSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "URL of the cluster endpoint under custom_domain, empty when custom_domain is not set",
+	}
+}
+
+// validateFQDN checks that a string attribute is a fully qualified domain
+// name in lower case, with at least two labels and no trailing dot.
+func validateFQDN(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	labels := strings.Split(value, ".")
+	valid := len(value) <= 253 && len(labels) >= 2
+	for _, label := range labels {
+		valid = valid && fqdnLabelPattern.MatchString(label)
+	}
+	// Top-level domains are never all-numeric, which rules out IP addresses.
+	valid = valid && strings.Trim(labels[len(labels)-1], "0123456789") != ""
+
+	if !valid {
+		errors = append(errors, fmt.Errorf("%s must be a fully qualified domain name in lower case such as vault.example.com, got %q", k, value))
+	}
+	return
+}
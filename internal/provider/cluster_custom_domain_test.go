@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestValidateFQDN(t *testing.T) {
+	cases := map[string]bool{
+		"vault.internal.example.com": true,
+		"nomad-1.example.io":         true,
+		"example.com":                true,
+		"localhost":                  false,
+		"vault.example.com.":         false,
+		"Vault.Example.com":          false,
+		"-vault.example.com":         false,
+		"vault-.example.com":         false,
+		"vault..example.com":         false,
+		"vault_1.example.com":        false,
+		"10.0.0.1":                   false,
+		"https://vault.example.com":  false,
+		"":                           false,
+	}
+
+	for domain, valid := range cases {
+		_, errs := validateFQDN(domain, "custom_domain")
+		if (len(errs) == 0) != valid {
+			t.Errorf("domain %q: expected valid=%t, got errors %v", domain, valid, errs)
+		}
+	}
+}
+
+// TestVaultClusterRead_customEndpoint checks that the endpoint provisioned
+// under custom_domain is read back
+func TestVaultClusterRead_customEndpoint(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY", "customDomain": "vault.internal.example.com", "customEndpoint": "https://vault.internal.example.com:8200"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["custom_domain"] = "vault.internal.example.com"
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+	d.SetId("vault-123")
+
+	if diags := resourceVaultClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("custom_domain").(string); got != "vault.internal.example.com" {
+		t.Errorf("expected custom_domain vault.internal.example.com, got %q", got)
+	}
+	if got := d.Get("custom_endpoint").(string); got != "https://vault.internal.example.com:8200" {
+		t.Errorf("expected custom_endpoint https://vault.internal.example.com:8200, got %q", got)
+	}
+}
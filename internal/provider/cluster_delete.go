@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// clusterDeletePollInterval is how often a cluster is polled while waiting for
+// its deletion to complete, unless poll_interval is set on the provider.
+var clusterDeletePollInterval = 30 * time.Second
+
+// deleteCluster deletes a cluster of the given service (nomad, vault, consul or
+// boundary) and waits until it is gone. With forceDestroy, deletion protection
+// is disabled and dependent resources are detached first.
+func deleteCluster(ctx context.Context, config *Config, service, clusterId string, forceDestroy bool, timeout time.Duration) error {
+	path := fmt.Sprintf("/cloud/project/%s/cluster/%s", service, clusterId)
+
+	if forceDestroy {
+		if err := config.OVHClient.Put(path, map[string]interface{}{"deletionProtection": false}, nil); err != nil {
+			return fmt.Errorf("failed to disable deletion protection: %w", err)
+		}
+		if err := config.OVHClient.Post(path+"/detachDependents", nil, nil); err != nil {
+			return fmt.Errorf("failed to detach dependent resources: %w", err)
+		}
+	}
+
+	var result map[string]interface{}
+	if err := config.OVHClient.Delete(path, &result); err != nil {
+		if !forceDestroy && isOVHErrorCode(err, http.StatusForbidden, http.StatusConflict, http.StatusPreconditionFailed) {
+			return fmt.Errorf("cluster %s cannot be deleted while deletion protection is enabled or resources are attached, set force_destroy = true to remove them: %w", clusterId, err)
+		}
+		return err
+	}
+
+	// The operation reports a failed teardown, which polling the cluster
+	// alone would only surface as a timeout.
+	if operationId := getString(result, "operationId"); operationId != "" {
+		if err := waitForOperation(ctx, config, service, clusterId, operationId, true); err != nil {
+			return err
+		}
+	}
+
+	return waitForClusterDeleted(ctx, config, path, timeout)
+}
+
+// waitForClusterDeleted polls path until the API answers 404, as OVH tears
+// resources down asynchronously after accepting the DELETE. Transient errors
+// are retried, and other errors, such as expired credentials, returned.
+func waitForClusterDeleted(ctx context.Context, config *Config, path string, timeout time.Duration) error {
+	last := "status was unknown"
+	return poll(ctx, path+" to be deleted", timeout, config.pollInterval(clusterDeletePollInterval), func() (string, error) {
+		var object map[string]interface{}
+		err := config.OVHClient.Get(path, &object)
+		switch {
+		case isOVHErrorCode(err, http.StatusNotFound):
+			return "", nil
+		case err == nil:
+			last = fmt.Sprintf("status was %s", getString(object, "status"))
+		case !isTransientOVHError(err):
+			return "", fmt.Errorf("failed to check that %s was deleted: %w", path, err)
+		}
+		return last, nil
+	})
+}
+
+// isOVHErrorCode reports whether err is an OVH API error with one of the given HTTP status codes.
+func isOVHErrorCode(err error, codes ...int) bool {
+	var apiErr *ovh.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if apiErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransientOVHError reports whether err may not happen again when the
+// call is retried: the API was unavailable or rate limited the call. A server
+// error that persists opens the circuit breaker, whose error is not transient.
+func isTransientOVHError(err error) bool {
+	return isOVHUnavailable(err) || isOVHErrorCode(err, http.StatusTooManyRequests)
+}
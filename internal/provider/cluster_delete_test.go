@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const testProtectedClusterError = `{"class": "Client::Conflict", "message": "cluster has deletion protection enabled"}`
+
+func TestVaultClusterDelete_protected(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(409, testProtectedClusterError, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, testVaultClusterRawConfig())
+	d.SetId("vault-123")
+
+	diags := resourceVaultClusterDelete(context.Background(), d, mock.NewConfig(t))
+	if !diags.HasError() {
+		t.Fatal("expected an error when deleting a protected cluster")
+	}
+	if !strings.Contains(diags[0].Summary, "force_destroy") {
+		t.Errorf("expected the error to mention force_destroy, got: %s", diags[0].Summary)
+	}
+	if d.Id() == "" {
+		t.Error("expected the ID to be kept when deletion fails")
+	}
+}
+
+func TestVaultClusterDelete_forceDestroy(t *testing.T) {
+	interval := clusterDeletePollInterval
+	clusterDeletePollInterval = 10 * time.Millisecond
+	defer func() { clusterDeletePollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(404, `{"message": "cluster not found"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["force_destroy"] = true
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+	d.SetId("vault-123")
+
+	if diags := resourceVaultClusterDelete(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the ID to be cleared, got %q", d.Id())
+	}
+
+	expected := []string{
+		http.MethodPut + " /cloud/project/vault/cluster/vault-123",
+		http.MethodPost + " /cloud/project/vault/cluster/vault-123/detachDependents",
+		http.MethodDelete + " /cloud/project/vault/cluster/vault-123",
+		http.MethodGet + " /cloud/project/vault/cluster/vault-123",
+	}
+	if len(mock.Requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, r := range mock.Requests {
+		if got := r.Method + " " + r.URL.Path; got != expected[i] {
+			t.Errorf("request %d: expected %q, got %q", i, expected[i], got)
+		}
+	}
+}
+
+func TestPackerTemplateDelete_waitsForDeletion(t *testing.T) {
+	interval := clusterDeletePollInterval
+	clusterDeletePollInterval = 10 * time.Millisecond
+	defer func() { clusterDeletePollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "template-123", "status": "DELETING"}`, nil)
+	mock.AddResponse(404, `{"message": "template not found"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourcePackerTemplate().Schema, map[string]interface{}{
+		"name":         "test-template",
+		"source_image": "ubuntu-22.04",
+	})
+	d.SetId("template-123")
+
+	if diags := resourcePackerTemplateDelete(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the ID to be cleared, got %q", d.Id())
+	}
+	if got := mock.GetRequestCount(); got != 3 {
+		t.Errorf("expected the DELETE and two polls, got %d requests", got)
+	}
+}
+
+// TestWaitForClusterDeleted_errors checks that the deletion wait retries
+// transient errors and returns the others right away
+func TestWaitForClusterDeleted_errors(t *testing.T) {
+	interval := clusterDeletePollInterval
+	clusterDeletePollInterval = 10 * time.Millisecond
+	defer func() { clusterDeletePollInterval = interval }()
+
+	t.Run("transient", func(t *testing.T) {
+		mock := NewMockHTTPServer()
+		defer mock.Close()
+
+		mock.AddResponse(503, `{"message": "Service unavailable"}`, nil)
+		mock.AddResponse(429, `{"message": "Too many requests"}`, nil)
+		mock.AddResponse(404, `{"message": "cluster not found"}`, nil)
+
+		if err := waitForClusterDeleted(context.Background(), mock.NewConfig(t), "/cloud/project/vault/cluster/vault-123", time.Minute); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := mock.GetRequestCount(); got != 3 {
+			t.Errorf("expected the transient errors to be retried, got %d requests", got)
+		}
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		mock := NewMockHTTPServer()
+		defer mock.Close()
+
+		mock.AddResponse(403, `{"message": "This call has not been granted"}`, nil)
+
+		err := waitForClusterDeleted(context.Background(), mock.NewConfig(t), "/cloud/project/vault/cluster/vault-123", time.Minute)
+		if err == nil || !strings.Contains(err.Error(), "not been granted") {
+			t.Fatalf("expected the permission error, got %v", err)
+		}
+		if got := mock.GetRequestCount(); got != 1 {
+			t.Errorf("expected no retry, got %d requests", got)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func drainOnScaleInSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Drain the client nodes removed when client_count decreases before they are terminated, so their workloads move to the remaining nodes",
+	}
+}
+
+func drainTimeoutSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "15m",
+		Description:  "How long a drained client node waits for its workloads to move before they are stopped, as a duration such as 15m",
+		ValidateFunc: validateDuration,
+	}
+}
+
+// drainClientsOnScaleIn drains the client nodes that a decrease of
+// client_count removes, unless drain_on_scale_in is false. Nomad reschedules
+// the allocations of drained nodes and Consul agents leave the cluster
+// gracefully, before the update terminates the nodes. It returns the IDs of
+// the drained nodes, which the update must remove.
+func drainClientsOnScaleIn(ctx context.Context, config *Config, d *schema.ResourceData, service string) ([]interface{}, error) {
+	if !d.Get("drain_on_scale_in").(bool) || !d.HasChange("client_count") {
+		return nil, nil
+	}
+	oldCount, newCount := d.GetChange("client_count")
+	count := oldCount.(int) - newCount.(int)
+	if count <= 0 {
+		return nil, nil
+	}
+
+	deadline, err := time.ParseDuration(d.Get("drain_timeout").(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid drain_timeout: %w", err)
+	}
+
+	if err := waitForClusterReady(ctx, config, service, d.Id()); err != nil {
+		return nil, fmt.Errorf("cluster is not ready for draining: %w", err)
+	}
+
+	drainConfig := map[string]interface{}{
+		"count":           count,
+		"deadlineSeconds": int(deadline.Seconds()),
+	}
+	var result map[string]interface{}
+	err = config.OVHClient.Post(fmt.Sprintf("/cloud/project/%s/cluster/%s/client/drain", service, d.Id()), drainConfig, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to drain %d client nodes: %w", count, err)
+	}
+	if err := waitForClusterOperation(ctx, config, service, d.Id(), getString(result, "operationId")); err != nil {
+		return nil, fmt.Errorf("failed to drain %d client nodes: %w", count, err)
+	}
+
+	nodes, _ := result["nodes"].([]interface{})
+	return nodes, nil
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestConsulClusterUpdate_drainOnScaleIn checks that the client nodes removed
+// by a scale-in are drained before the update, which then removes them
+func TestConsulClusterUpdate_drainOnScaleIn(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-1", "nodes": ["node-4", "node-5"]}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-2"}`, nil)
+	mock.AddResponse(200, `{"id": "op-2", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "clientCount": 1}`, nil)
+
+	raw := testConsulClusterRawConfig()
+	raw["client_count"] = 1
+	raw["drain_timeout"] = "5m"
+	d := testConsulClusterUpdateData(t, raw)
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []struct{ method, path string }{
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123"},
+		{http.MethodPost, "/cloud/project/consul/cluster/consul-123/client/drain"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123/operation/op-1"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123"},
+		{http.MethodPut, "/cloud/project/consul/cluster/consul-123"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123/operation/op-2"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123"},
+	}
+	if len(mock.Requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, r := range mock.Requests {
+		if r.Method != expected[i].method || r.URL.Path != expected[i].path {
+			t.Errorf("request %d: expected %s %s, got %s %s", i, expected[i].method, expected[i].path, r.Method, r.URL.Path)
+		}
+	}
+	if body := mock.RequestBodies[1]; body != `{"count":2,"deadlineSeconds":300}` {
+		t.Errorf("expected 2 nodes to be drained within 300 seconds, got %s", body)
+	}
+	if body := mock.RequestBodies[4]; !strings.Contains(body, `"removedNodes":["node-4","node-5"]`) {
+		t.Errorf("expected the drained nodes to be removed, got %s", body)
+	}
+}
+
+// TestConsulClusterUpdate_noDrain checks that scaling out, or scaling in with
+// drain_on_scale_in disabled, updates the client count without draining
+func TestConsulClusterUpdate_noDrain(t *testing.T) {
+	cases := map[string]struct {
+		clientCount    int
+		drainOnScaleIn bool
+	}{
+		"scale out":             {clientCount: 5, drainOnScaleIn: true},
+		"scale in, no draining": {clientCount: 1, drainOnScaleIn: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+			mock.AddResponse(200, `{"operationId": "op-1"}`, nil)
+			mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+			mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY"}`, nil)
+
+			raw := testConsulClusterRawConfig()
+			raw["client_count"] = tc.clientCount
+			raw["drain_on_scale_in"] = tc.drainOnScaleIn
+			d := testConsulClusterUpdateData(t, raw)
+
+			if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			for _, r := range mock.Requests {
+				if strings.HasSuffix(r.URL.Path, "/client/drain") {
+					t.Fatal("expected no drain request")
+				}
+			}
+			if body := mock.RequestBodies[1]; strings.Contains(body, "removedNodes") {
+				t.Errorf("expected no removed nodes, got %s", body)
+			}
+		})
+	}
+}
+
+// TestConsulClusterUpdate_drainFailure checks that a scale-in whose drain or
+// update fails keeps the previous client_count in state
+func TestConsulClusterUpdate_drainFailure(t *testing.T) {
+	cases := map[string][]string{
+		"drain":  {`{"id": "consul-123", "status": "READY"}`},
+		"update": {`{"id": "consul-123", "status": "READY"}`, `{"operationId": "op-1", "nodes": ["node-4", "node-5"]}`, `{"id": "op-1", "status": "DONE"}`, `{"id": "consul-123", "status": "READY"}`},
+	}
+
+	for name, responses := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			for _, response := range responses {
+				mock.AddResponse(200, response, nil)
+			}
+			mock.AddResponse(403, `{"message": "This call has not been granted"}`, nil)
+
+			raw := testConsulClusterRawConfig()
+			raw["client_count"] = 1
+			d := testConsulClusterUpdateData(t, raw)
+
+			if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			if got := d.State().Attributes["client_count"]; got != "3" {
+				t.Errorf("expected client_count to stay 3, got %q", got)
+			}
+		})
+	}
+}
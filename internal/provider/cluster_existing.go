@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func errorOnExistingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Fail the create when a cluster with the same name already exists in the project, suggesting to import it, rather than creating a second cluster with that name",
+	}
+}
+
+// checkExistingCluster fails the create of a service cluster, named kind in
+// errors, when one with the same name already exists in projectId, for
+// example because it was created out of band, unless error_on_existing is
+// false. A blue_green create expects the cluster it replaces under the name,
+// see setPreviousCluster. A failed lookup is only logged, so that the check
+// never blocks a create the API would accept.
+func checkExistingCluster(ctx context.Context, config *Config, d *schema.ResourceData, service, kind, projectId string) diag.Diagnostics {
+	if !d.Get("error_on_existing").(bool) || isBlueGreen(d) {
+		return nil
+	}
+	name := d.Get("name").(string)
+
+	cluster, err := findClusterByName(config, service, name, projectId)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to check for an existing cluster with the same name", map[string]any{
+			"name":  name,
+			"error": err.Error(),
+		})
+		return nil
+	}
+	if cluster == nil {
+		return nil
+	}
+
+	id := getString(cluster, "id")
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s cluster %q already exists", kind, name),
+		Detail: fmt.Sprintf("The cluster %s already has this name. Import it to manage it with Terraform:\n\n"+
+			"  terraform import %s.<name> %s\n\n"+
+			"or set error_on_existing = false to create another cluster with the same name.", id, fmt.Sprintf("hashicorp_ovh_%s_cluster", service), id),
+	}}
+}
+
+// findClusterByName returns the service cluster named name in projectId, or
+// nil when there is none.
+func findClusterByName(config *Config, service, name, projectId string) (map[string]interface{}, error) {
+	var clusters []map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/%s/cluster?name=%s", service, url.QueryEscape(name)), &clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		if getString(cluster, "name") != name {
+			continue
+		}
+		if clusterProject := getString(cluster, "projectId"); clusterProject != "" && projectId != "" && clusterProject != projectId {
+			continue
+		}
+		return cluster, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestVaultClusterCreate_existingName checks that a cluster is not created
+// when one with the same name exists in the project, unless
+// error_on_existing is false
+func TestVaultClusterCreate_existingName(t *testing.T) {
+	cases := map[string]struct {
+		clusters        string
+		errorOnExisting bool
+		expectErr       bool
+	}{
+		"duplicate name":           {clusters: `[{"id": "vault-999", "name": "test-vault"}]`, errorOnExisting: true, expectErr: true},
+		"duplicate allowed":        {clusters: `[{"id": "vault-999", "name": "test-vault"}]`},
+		"name prefix only":         {clusters: `[{"id": "vault-999", "name": "test-vault-2"}]`, errorOnExisting: true},
+		"other project":            {clusters: `[{"id": "vault-999", "name": "test-vault", "projectId": "def456"}]`, errorOnExisting: true},
+		"no cluster with the name": {clusters: `[]`, errorOnExisting: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			if tc.errorOnExisting {
+				mock.AddResponse(200, tc.clusters, nil)
+			}
+			mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+			mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY"}`, nil)
+
+			raw := testVaultClusterRawConfig()
+			raw["error_on_existing"] = tc.errorOnExisting
+			d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "abc123"
+			diags := resourceVaultClusterCreate(context.Background(), d, config)
+
+			if !tc.expectErr {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				if d.Id() != "vault-123" {
+					t.Errorf("expected the cluster to be created, got ID %q", d.Id())
+				}
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatal("expected an error for a duplicate name")
+			}
+			if detail := diags[0].Detail; !strings.Contains(detail, "terraform import hashicorp_ovh_vault_cluster.<name> vault-999") {
+				t.Errorf("expected an import suggestion with the existing ID, got %q", detail)
+			}
+			for _, r := range mock.Requests {
+				if r.Method == http.MethodPost {
+					t.Fatalf("expected no cluster to be created, got POST %s", r.URL.Path)
+				}
+			}
+			if got := mock.Requests[0].URL.Query().Get("name"); got != "test-vault" {
+				t.Errorf("expected clusters to be listed by name, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var maintenanceDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// maintenanceWindowSchema describes the weekly window OVH confines disruptive
+// node maintenance and upgrades to.
+func maintenanceWindowSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Weekly window during which OVH may perform disruptive node maintenance, maintenance can happen at any time when unset",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"day_of_week": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Day the window starts on (monday to sunday)",
+					ValidateFunc: validation.StringInSlice(maintenanceDays, false),
+				},
+				"start_hour": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					Description:  "Hour of the day the window starts at, in UTC",
+					ValidateFunc: validateIntBetween(0, 23),
+				},
+				"duration_hours": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      4,
+					Description:  "Length of the window in hours",
+					ValidateFunc: validateIntBetween(1, 24),
+				},
+			},
+		},
+	}
+}
+
+func nextMaintenanceAtSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Start of the next scheduled maintenance in RFC 3339 format, empty when none is scheduled",
+	}
+}
+
+// expandMaintenanceWindow returns nil when no window is configured, which
+// clears a previously set window on update.
+func expandMaintenanceWindow(l []interface{}) map[string]interface{} {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	raw := l[0].(map[string]interface{})
+	return map[string]interface{}{
+		"dayOfWeek":     raw["day_of_week"].(string),
+		"startHour":     raw["start_hour"].(int),
+		"durationHours": raw["duration_hours"].(int),
+	}
+}
+
+func flattenMaintenanceWindow(cluster map[string]interface{}) []interface{} {
+	window, ok := cluster["maintenanceWindow"].(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"day_of_week":    getString(window, "dayOfWeek"),
+			"start_hour":     getInt(window, "startHour"),
+			"duration_hours": getInt(window, "durationHours"),
+		},
+	}
+}
+
+// setMaintenanceWindow reads the maintenance window back from a cluster API
+// response. Regions without scheduled maintenance omit maintenanceWindow, in
+// which case the configured block is kept.
+func setMaintenanceWindow(d *schema.ResourceData, cluster map[string]interface{}) {
+	if _, ok := cluster["maintenanceWindow"]; ok {
+		d.Set("maintenance_window", flattenMaintenanceWindow(cluster))
+	}
+	d.Set("next_maintenance_at", getString(cluster, "nextMaintenanceAt"))
+}
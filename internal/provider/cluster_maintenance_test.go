@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestMaintenanceWindow_validation(t *testing.T) {
+	cases := map[string]struct {
+		window      map[string]interface{}
+		expectError bool
+	}{
+		"valid": {
+			window: map[string]interface{}{"day_of_week": "sunday", "start_hour": 2, "duration_hours": 4},
+		},
+		"default duration": {
+			window: map[string]interface{}{"day_of_week": "monday", "start_hour": 0},
+		},
+		"unknown day": {
+			window:      map[string]interface{}{"day_of_week": "Sun", "start_hour": 2},
+			expectError: true,
+		},
+		"hour too large": {
+			window:      map[string]interface{}{"day_of_week": "sunday", "start_hour": 24},
+			expectError: true,
+		},
+		"negative hour": {
+			window:      map[string]interface{}{"day_of_week": "sunday", "start_hour": -1},
+			expectError: true,
+		},
+		"zero duration": {
+			window:      map[string]interface{}{"day_of_week": "sunday", "start_hour": 2, "duration_hours": 0},
+			expectError: true,
+		},
+		"duration too long": {
+			window:      map[string]interface{}{"day_of_week": "sunday", "start_hour": 2, "duration_hours": 25},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testVaultClusterRawConfig()
+			raw["maintenance_window"] = []interface{}{tc.window}
+
+			diags := resourceVaultCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if tc.expectError && !diags.HasError() {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && diags.HasError() {
+				t.Errorf("unexpected error: %v", diags)
+			}
+		})
+	}
+}
+
+func TestExpandMaintenanceWindow(t *testing.T) {
+	got := expandMaintenanceWindow([]interface{}{
+		map[string]interface{}{"day_of_week": "saturday", "start_hour": 22, "duration_hours": 3},
+	})
+	expected := map[string]interface{}{"dayOfWeek": "saturday", "startHour": 22, "durationHours": 3}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := expandMaintenanceWindow([]interface{}{}); got != nil {
+		t.Errorf("expected nil for an unset window, got %v", got)
+	}
+}
+
+func TestFlattenMaintenanceWindow(t *testing.T) {
+	got := flattenMaintenanceWindow(map[string]interface{}{
+		"maintenanceWindow": map[string]interface{}{"dayOfWeek": "saturday", "startHour": 22.0, "durationHours": 3.0},
+	})
+	expected := []interface{}{
+		map[string]interface{}{"day_of_week": "saturday", "start_hour": 22, "duration_hours": 3},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
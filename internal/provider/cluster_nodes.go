@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterNodesSchema describes the computed per-node details shared by the cluster resources.
+func clusterNodesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Details of each node in the cluster",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Node ID",
+				},
+				"role": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Node role, such as server, client, controller or worker",
+				},
+				"private_ip": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Private IP address of the node",
+				},
+				"public_ip": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Public IP address of the node",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Node status",
+				},
+				"instance_type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "OVH instance type of the node",
+				},
+			},
+		},
+	}
+}
+
+// flattenClusterNodes converts the "nodes" list of a cluster API response into
+// the shape of clusterNodesSchema.
+func flattenClusterNodes(cluster map[string]interface{}) []interface{} {
+	items, ok := cluster["nodes"].([]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+
+	nodes := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		node, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		nodes = append(nodes, map[string]interface{}{
+			"id":            getString(node, "id"),
+			"role":          getString(node, "role"),
+			"private_ip":    getString(node, "privateIp"),
+			"public_ip":     getString(node, "publicIp"),
+			"status":        getString(node, "status"),
+			"instance_type": getString(node, "instanceType"),
+		})
+	}
+	return nodes
+}
+
+// completeClusterNodes replaces the nodes of a cluster API response with the
+// list of its node endpoint, every page of it, when the response holds fewer
+// nodes than the cluster has: OVH only embeds the first page of the nodes of
+// large clusters. serverEndpoints is completed from the endpoints of the
+// server nodes in the same way. Responses that do not report nodes are left
+// as they are.
+func completeClusterNodes(config *Config, service, clusterId string, cluster map[string]interface{}) error {
+	embedded, ok := cluster["nodes"].([]interface{})
+	if !ok {
+		return nil
+	}
+	expected := 0
+	for _, field := range clusterNodeCountFields[service] {
+		expected += getInt(cluster, field)
+	}
+	if len(embedded) >= expected {
+		return nil
+	}
+
+	var nodes []map[string]interface{}
+	if err := config.OVHClient.GetAll(fmt.Sprintf("/cloud/project/%s/cluster/%s/node", service, clusterId), &nodes); err != nil {
+		return err
+	}
+	if len(nodes) <= len(embedded) {
+		return nil
+	}
+
+	items := make([]interface{}, len(nodes))
+	var endpoints []interface{}
+	for i, node := range nodes {
+		items[i] = node
+		if endpoint := getString(node, "endpoint"); getString(node, "role") == "server" && endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	cluster["nodes"] = items
+	if len(endpoints) > len(getStringList(cluster, "serverEndpoints")) {
+		cluster["serverEndpoints"] = endpoints
+	}
+	return nil
+}
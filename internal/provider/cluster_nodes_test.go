@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestBoundaryClusterRead_nodes(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "id": "boundary-123",
+  "name": "test-boundary",
+  "status": "READY",
+  "nodes": [
+    {"id": "node-1", "role": "controller", "privateIp": "10.0.0.10", "publicIp": "51.68.1.10", "status": "RUNNING", "instanceType": "c2-15"},
+    {"id": "node-2", "role": "worker", "privateIp": "10.0.0.20", "status": "RUNNING", "instanceType": "c2-15"}
+  ]
+}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceBoundaryCluster().Schema, testBoundaryClusterRawConfig())
+	d.SetId("boundary-123")
+
+	if diags := resourceBoundaryClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("nodes.#").(int); got != 2 {
+		t.Fatalf("expected 2 nodes, got %d", got)
+	}
+
+	expected := map[string]string{
+		"nodes.0.id":         "node-1",
+		"nodes.0.role":       "controller",
+		"nodes.0.private_ip": "10.0.0.10",
+		"nodes.0.public_ip":  "51.68.1.10",
+		"nodes.1.role":       "worker",
+		"nodes.1.public_ip":  "",
+	}
+	for key, value := range expected {
+		if got := d.Get(key).(string); got != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, got)
+		}
+	}
+}
+
+// TestConsulClusterRead_pagedNodes checks that a cluster response holding
+// fewer nodes than the cluster has is completed from every page of its node
+// endpoint, server endpoints included
+func TestConsulClusterRead_pagedNodes(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "id": "consul-123",
+  "status": "READY",
+  "serverCount": 3,
+  "clientCount": 1,
+  "serverEndpoints": ["10.0.0.1:8500"],
+  "nodes": [{"id": "node-1", "role": "server"}]
+}`, nil)
+	mock.AddResponse(200, `[
+  {"id": "node-1", "role": "server", "endpoint": "10.0.0.1:8500"},
+  {"id": "node-2", "role": "server", "endpoint": "10.0.0.2:8500"}
+]`, map[string]string{"X-Pagination-Cursor-Next": "page-2"})
+	mock.AddResponse(200, `[
+  {"id": "node-3", "role": "server", "endpoint": "10.0.0.3:8500"},
+  {"id": "node-4", "role": "client"}
+]`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	if diags := resourceConsulClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	pages := mock.Requests[1:3]
+	for i, r := range pages {
+		if r.URL.Path != "/cloud/project/consul/cluster/consul-123/node" {
+			t.Errorf("expected page %d to be read from the node endpoint, got %s", i+1, r.URL.Path)
+		}
+	}
+	if got := pages[0].Header.Get("X-Pagination-Cursor"); got != "" {
+		t.Errorf("expected the first page to be read without a cursor, got %q", got)
+	}
+	if got := pages[1].Header.Get("X-Pagination-Cursor"); got != "page-2" {
+		t.Errorf("expected the second page to be read with its cursor, got %q", got)
+	}
+
+	if got := d.Get("nodes.#").(int); got != 4 {
+		t.Errorf("expected 4 nodes, got %d", got)
+	}
+	if got := d.Get("nodes.3.role").(string); got != "client" {
+		t.Errorf("expected the last node to be the client, got %q", got)
+	}
+	endpoints := d.Get("server_endpoints").([]interface{})
+	if len(endpoints) != 3 || endpoints[2] != "10.0.0.3:8500" {
+		t.Errorf("expected the endpoints of the 3 servers, got %v", endpoints)
+	}
+}
+
+// TestFilterHealthyClusters_pagedNodes checks that the health of a large
+// cluster is checked against every page of its nodes, so that it is not
+// filtered out because OVH only embedded the first one
+func TestFilterHealthyClusters_pagedNodes(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY", "serverCount": 3, "nodes": [{"id": "node-1", "status": "RUNNING"}]}`, nil)
+	mock.AddResponse(200, `[{"id": "node-1", "status": "RUNNING"}, {"id": "node-2", "status": "RUNNING"}]`, map[string]string{"X-Pagination-Cursor-Next": "page-2"})
+	mock.AddResponse(200, `[{"id": "node-3", "status": "RUNNING"}]`, nil)
+
+	clusters := []map[string]interface{}{{"id": "consul-123", "status": "READY"}}
+	healthy, err := filterHealthyClusters(mock.NewConfig(t), "consul", clusters)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(healthy) != 1 {
+		t.Errorf("expected the cluster to be healthy, got %v", healthy)
+	}
+}
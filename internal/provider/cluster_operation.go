@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func lastOperationIdSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the last asynchronous OVH operation started on the cluster, as shown in the OVHcloud Control Panel",
+	}
+}
+
+// setLastOperationId records the operation ID returned by a create or update
+// call. Calls that did not start an operation leave the previous one in state.
+func setLastOperationId(d *schema.ResourceData, result map[string]interface{}) string {
+	operationId := getString(result, "operationId")
+	if operationId != "" {
+		d.Set("last_operation_id", operationId)
+	}
+	return operationId
+}
+
+// waitForClusterOperation waits for the operation started on a cluster to
+// complete, or for the cluster to be READY when the API returned no operation
+// ID.
+func waitForClusterOperation(ctx context.Context, config *Config, service, clusterId, operationId string) error {
+	if operationId == "" {
+		return waitForClusterReady(ctx, config, service, clusterId)
+	}
+	return waitForOperation(ctx, config, service, clusterId, operationId, false)
+}
+
+// waitForOperation polls an operation of a cluster until it is DONE, and
+// returns its error message if it fails. For a deletion, a 404 means the
+// cluster and its operations are gone, which completes it; for other
+// operations it is an error. Transient errors are retried, and other errors,
+// such as expired credentials, returned.
+func waitForOperation(ctx context.Context, config *Config, service, clusterId, operationId string, deletion bool) error {
+	path := fmt.Sprintf("/cloud/project/%s/cluster/%s/operation/%s", service, clusterId, operationId)
+	last := "status was unknown"
+	return poll(ctx, fmt.Sprintf("operation %s to complete", operationId), 30*time.Minute, config.pollInterval(clusterReadyPollInterval), func() (string, error) {
+		var operation map[string]interface{}
+		err := config.OVHClient.Get(path, &operation)
+		switch {
+		case isOVHErrorCode(err, http.StatusNotFound) && deletion:
+			return "", nil
+		case isOVHErrorCode(err, http.StatusNotFound):
+			return "", fmt.Errorf("operation %s of %s cluster %s was not found: %w", operationId, service, clusterId, err)
+		case isTransientOVHError(err):
+			return last, nil
+		case err != nil:
+			return "", fmt.Errorf("failed to read operation %s: %w", operationId, err)
+		}
+
+		switch status := getString(operation, "status"); status {
+		case "DONE":
+			return "", nil
+		case "ERROR", "CANCELLED":
+			return "", fmt.Errorf("operation %s %s: %s", operationId, status, getString(operation, "message"))
+		default:
+			last = fmt.Sprintf("status was %s", status)
+		}
+		return last, nil
+	})
+}
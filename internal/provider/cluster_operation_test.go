@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestConsulClusterUpdate_waitsForOperation checks that an update returning an
+// operation ID polls that operation rather than the cluster status
+func TestConsulClusterUpdate_waitsForOperation(t *testing.T) {
+	interval := clusterReadyPollInterval
+	clusterReadyPollInterval = 10 * time.Millisecond
+	defer func() { clusterReadyPollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-456"}`, nil)
+	mock.AddResponse(200, `{"id": "op-456", "status": "RUNNING"}`, nil)
+	mock.AddResponse(200, `{"id": "op-456", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "serverCount": 5}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.Requests[2].URL.Path; got != "/cloud/project/consul/cluster/consul-123/operation/op-456" {
+		t.Errorf("expected the operation to be polled, got %s", got)
+	}
+	if got := mock.GetRequestCount(); got != 5 {
+		t.Errorf("expected 5 requests, got %d", got)
+	}
+	if got := d.Get("last_operation_id").(string); got != "op-456" {
+		t.Errorf("expected last_operation_id op-456, got %q", got)
+	}
+}
+
+func TestWaitForOperation(t *testing.T) {
+	cases := map[string]struct {
+		statusCode  int
+		body        string
+		deletion    bool
+		expectError string
+	}{
+		"done":         {statusCode: 200, body: `{"id": "op-456", "status": "DONE"}`},
+		"failed":       {statusCode: 200, body: `{"id": "op-456", "status": "ERROR", "message": "quota exceeded"}`, expectError: "operation op-456 ERROR: quota exceeded"},
+		"deleted":      {statusCode: 404, body: `{"message": "This cluster does not exist"}`, deletion: true},
+		"not found":    {statusCode: 404, body: `{"message": "This operation does not exist"}`, expectError: "operation op-456 of vault cluster vault-123 was not found"},
+		"unauthorized": {statusCode: 401, body: `{"message": "Invalid credentials"}`, expectError: "failed to read operation op-456"},
+		"forbidden":    {statusCode: 403, body: `{"message": "This call has not been granted"}`, expectError: "failed to read operation op-456"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.statusCode, tc.body, nil)
+
+			err := waitForOperation(context.Background(), mock.NewConfig(t), "vault", "vault-123", "op-456", tc.deletion)
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Errorf("expected an error containing %q, got %v", tc.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestWaitForOperation_transientError checks that an operation wait keeps
+// polling through server errors
+func TestWaitForOperation_transientError(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(503, `{"message": "Service unavailable"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	config := mock.NewConfig(t)
+	config.PollInterval = 10 * time.Millisecond
+
+	if err := waitForOperation(context.Background(), config, "vault", "vault-123", "op-1", false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := mock.GetRequestCount(); got != 2 {
+		t.Errorf("expected the operation to be polled again, got %d requests", got)
+	}
+}
+
+// TestWaitForOperation_contextDeadline checks that an operation wait ends at
+// the deadline of the operation timeout with the last status observed
+func TestWaitForOperation_contextDeadline(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	for i := 0; i < 20; i++ {
+		mock.AddResponse(200, `{"id": "op-1", "status": "RUNNING"}`, nil)
+	}
+	config := mock.NewConfig(t)
+	config.PollInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := waitForOperation(ctx, config, "vault", "vault-123", "op-1", false)
+	if err == nil || !strings.Contains(err.Error(), "last status was RUNNING") {
+		t.Fatalf("expected a timeout with the last status, got %v", err)
+	}
+}
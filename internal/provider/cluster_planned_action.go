@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func plannedActionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "How the last planned change of node counts or of a storage backend is applied, such as an in-place scale out, a scale in losing Raft quorum or a replacement losing the cluster data",
+	}
+}
+
+// planNodeCountChange sets planned_action on an existing cluster, describing
+// for each of countKeys that changes whether nodes join or leave the running
+// cluster, or clearing it when none does. All counts change in place, but
+// removing a majority of the Raft servers of raftKey at once loses quorum,
+// which is also logged as a warning since CustomizeDiff cannot return
+// diagnostics.
+func planNodeCountChange(ctx context.Context, d *schema.ResourceDiff, service, raftKey string, countKeys ...string) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	var actions []string
+	for _, key := range countKeys {
+		if !d.HasChange(key) {
+			continue
+		}
+		if !d.NewValueKnown(key) {
+			actions = append(actions, fmt.Sprintf("%s changes in place to a value known after apply", key))
+			continue
+		}
+
+		o, n := d.GetChange(key)
+		oldCount, newCount := o.(int), n.(int)
+		if newCount > oldCount {
+			actions = append(actions, fmt.Sprintf("%s %d -> %d: in-place scale out, new nodes join the running cluster", key, oldCount, newCount))
+			continue
+		}
+
+		action := fmt.Sprintf("%s %d -> %d: in-place scale in", key, oldCount, newCount)
+		if key == "client_count" && d.Get("drain_on_scale_in").(bool) {
+			action += ", removed clients are drained first"
+		}
+		if key == raftKey && newCount < oldCount/2+1 {
+			action += ", disruptive: removes a majority of the servers at once and loses Raft quorum"
+			tflog.Warn(ctx, fmt.Sprintf("Reducing %s removes a majority of the %s servers at once, which loses Raft quorum", key, service), map[string]any{
+				"cluster_id": d.Id(),
+				"old_value":  oldCount,
+				"new_value":  newCount,
+			})
+		}
+		actions = append(actions, action)
+	}
+
+	// Without a count change, the action of an earlier plan is cleared.
+	return d.SetNew("planned_action", strings.Join(actions, "; "))
+}
+
+// planDataLossOnReplace sets planned_action when a change of the string
+// attribute key replaces an existing cluster, as its data is lost unless a
+// snapshot is restored into the new cluster. The SDK plans a replacement
+// again as a create, without the prior state but its raw value, which the
+// prior key is read from. The actions of planNodeCountChange are replaced,
+// since the node counts of a new cluster are not changed in place.
+func planDataLossOnReplace(d *schema.ResourceDiff, key string) error {
+	prior := d.GetRawState()
+	if prior.IsNull() || !prior.IsKnown() {
+		return nil
+	}
+	old := prior.GetAttr(key)
+	if old.IsNull() || !old.IsKnown() || old.AsString() == d.Get(key).(string) {
+		return nil
+	}
+	return d.SetNew("planned_action", fmt.Sprintf("%s %s -> %s: replaces the cluster, which loses its data unless a snapshot is restored into the new cluster", key, old.AsString(), d.Get(key).(string)))
+}
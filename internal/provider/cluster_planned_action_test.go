@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestConsulCluster_plannedAction checks that changes of node counts plan a
+// planned_action explaining them, flagging scale ins losing Raft quorum
+func TestConsulCluster_plannedAction(t *testing.T) {
+	cases := map[string]struct {
+		serverCount int
+		clientCount int
+		expect      []string
+		reject      []string
+	}{
+		"no change":        {serverCount: 3, clientCount: 3},
+		"scale out":        {serverCount: 5, clientCount: 3, expect: []string{"server_count 3 -> 5: in-place scale out"}},
+		"clients scale in": {serverCount: 3, clientCount: 1, expect: []string{"client_count 3 -> 1: in-place scale in", "drained first"}, reject: []string{"quorum"}},
+		"quorum lost":      {serverCount: 1, clientCount: 3, expect: []string{"server_count 3 -> 1", "loses Raft quorum"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := &sdkterraform.InstanceState{
+				ID: "consul-123",
+				Attributes: map[string]string{
+					"id":                "consul-123",
+					"name":              "test-consul",
+					"region":            "GRA",
+					"server_count":      "3",
+					"client_count":      "3",
+					"instance_type":     "c2-15",
+					"datacenter":        "dc1",
+					"status":            "READY",
+					"drain_on_scale_in": "true",
+				},
+			}
+			raw := testConsulClusterRawConfig()
+			raw["server_count"] = tc.serverCount
+			raw["client_count"] = tc.clientCount
+
+			diff, err := resourceConsulCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected diff error: %s", err)
+			}
+
+			var planned string
+			if diff != nil && diff.Attributes["planned_action"] != nil {
+				planned = diff.Attributes["planned_action"].New
+			}
+			if len(tc.expect) == 0 && planned != "" {
+				t.Errorf("expected no planned_action, got %q", planned)
+			}
+			for _, s := range tc.expect {
+				if !strings.Contains(planned, s) {
+					t.Errorf("expected planned_action to contain %q, got %q", s, planned)
+				}
+			}
+			for _, s := range tc.reject {
+				if strings.Contains(planned, s) {
+					t.Errorf("expected planned_action not to contain %q, got %q", s, planned)
+				}
+			}
+		})
+	}
+}
+
+// TestConsulCluster_plannedActionCleared checks that a plan without count
+// changes clears the planned_action of an earlier one
+func TestConsulCluster_plannedActionCleared(t *testing.T) {
+	state := &sdkterraform.InstanceState{
+		ID: "consul-123",
+		Attributes: map[string]string{
+			"id":             "consul-123",
+			"name":           "test-consul",
+			"region":         "GRA",
+			"server_count":   "5",
+			"client_count":   "3",
+			"instance_type":  "c2-15",
+			"datacenter":     "dc1",
+			"status":         "READY",
+			"planned_action": "server_count 3 -> 5: in-place scale out, new nodes join the running cluster",
+		},
+	}
+	raw := testConsulClusterRawConfig()
+	raw["server_count"] = 5
+	raw["client_count"] = 3
+	raw["tags"] = map[string]interface{}{"team": "platform"}
+
+	diff, err := resourceConsulCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if planned := diff.Attributes["planned_action"]; planned == nil || planned.New != "" {
+		t.Errorf("expected planned_action to be cleared, got %v", planned)
+	}
+}
+
+// TestVaultCluster_plannedActionQuorum checks when a decrease of node_count
+// is flagged as losing Raft quorum
+func TestVaultCluster_plannedActionQuorum(t *testing.T) {
+	cases := []struct {
+		from, to   int
+		quorumLost bool
+	}{
+		{from: 5, to: 3},
+		{from: 5, to: 2, quorumLost: true},
+		{from: 3, to: 2},
+		{from: 3, to: 1, quorumLost: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(strconv.Itoa(tc.from)+" to "+strconv.Itoa(tc.to), func(t *testing.T) {
+			state := &sdkterraform.InstanceState{
+				ID: "vault-123",
+				Attributes: map[string]string{
+					"id":            "vault-123",
+					"auto_unseal":   "true",
+					"name":          "test-vault",
+					"region":        "GRA",
+					"node_count":    strconv.Itoa(tc.from),
+					"instance_type": "c2-15",
+					"storage_type":  "consul",
+					"status":        "READY",
+				},
+			}
+			raw := testVaultClusterRawConfig()
+			raw["node_count"] = tc.to
+
+			diff, err := resourceVaultCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected diff error: %s", err)
+			}
+			if diff.RequiresNew() {
+				t.Errorf("expected node_count to change in place")
+			}
+			planned := diff.Attributes["planned_action"].New
+			if lost := strings.Contains(planned, "loses Raft quorum"); lost != tc.quorumLost {
+				t.Errorf("expected quorum lost %t, got planned_action %q", tc.quorumLost, planned)
+			}
+		})
+	}
+}
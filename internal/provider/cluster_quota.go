@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// quotaKeywords maps words of OVH quota errors to the name of the quota they
+// are about, most specific first.
+var quotaKeywords = []struct{ keyword, quota string }{
+	{"floating ip", "floating IPs"},
+	{"floatingip", "floating IPs"},
+	{"vrack", "vRack"},
+	{"instance", "instances"},
+	{"vcpu", "cores"},
+	{"core", "cores"},
+	{"ram", "RAM"},
+	{"memory", "RAM"},
+	{"volume", "volumes"},
+	{"snapshot", "snapshots"},
+	{"network", "networks"},
+}
+
+// quotaExceeded reports whether err is OVH rejecting a call because it would
+// exceed a quota of the project, and returns the name of that quota, or
+// "project" when the error does not say which one.
+func quotaExceeded(err error) (string, bool) {
+	var apiErr *ovh.APIError
+	if !errors.As(err, &apiErr) {
+		return "", false
+	}
+	text := strings.ToLower(apiErr.Class + " " + apiErr.Message)
+	if !strings.Contains(text, "quota") {
+		return "", false
+	}
+
+	if quota := apiErr.Details["quota"]; quota != "" {
+		return quota, true
+	}
+	for _, k := range quotaKeywords {
+		if strings.Contains(text, k.keyword) {
+			return k.quota, true
+		}
+	}
+	return "project", true
+}
+
+// quotaDiagnostics describes a create of a service cluster, named kind,
+// exceeding the quota of projectId, with a link to request an increase.
+func quotaDiagnostics(kind, projectId, quota, detail string) diag.Diagnostics {
+	link := "https://www.ovh.com/manager/#/public-cloud/"
+	if projectId != "" {
+		link = fmt.Sprintf("https://www.ovh.com/manager/#/public-cloud/pci/projects/%s/quota", url.PathEscape(projectId))
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s cluster exceeds the %s quota of the OVH project", kind, quota),
+		Detail:   fmt.Sprintf("%s\n\nFree up resources in the project, or request a quota increase at %s", detail, link),
+	}}
+}
+
+// createClusterError returns the diagnostics of a failed create of a service
+// cluster, naming the exhausted quota when OVH rejected it for one.
+func createClusterError(kind, projectId string, err error) diag.Diagnostics {
+	if quota, ok := quotaExceeded(err); ok {
+		return quotaDiagnostics(kind, projectId, quota, fmt.Sprintf("OVH refused to create the cluster: %s", err))
+	}
+	if errors.Is(err, errDryRun) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s cluster not created in a dry run", kind),
+				Detail:   fmt.Sprintf("The %s cluster passed the checks of its create, and was not created: %s.", kind, err),
+			},
+		}
+	}
+	return diag.FromErr(fmt.Errorf("failed to create %s cluster: %w", kind, err))
+}
+
+// clusterInstancesByRegion returns the number of instances the create of a
+// cluster starts in each region: the sum of countKeys in region, or the
+// servers and clients of each region of region_distribution.
+func clusterInstancesByRegion(d interface{ Get(string) interface{} }, countKeys ...string) map[string]int {
+	instances := map[string]int{}
+
+	if distribution, _ := d.Get("region_distribution").([]interface{}); len(distribution) > 0 {
+		for _, item := range distribution {
+			entry := item.(map[string]interface{})
+			instances[entry["region"].(string)] += entry["server_count"].(int) + entry["client_count"].(int)
+		}
+		return instances
+	}
+
+	for _, key := range countKeys {
+		instances[d.Get("region").(string)] += d.Get(key).(int)
+	}
+	return instances
+}
+
+// checkInstanceQuota fails the create of a service cluster, named kind in
+// errors, when it starts more instances in a region than the instance quota
+// of projectId has left there, rather than having OVH reject it part way.
+// Like checkExistingCluster, a failed lookup is only logged.
+func checkInstanceQuota(ctx context.Context, config *Config, d *schema.ResourceData, kind, projectId string, countKeys ...string) diag.Diagnostics {
+	if projectId == "" {
+		return nil
+	}
+
+	var quotas []map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/%s/quota", url.PathEscape(projectId)), &quotas)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to check the instance quota of the project", map[string]any{
+			"project_id": projectId,
+			"error":      err.Error(),
+		})
+		return nil
+	}
+
+	if detail := instanceQuotaShortfall(quotas, clusterInstancesByRegion(d, countKeys...)); detail != "" {
+		return quotaDiagnostics(kind, projectId, "instances", detail)
+	}
+	return nil
+}
+
+// instanceQuotaShortfall returns why starting instances, counted by region,
+// exceeds the instance quotas of a project, or an empty string when they fit.
+func instanceQuotaShortfall(quotas []map[string]interface{}, instances map[string]int) string {
+	regions := make([]string, 0, len(instances))
+	for region := range instances {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	for _, region := range regions {
+		for _, quota := range quotas {
+			if getString(quota, "region") != region {
+				continue
+			}
+			instanceQuota, _ := quota["instance"].(map[string]interface{})
+			max := getInt(instanceQuota, "maxInstances")
+			used := getInt(instanceQuota, "usedInstances")
+			if max > 0 && instances[region] > max-used {
+				return fmt.Sprintf(
+					"The cluster needs %d instances in region %s, but the project only has %d of its %d instances left there.",
+					instances[region], region, max-used, max)
+			}
+		}
+	}
+	return ""
+}
+
+// priorValues reads the values of a planned cluster before the plan.
+type priorValues struct {
+	d *schema.ResourceDiff
+}
+
+func (p priorValues) Get(key string) interface{} {
+	old, _ := p.d.GetChange(key)
+	return old
+}
+
+// planInstanceQuota checks at plan time that the instances a service
+// cluster, named kind in errors, adds in each region fit in the instance
+// quota of its project: all of them for a new cluster, and those added by
+// larger countKeys or region_distribution for an existing one. This fails
+// the plan rather than the apply part way. Like checkInstanceQuota, a failed
+// lookup is only logged, and values unknown until apply skip the check.
+func planInstanceQuota(ctx context.Context, d *schema.ResourceDiff, meta interface{}, kind string, countKeys ...string) error {
+	config, ok := meta.(*Config)
+	if !ok {
+		return nil
+	}
+	for _, key := range append([]string{"region", "region_distribution"}, countKeys...) {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	// project_id is computed, so it is also unknown on create when it is not
+	// set and the provider ovh_project_id is used.
+	projectId := d.Get("project_id").(string)
+	if !d.NewValueKnown("project_id") {
+		if raw := d.GetRawConfig(); !raw.IsNull() && !raw.GetAttr("project_id").IsNull() {
+			return nil
+		}
+		projectId = ""
+	}
+
+	instances := clusterInstancesByRegion(d, countKeys...)
+	if d.Id() != "" {
+		for region, count := range clusterInstancesByRegion(priorValues{d}, countKeys...) {
+			instances[region] -= count
+		}
+	}
+	for region, count := range instances {
+		if count <= 0 {
+			delete(instances, region)
+		}
+	}
+	if len(instances) == 0 {
+		return nil
+	}
+
+	projectId, err := config.projectID(projectId)
+	if err != nil || projectId == "" {
+		return nil
+	}
+
+	var quotas []map[string]interface{}
+	if err := config.getList(fmt.Sprintf("/cloud/project/%s/quota", url.PathEscape(projectId)), &quotas); err != nil {
+		tflog.Warn(ctx, "Unable to check the instance quota of the project", map[string]any{
+			"project_id": projectId,
+			"error":      err.Error(),
+		})
+		return nil
+	}
+
+	if detail := instanceQuotaShortfall(quotas, instances); detail != "" {
+		diags := quotaDiagnostics(kind, projectId, "instances", detail)
+		return fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	return nil
+}
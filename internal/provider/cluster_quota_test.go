@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestQuotaExceeded(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	cases := []struct {
+		body    string
+		quota   string
+		isQuota bool
+	}{
+		{body: `{"class": "Client::Forbidden::QuotaExceeded", "message": "Quota exceeded for instances in region GRA"}`, quota: "instances", isQuota: true},
+		{body: `{"message": "vRack quota reached for this project"}`, quota: "vRack", isQuota: true},
+		{body: `{"message": "Quota exceeded", "details": {"quota": "maxCores"}}`, quota: "maxCores", isQuota: true},
+		{body: `{"message": "Project quota exceeded"}`, quota: "project", isQuota: true},
+		{body: `{"message": "Invalid instance type"}`},
+	}
+
+	config := mock.NewConfig(t)
+	for _, tc := range cases {
+		mock.AddResponse(403, tc.body, nil)
+		err := config.OVHClient.Get("/cloud/project/p/quota", nil)
+
+		quota, ok := quotaExceeded(err)
+		if ok != tc.isQuota || quota != tc.quota {
+			t.Errorf("%s: expected quota %q (%t), got %q (%t)", tc.body, tc.quota, tc.isQuota, quota, ok)
+		}
+	}
+}
+
+// TestConsulClusterCreate_quotaExceeded checks that a create rejected for a
+// quota names the quota and links to the increase request
+func TestConsulClusterCreate_quotaExceeded(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(403, `{"class": "Client::Forbidden::QuotaExceeded", "message": "Quota exceeded for instances in region GRA"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+
+	diags := resourceConsulClusterCreate(context.Background(), d, mock.NewConfig(t))
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(diags[0].Summary, "instances quota") {
+		t.Errorf("expected the summary to name the instances quota, got %q", diags[0].Summary)
+	}
+	if !strings.Contains(diags[0].Detail, "https://www.ovh.com/manager/#/public-cloud/") {
+		t.Errorf("expected a link to request an increase, got %q", diags[0].Detail)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no cluster in state, got %q", d.Id())
+	}
+}
+
+// TestConsulClusterCreate_quotaPreCheck checks that a cluster needing more
+// instances than the quota has left fails before the create
+func TestConsulClusterCreate_quotaPreCheck(t *testing.T) {
+	cases := map[string]struct {
+		used      int
+		expectErr bool
+	}{
+		"enough left":     {used: 14},
+		"not enough left": {used: 15, expectErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `[]`, nil)
+			mock.AddResponse(200, `[{"region": "SBG", "instance": {"maxInstances": 20, "usedInstances": 20}}, {"region": "GRA", "instance": {"maxInstances": 20, "usedInstances": `+strconv.Itoa(tc.used)+`}}]`, nil)
+			mock.AddResponse(200, `{"id": "consul-123"}`, nil)
+
+			raw := testConsulClusterRawConfig()
+			raw["server_count"] = 3
+			raw["client_count"] = 3
+			d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, raw)
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "abc123"
+			diags := resourceConsulClusterCreate(context.Background(), d, config)
+
+			if !tc.expectErr {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() || !strings.Contains(diags[0].Detail, "needs 6 instances in region GRA") {
+				t.Fatalf("expected a quota error, got %v", diags)
+			}
+			if !strings.Contains(diags[0].Detail, "/pci/projects/abc123/quota") {
+				t.Errorf("expected a link to the quotas of the project, got %q", diags[0].Detail)
+			}
+			if got := mock.GetRequestCount(); got != 2 {
+				t.Errorf("expected no create request, got %d requests", got)
+			}
+		})
+	}
+}
+
+// TestConsulCluster_planInstanceQuota checks that a plan adding more
+// instances than the quota has left fails, counting only the instances added
+// to an existing cluster
+func TestConsulCluster_planInstanceQuota(t *testing.T) {
+	cases := map[string]struct {
+		state     map[string]string
+		used      int
+		expectErr string
+	}{
+		"new cluster fits":               {used: 14},
+		"new cluster exceeds":            {used: 15, expectErr: "needs 6 instances in region GRA"},
+		"existing cluster scale fits":    {state: map[string]string{"server_count": "3", "client_count": "1"}, used: 18},
+		"existing cluster scale exceeds": {state: map[string]string{"server_count": "3", "client_count": "1"}, used: 19, expectErr: "needs 2 instances in region GRA"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `[{"region": "GRA", "instance": {"maxInstances": 20, "usedInstances": `+strconv.Itoa(tc.used)+`}}]`, nil)
+
+			var state *sdkterraform.InstanceState
+			if tc.state != nil {
+				state = &sdkterraform.InstanceState{
+					ID: "consul-123",
+					Attributes: map[string]string{
+						"id":            "consul-123",
+						"name":          "test-consul",
+						"region":        "GRA",
+						"instance_type": "c2-15",
+						"datacenter":    "gra",
+						"storage_type":  "consul",
+					},
+				}
+				for key, value := range tc.state {
+					state.Attributes[key] = value
+				}
+			}
+
+			raw := testConsulClusterRawConfig()
+			raw["server_count"] = 3
+			raw["client_count"] = 3
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "abc123"
+			_, err := resourceConsulCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), config)
+
+			if tc.expectErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Fatalf("expected a quota error, got %v", err)
+			}
+			if last := mock.GetLastRequest(); last == nil || last.URL.Path != "/cloud/project/abc123/quota" {
+				t.Errorf("expected the quotas of the project to be read")
+			}
+		})
+	}
+}
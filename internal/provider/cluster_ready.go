@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterNodeCountFields are the API fields holding the number of nodes of a
+// cluster of each service.
+var clusterNodeCountFields = map[string][]string{
+	"vault":    {"nodeCount"},
+	"consul":   {"serverCount", "clientCount"},
+	"nomad":    {"serverCount", "clientCount"},
+	"boundary": {"controllerCount", "workerCount"},
+}
+
+// raftServices are the services whose servers elect a Raft leader. Boundary
+// controllers share a database instead.
+var raftServices = map[string]bool{"vault": true, "consul": true, "nomad": true}
+
+func readySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the cluster is usable: READY, with a Raft leader elected and all nodes joined. Unlike status, it stays false until the cluster can serve requests",
+	}
+}
+
+func readyAtSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time the cluster was first read as ready, in RFC 3339 format. Empty while it is not ready",
+	}
+}
+
+func waitForReadyOnCreateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Wait for the cluster to be ready after creating it, so that resources depending on it are only created once it is usable",
+	}
+}
+
+func onlyHealthySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Only return clusters that are ready: READY, with a Raft leader elected and all nodes joined, as for the ready attribute of the cluster resources",
+	}
+}
+
+// clusterNotReadyReason returns an empty string when the API object of a
+// service cluster is usable, else why it is not. Beyond the READY status, a
+// Raft leader must be elected and every node must have joined. The leader
+// and the node statuses are only checked when the API reports them.
+func clusterNotReadyReason(service string, cluster map[string]interface{}) string {
+	if status := getString(cluster, "status"); status != "READY" {
+		return fmt.Sprintf("status was %s", status)
+	}
+
+	if _, ok := cluster["leader"]; ok && raftServices[service] && getString(cluster, "leader") == "" {
+		return "no Raft leader was elected"
+	}
+
+	nodes, ok := cluster["nodes"].([]interface{})
+	if !ok {
+		return ""
+	}
+	expected := 0
+	for _, field := range clusterNodeCountFields[service] {
+		expected += getInt(cluster, field)
+	}
+	joined := 0
+	for _, item := range nodes {
+		node, _ := item.(map[string]interface{})
+		if status := getString(node, "status"); status == "" || status == "RUNNING" {
+			joined++
+		}
+	}
+	if joined < len(nodes) || joined < expected {
+		return fmt.Sprintf("%d of %d nodes had joined", joined, max(len(nodes), expected))
+	}
+	return ""
+}
+
+// setClusterReadiness sets ready and ready_at from an API object of a
+// service cluster. ready_at keeps the time of the first read finding the
+// cluster ready, and is cleared when it no longer is.
+func setClusterReadiness(d *schema.ResourceData, service string, cluster map[string]interface{}) {
+	ready := clusterNotReadyReason(service, cluster) == ""
+	d.Set("ready", ready)
+
+	switch {
+	case !ready:
+		d.Set("ready_at", "")
+	case d.Get("ready_at").(string) == "":
+		d.Set("ready_at", time.Now().UTC().Format(time.RFC3339))
+	}
+}
+
+// waitForClusterUsable polls a cluster of the given service until it is
+// usable, as reported by clusterNotReadyReason: READY, with a Raft leader
+// elected and all its nodes joined. The nodes of large clusters are
+// completed first, so that every page of them is checked.
+func waitForClusterUsable(ctx context.Context, config *Config, service, clusterId string) error {
+	path := fmt.Sprintf("/cloud/project/%s/cluster/%s", service, clusterId)
+	return waitFor(ctx, config, path, "the cluster to be ready", 30*time.Minute, config.pollInterval(clusterReadyPollInterval), func(cluster map[string]interface{}) string {
+		if err := completeClusterNodes(config, service, clusterId, cluster); err != nil {
+			return fmt.Sprintf("nodes could not be read: %s", err)
+		}
+		return clusterNotReadyReason(service, cluster)
+	})
+}
+
+// waitForReadyAfterCreate waits for a created cluster to be ready when
+// wait_for_ready is set. An error here would taint the cluster and the next
+// apply would replace it, so a cluster that does not become ready is kept in
+// state with a warning, and read with ready false.
+func waitForReadyAfterCreate(ctx context.Context, config *Config, d *schema.ResourceData, kind, service string) diag.Diagnostics {
+	if !d.Get("wait_for_ready").(bool) {
+		return nil
+	}
+
+	if err := waitForClusterUsable(ctx, config, service, d.Id()); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s cluster is not ready yet", kind),
+				Detail:   fmt.Sprintf("%s cluster %s was created but did not become ready: %s. It has been kept in state with ready = false.", kind, d.Id(), err),
+			},
+		}
+	}
+	return nil
+}
+
+// filterHealthyClusters returns the clusters of a list data source that are
+// ready, as reported by clusterNotReadyReason. List entries may not report
+// the leader and the nodes, so each READY cluster is read, with all its
+// nodes, to check them.
+func filterHealthyClusters(config *Config, service string, clusters []map[string]interface{}) ([]map[string]interface{}, error) {
+	var healthy []map[string]interface{}
+	for _, cluster := range clusters {
+		if getString(cluster, "status") != "READY" {
+			continue
+		}
+
+		id := getString(cluster, "id")
+		var details map[string]interface{}
+		if err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/%s/cluster/%s", service, id), &details); err != nil {
+			return nil, fmt.Errorf("failed to check the health of cluster %s: %w", id, err)
+		}
+		if err := completeClusterNodes(config, service, id, details); err != nil {
+			return nil, fmt.Errorf("failed to check the health of cluster %s: %w", id, err)
+		}
+		if clusterNotReadyReason(service, details) == "" {
+			healthy = append(healthy, cluster)
+		}
+	}
+	return healthy, nil
+}
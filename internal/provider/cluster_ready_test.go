@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestClusterNotReadyReason checks that a cluster is only ready once READY,
+// with a Raft leader elected and all its nodes joined
+func TestClusterNotReadyReason(t *testing.T) {
+	cases := map[string]struct {
+		service string
+		cluster string
+		expect  string
+	}{
+		"ready":                 {service: "consul", cluster: `{"status": "READY", "leader": "node-1", "serverCount": 1, "nodes": [{"id": "node-1", "status": "RUNNING"}]}`},
+		"details not reported":  {service: "consul", cluster: `{"status": "READY"}`},
+		"provisioning":          {service: "consul", cluster: `{"status": "PROVISIONING"}`, expect: "status was PROVISIONING"},
+		"no leader":             {service: "nomad", cluster: `{"status": "READY", "leader": ""}`, expect: "no Raft leader was elected"},
+		"boundary has no raft":  {service: "boundary", cluster: `{"status": "READY", "leader": ""}`},
+		"node not running":      {service: "vault", cluster: `{"status": "READY", "nodes": [{"status": "RUNNING"}, {"status": "BUILDING"}]}`, expect: "1 of 2 nodes had joined"},
+		"node missing":          {service: "vault", cluster: `{"status": "READY", "nodeCount": 3, "nodes": [{"status": "RUNNING"}, {"status": "RUNNING"}]}`, expect: "2 of 3 nodes had joined"},
+		"client nodes included": {service: "nomad", cluster: `{"status": "READY", "serverCount": 1, "clientCount": 1, "nodes": [{"status": "RUNNING"}]}`, expect: "1 of 2 nodes had joined"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var cluster map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.cluster), &cluster); err != nil {
+				t.Fatal(err)
+			}
+			if got := clusterNotReadyReason(tc.service, cluster); got != tc.expect {
+				t.Errorf("expected %q, got %q", tc.expect, got)
+			}
+		})
+	}
+}
+
+// TestVaultClusterRead_ready checks that ready_at keeps the time the cluster
+// was first read as ready, and is cleared when it no longer is
+func TestVaultClusterRead_ready(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, testVaultClusterRawConfig())
+	d.SetId("vault-123")
+
+	read := func(cluster string) {
+		t.Helper()
+		mock.AddResponse(200, cluster, nil)
+		if diags := resourceVaultClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+	}
+
+	read(`{"id": "vault-123", "status": "READY", "leader": ""}`)
+	if d.Get("ready").(bool) || d.Get("ready_at").(string) != "" {
+		t.Fatalf("expected a cluster without leader not to be ready, got ready %t at %q", d.Get("ready").(bool), d.Get("ready_at").(string))
+	}
+
+	read(`{"id": "vault-123", "status": "READY", "leader": "node-1"}`)
+	if !d.Get("ready").(bool) {
+		t.Fatal("expected the cluster to be ready")
+	}
+	readyAt := d.Get("ready_at").(string)
+	if _, err := time.Parse(time.RFC3339, readyAt); err != nil {
+		t.Fatalf("expected ready_at in RFC 3339 format, got %q", readyAt)
+	}
+
+	d.Set("ready_at", "2026-01-01T00:00:00Z")
+	read(`{"id": "vault-123", "status": "READY", "leader": "node-1"}`)
+	if got := d.Get("ready_at").(string); got != "2026-01-01T00:00:00Z" {
+		t.Errorf("expected ready_at to be kept, got %q", got)
+	}
+
+	read(`{"id": "vault-123", "status": "UPDATING", "leader": "node-1"}`)
+	if d.Get("ready").(bool) || d.Get("ready_at").(string) != "" {
+		t.Errorf("expected an updating cluster not to be ready, got ready %t at %q", d.Get("ready").(bool), d.Get("ready_at").(string))
+	}
+}
+
+// TestConsulClusterCreate_waitForReady checks that wait_for_ready holds the
+// create until the cluster has a leader, rather than returning on READY
+func TestConsulClusterCreate_waitForReady(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "consul-123"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY", "leader": ""}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY", "leader": "node-1"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "leader": "node-1"}`, nil)
+
+	raw := testConsulClusterRawConfig()
+	raw["wait_for_ready"] = true
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, raw)
+
+	config := mock.NewConfig(t)
+	config.PollInterval = 10 * time.Millisecond
+	diags := resourceConsulClusterCreate(context.Background(), d, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !d.Get("ready").(bool) {
+		t.Error("expected the cluster to be ready")
+	}
+	reads := 0
+	for _, r := range mock.Requests {
+		if r.URL.Path == "/cloud/project/consul/cluster/consul-123" {
+			reads++
+		}
+	}
+	if reads != 3 {
+		t.Errorf("expected 2 polls until the cluster has a leader and a read, got %d reads", reads)
+	}
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxRaftServers is the largest number of servers in a Raft cluster, as more
+// servers slow down every write without improving availability.
+const maxRaftServers = 7
+
+// maxClusterClients is the largest number of client nodes in a cluster.
+const maxClusterClients = 100
+
+// regionDistributionSchema describes the nodes of a cluster stretched across
+// several regions. It replaces the region, server_count and client_count
+// attributes, which are planned from it.
+func regionDistributionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Spread the cluster across several regions instead of setting region, server_count and client_count. The first region is the primary one",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"region": {
+					Type:        schema.TypeString,
+					Required:    true,
+					ForceNew:    true,
+					Description: "OVH region",
+				},
+				"server_count": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					Description:  "Number of server nodes in the region",
+					ValidateFunc: validateIntBetween(0, maxRaftServers),
+				},
+				"client_count": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					Description:  "Number of client nodes in the region",
+					ValidateFunc: validateIntBetween(0, maxClusterClients),
+				},
+			},
+		},
+	}
+}
+
+func regionNodesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Nodes of the cluster grouped by region",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"region": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "OVH region",
+				},
+				"nodes": clusterNodesSchema(),
+			},
+		},
+	}
+}
+
+// planRegionDistribution plans region, server_count and client_count as the
+// primary region and the total node counts of region_distribution when it is
+// set, after checking that the regions are distinct and the servers form a
+// valid Raft cluster. Otherwise client_count defaults to defaultClientCount
+// on new clusters.
+func planRegionDistribution(d *schema.ResourceDiff, defaultClientCount int) error {
+	if !d.NewValueKnown("region_distribution") {
+		for _, key := range []string{"region", "server_count", "client_count"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	distribution := d.Get("region_distribution").([]interface{})
+	if len(distribution) == 0 {
+		if d.Id() == "" && !d.NewValueKnown("client_count") {
+			return d.SetNew("client_count", defaultClientCount)
+		}
+		return nil
+	}
+
+	seen := map[string]bool{}
+	servers, clients := 0, 0
+	for _, raw := range distribution {
+		entry := raw.(map[string]interface{})
+		region := entry["region"].(string)
+		if seen[region] {
+			return fmt.Errorf("region_distribution lists region %s more than once", region)
+		}
+		seen[region] = true
+		servers += entry["server_count"].(int)
+		clients += entry["client_count"].(int)
+	}
+	if servers < 1 || servers > maxRaftServers {
+		return fmt.Errorf("region_distribution must have between 1 and %d servers in total for Raft quorum, got %d", maxRaftServers, servers)
+	}
+	if clients > maxClusterClients {
+		return fmt.Errorf("region_distribution must have at most %d clients in total, got %d", maxClusterClients, clients)
+	}
+
+	if err := d.SetNew("region", distribution[0].(map[string]interface{})["region"].(string)); err != nil {
+		return err
+	}
+	if err := d.SetNew("server_count", servers); err != nil {
+		return err
+	}
+	return d.SetNew("client_count", clients)
+}
+
+// expandRegionDistribution converts the region_distribution block into its
+// API representation, or returns nil when it is not set.
+func expandRegionDistribution(raw []interface{}) []interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	distribution := make([]interface{}, 0, len(raw))
+	for _, item := range raw {
+		entry := item.(map[string]interface{})
+		distribution = append(distribution, map[string]interface{}{
+			"region":      entry["region"].(string),
+			"serverCount": entry["server_count"].(int),
+			"clientCount": entry["client_count"].(int),
+		})
+	}
+	return distribution
+}
+
+// setRegionDistribution stores the region distribution of a stretched
+// cluster. Single-region clusters have none and keep the block unset.
+func setRegionDistribution(d *schema.ResourceData, cluster map[string]interface{}) {
+	items, ok := cluster["regionDistribution"].([]interface{})
+	if !ok {
+		return
+	}
+
+	distribution := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		distribution = append(distribution, map[string]interface{}{
+			"region":       getString(entry, "region"),
+			"server_count": getInt(entry, "serverCount"),
+			"client_count": getInt(entry, "clientCount"),
+		})
+	}
+	d.Set("region_distribution", distribution)
+}
+
+// flattenRegionNodes groups the nodes of a cluster by region. A single-region
+// cluster has one group holding all its nodes.
+func flattenRegionNodes(cluster map[string]interface{}) []interface{} {
+	items, ok := cluster["regionDistribution"].([]interface{})
+	if !ok {
+		return []interface{}{
+			map[string]interface{}{
+				"region": getString(cluster, "region"),
+				"nodes":  flattenClusterNodes(cluster),
+			},
+		}
+	}
+
+	groups := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		groups = append(groups, map[string]interface{}{
+			"region": getString(entry, "region"),
+			"nodes":  flattenClusterNodes(entry),
+		})
+	}
+	return groups
+}
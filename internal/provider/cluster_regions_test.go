@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testStretchedConsulClusterRawConfig(distribution ...map[string]interface{}) map[string]interface{} {
+	raw := testConsulClusterRawConfig()
+	delete(raw, "region")
+	delete(raw, "server_count")
+
+	entries := make([]interface{}, 0, len(distribution))
+	for _, entry := range distribution {
+		entries = append(entries, entry)
+	}
+	raw["region_distribution"] = entries
+	return raw
+}
+
+// TestConsulCluster_regionDistributionPlan checks that the flat region and
+// counts are planned from region_distribution
+func TestConsulCluster_regionDistributionPlan(t *testing.T) {
+	raw := testStretchedConsulClusterRawConfig(
+		map[string]interface{}{"region": "GRA", "server_count": 2, "client_count": 3},
+		map[string]interface{}{"region": "SBG", "server_count": 2, "client_count": 1},
+		map[string]interface{}{"region": "RBX", "server_count": 1},
+	)
+
+	r := resourceConsulCluster()
+	if diags := r.Validate(sdkterraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Fatalf("unexpected validation error: %v", diags)
+	}
+
+	diff, err := r.Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		"region":       "GRA",
+		"server_count": "5",
+		"client_count": "4",
+	}
+	for key, value := range expected {
+		if got := diff.Attributes[key]; got == nil || got.New != value {
+			t.Errorf("expected %s to be planned as %s, got %+v", key, value, got)
+		}
+	}
+}
+
+func TestConsulCluster_regionDistributionValidation(t *testing.T) {
+	cases := map[string]struct {
+		distribution []map[string]interface{}
+		expectError  string
+	}{
+		"duplicate region": {
+			distribution: []map[string]interface{}{
+				{"region": "GRA", "server_count": 3},
+				{"region": "GRA", "server_count": 2},
+			},
+			expectError: "region GRA more than once",
+		},
+		"too many servers": {
+			distribution: []map[string]interface{}{
+				{"region": "GRA", "server_count": 5},
+				{"region": "SBG", "server_count": 4},
+			},
+			expectError: "between 1 and 7 servers",
+		},
+		"no servers": {
+			distribution: []map[string]interface{}{
+				{"region": "GRA", "server_count": 0, "client_count": 3},
+			},
+			expectError: "between 1 and 7 servers",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testStretchedConsulClusterRawConfig(tc.distribution...)
+
+			_, err := resourceConsulCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !regexp.MustCompile(tc.expectError).MatchString(err.Error()) {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestConsulCluster_regionDistributionConflicts(t *testing.T) {
+	raw := testStretchedConsulClusterRawConfig(map[string]interface{}{"region": "GRA", "server_count": 3})
+	raw["region"] = "GRA"
+
+	if diags := resourceConsulCluster().Validate(sdkterraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Fatal("expected region and region_distribution to conflict")
+	}
+
+	raw = testConsulClusterRawConfig()
+	delete(raw, "region")
+	if diags := resourceConsulCluster().Validate(sdkterraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Fatal("expected an error without region or region_distribution")
+	}
+}
+
+// TestConsulCluster_defaultClientCount checks that single-region clusters
+// default to no clients
+func TestConsulCluster_defaultClientCount(t *testing.T) {
+	diff, err := resourceConsulCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(testConsulClusterRawConfig()), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := diff.Attributes["client_count"]; got == nil || got.New != "0" {
+		t.Errorf("expected client_count to be planned as 0, got %+v", got)
+	}
+}
+
+func TestNomadClusterRead_regionDistribution(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "id": "nomad-123",
+  "name": "test-nomad",
+  "region": "GRA",
+  "serverCount": 3,
+  "clientCount": 2,
+  "status": "READY",
+  "regionDistribution": [
+    {"region": "GRA", "serverCount": 2, "clientCount": 2, "nodes": [
+      {"id": "node-1", "role": "server"},
+      {"id": "node-2", "role": "server"},
+      {"id": "node-3", "role": "client"},
+      {"id": "node-4", "role": "client"}
+    ]},
+    {"region": "SBG", "serverCount": 1, "clientCount": 0, "nodes": [
+      {"id": "node-5", "role": "server"}
+    ]}
+  ]
+}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, map[string]interface{}{
+		"name":          "test-nomad",
+		"instance_type": "c2-15",
+		"region_distribution": []interface{}{
+			map[string]interface{}{"region": "GRA", "server_count": 2, "client_count": 2},
+			map[string]interface{}{"region": "SBG", "server_count": 1},
+		},
+	})
+	d.SetId("nomad-123")
+
+	if diags := resourceNomadClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("region_distribution.1.region").(string); got != "SBG" {
+		t.Errorf("expected the second region to be SBG, got %q", got)
+	}
+	if got := d.Get("region_nodes.#").(int); got != 2 {
+		t.Fatalf("expected 2 region node groups, got %d", got)
+	}
+	if got := d.Get("region_nodes.0.nodes.#").(int); got != 4 {
+		t.Errorf("expected 4 nodes in GRA, got %d", got)
+	}
+	if got := d.Get("region_nodes.1.nodes.0.id").(string); got != "node-5" {
+		t.Errorf("expected node-5 in SBG, got %q", got)
+	}
+}
+
+func TestNomadClusterRead_singleRegionNodes(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "nomad-123", "name": "test-nomad", "region": "GRA", "serverCount": 1, "status": "READY", "nodes": [{"id": "node-1", "role": "server"}]}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, map[string]interface{}{
+		"name":          "test-nomad",
+		"region":        "GRA",
+		"server_count":  1,
+		"instance_type": "c2-15",
+	})
+	d.SetId("nomad-123")
+
+	if diags := resourceNomadClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("region_distribution.#").(int); got != 0 {
+		t.Errorf("expected no region_distribution, got %d entries", got)
+	}
+	if got := d.Get("region_nodes.0.region").(string); got != "GRA" {
+		t.Errorf("expected a single GRA node group, got %q", got)
+	}
+	if got := d.Get("region_nodes.0.nodes.0.id").(string); got != "node-1" {
+		t.Errorf("expected node-1 in GRA, got %q", got)
+	}
+}
+
+// TestNomadCluster_regionNames checks that region takes any region the API
+// offers, such as the eu-west-1 style names alongside GRA
+func TestNomadCluster_regionNames(t *testing.T) {
+	for _, region := range []string{"GRA", "eu-west-1", "GRA11"} {
+		raw := testNomadClusterUpdateRaw()
+		raw["region"] = region
+		if diags := resourceNomadCluster().Validate(sdkterraform.NewResourceConfigRaw(raw)); diags.HasError() {
+			t.Errorf("unexpected validation error for %s: %v", region, diags)
+		}
+	}
+}
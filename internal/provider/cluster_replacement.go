@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func replacementStrategySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "in_place",
+		Description:  "How the cluster is replaced: in_place, or blue_green to replace it on an instance_type change and create the new cluster next to the old one. blue_green requires lifecycle { create_before_destroy = true }",
+		ValidateFunc: validation.StringInSlice([]string{"in_place", "blue_green"}, false),
+	}
+}
+
+func previousClusterIdSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the cluster a blue_green replacement created this one next to, until that cluster is destroyed",
+	}
+}
+
+func previousEndpointSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Address of the cluster a blue_green replacement created this one next to, to migrate data from until that cluster is destroyed",
+	}
+}
+
+func isBlueGreen(d interface{ Get(string) interface{} }) bool {
+	return d.Get("replacement_strategy").(string) == "blue_green"
+}
+
+// planBlueGreenReplacement replaces an existing blue_green cluster whose
+// instance_type changes, which OVH cannot apply to running nodes, rather
+// than leaving the change pending.
+func planBlueGreenReplacement(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !isBlueGreen(d) {
+		return nil
+	}
+	if d.HasChange("instance_type") && d.NewValueKnown("instance_type") {
+		return d.ForceNew("instance_type")
+	}
+	return nil
+}
+
+// setPreviousCluster records the cluster a blue_green create replaces. With
+// create_before_destroy it still exists under the same name, so it is looked
+// up by name instead of failing the create as checkExistingCluster would. A
+// failed lookup is only logged.
+func setPreviousCluster(ctx context.Context, config *Config, d *schema.ResourceData, service, projectId string) {
+	if !isBlueGreen(d) {
+		return
+	}
+	name := d.Get("name").(string)
+
+	cluster, err := findClusterByName(config, service, name, projectId)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to look up the cluster replaced by the blue_green create", map[string]any{
+			"name":  name,
+			"error": err.Error(),
+		})
+		return
+	}
+	if cluster == nil {
+		return
+	}
+
+	d.Set("previous_cluster_id", getString(cluster, "id"))
+	d.Set("previous_endpoint", clusterAddress(service, cluster))
+}
+
+// refreshPreviousCluster clears previous_cluster_id and previous_endpoint
+// once the cluster a blue_green replacement created this one next to is
+// gone. Other errors keep them, so that a transient failure does not hide the
+// old endpoint during the migration.
+func refreshPreviousCluster(config *Config, d *schema.ResourceData, service string) {
+	previousId := d.Get("previous_cluster_id").(string)
+	if previousId == "" {
+		return
+	}
+
+	var cluster map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/%s/cluster/%s", service, previousId), &cluster)
+	if isOVHErrorCode(err, 404) {
+		d.Set("previous_cluster_id", "")
+		d.Set("previous_endpoint", "")
+	}
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestVaultCluster_blueGreenInstanceType checks that an instance_type change
+// replaces the cluster only with the blue_green replacement_strategy
+func TestVaultCluster_blueGreenInstanceType(t *testing.T) {
+	cases := map[string]struct {
+		strategy      string
+		expectReplace bool
+	}{
+		"blue_green": {strategy: "blue_green", expectReplace: true},
+		"in_place":   {strategy: "in_place"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := &sdkterraform.InstanceState{
+				ID: "vault-123",
+				Attributes: map[string]string{
+					"id":                   "vault-123",
+					"auto_unseal":          "true",
+					"name":                 "test-vault",
+					"region":               "GRA",
+					"node_count":           "3",
+					"instance_type":        "c2-15",
+					"storage_type":         "consul",
+					"status":               "READY",
+					"replacement_strategy": tc.strategy,
+				},
+			}
+			raw := testVaultClusterRawConfig()
+			raw["instance_type"] = "c2-30"
+			raw["replacement_strategy"] = tc.strategy
+
+			diff, err := resourceVaultCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected diff error: %s", err)
+			}
+			if replace := diff != nil && diff.RequiresNew(); replace != tc.expectReplace {
+				t.Errorf("expected replace %t, got %t", tc.expectReplace, replace)
+			}
+		})
+	}
+}
+
+// TestVaultClusterCreate_blueGreen checks that a blue_green create does not
+// fail on the cluster it replaces, and exposes that cluster's endpoint
+func TestVaultClusterCreate_blueGreen(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[{"id": "vault-old", "name": "test-vault", "clusterUrl": "https://vault-old.example.com:8200"}]`, nil)
+	mock.AddResponse(200, `{"id": "vault-new"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-new", "name": "test-vault", "status": "READY", "clusterUrl": "https://vault-new.example.com:8200"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-old", "name": "test-vault", "status": "READY"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["replacement_strategy"] = "blue_green"
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+	diags := resourceVaultClusterCreate(context.Background(), d, mock.NewConfig(t))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "vault-new" {
+		t.Errorf("expected the new cluster to be created, got ID %q", d.Id())
+	}
+	if got := d.Get("previous_cluster_id").(string); got != "vault-old" {
+		t.Errorf("expected previous_cluster_id vault-old, got %q", got)
+	}
+	if got := d.Get("previous_endpoint").(string); got != "https://vault-old.example.com:8200" {
+		t.Errorf("expected the previous endpoint, got %q", got)
+	}
+	for _, r := range mock.Requests {
+		if r.Method == http.MethodGet && r.URL.Path == "/cloud/project/vault/cluster/vault-old" {
+			return
+		}
+	}
+	t.Error("expected the previous cluster to be read")
+}
+
+// TestVaultClusterRead_previousClusterDestroyed checks that the previous
+// cluster of a blue_green replacement is forgotten once it is destroyed
+func TestVaultClusterRead_previousClusterDestroyed(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-new", "name": "test-vault", "status": "READY"}`, nil)
+	mock.AddResponse(404, `{"message": "cluster not found"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, testVaultClusterRawConfig())
+	d.SetId("vault-new")
+	d.Set("previous_cluster_id", "vault-old")
+	d.Set("previous_endpoint", "https://vault-old.example.com:8200")
+
+	if diags := resourceVaultClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("previous_cluster_id").(string); got != "" {
+		t.Errorf("expected previous_cluster_id to be cleared, got %q", got)
+	}
+	if got := d.Get("previous_endpoint").(string); got != "" {
+		t.Errorf("expected previous_endpoint to be cleared, got %q", got)
+	}
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// clusterReadyPollInterval is how often a cluster is polled while waiting for
+// it to become READY, unless poll_interval is set on the provider.
+var clusterReadyPollInterval = 30 * time.Second
+
+// pollJitter is the fraction of the poll interval by which each poll of a
+// cluster wait is randomly brought forward or delayed, so that clusters
+// created in one apply do not poll the API in lockstep. The mean interval is
+// unchanged.
+const pollJitter = 0.2
+
+// jitter returns interval randomly spread by pollJitter.
+func jitter(interval time.Duration) time.Duration {
+	return interval + time.Duration((rand.Float64()*2-1)*pollJitter*float64(interval))
+}
+
+// waitTimeout returns timeout, shortened to the deadline of ctx, which the
+// SDK sets from the timeout of the resource operation.
+func waitTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			return remaining
+		}
+	}
+	return timeout
+}
+
+// waitForClusterReady polls a cluster of the given service until its status
+// is READY. It is used before and after changes, which only need OVH to
+// accept them: waitForClusterUsable waits for the deeper readiness.
+func waitForClusterReady(ctx context.Context, config *Config, service, clusterId string) error {
+	path := fmt.Sprintf("/cloud/project/%s/cluster/%s", service, clusterId)
+	return waitForStatus(ctx, config, path, "READY", 30*time.Minute, config.pollInterval(clusterReadyPollInterval))
+}
+
+// waitForStatus polls the object at path every interval until its status is
+// target. Failed reads are retried until timeout, and the timeout error holds
+// the last status observed.
+func waitForStatus(ctx context.Context, config *Config, path, target string, timeout, interval time.Duration) error {
+	return waitFor(ctx, config, path, "status "+target, timeout, interval, func(object map[string]interface{}) string {
+		if status := getString(object, "status"); status != target {
+			return fmt.Sprintf("status was %s", status)
+		}
+		return ""
+	})
+}
+
+// waitFor polls the object at path until pending, which describes why the
+// object is not there yet, returns an empty string. Failed reads are retried
+// until the timeout of poll, and the timeout error holds the last
+// description, waiting for what.
+func waitFor(ctx context.Context, config *Config, path, what string, timeout, interval time.Duration, pending func(map[string]interface{}) string) error {
+	last := "status was unknown"
+	return poll(ctx, what, timeout, interval, func() (string, error) {
+		var object map[string]interface{}
+		if err := config.OVHClient.Get(path, &object); err == nil {
+			last = pending(object)
+		}
+		return last, nil
+	})
+}
+
+// poll calls check until it returns an empty description of why it is still
+// pending, or an error, which ends the wait. Calls are interval apart, with
+// jitter. The wait times out after timeout, or at the deadline of ctx when it
+// is sooner, with an error holding the last description, waiting for what.
+func poll(ctx context.Context, what string, timeout, interval time.Duration, check func() (string, error)) error {
+	timeout = waitTimeout(ctx, timeout)
+	deadline := time.After(timeout)
+
+	for {
+		last, err := check()
+		if err != nil {
+			return err
+		}
+		if last == "" {
+			return nil
+		}
+
+		timer := time.NewTimer(jitter(interval))
+		select {
+		case <-deadline:
+		case <-timer.C:
+			continue
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+		timer.Stop()
+		return fmt.Errorf("timeout after %s waiting for %s, last %s", timeout.Round(time.Second), what, last)
+	}
+}
+
+// checkClusterReady returns an error unless the cluster of the given service
+// exists and is READY.
+func checkClusterReady(config *Config, service, clusterId string) error {
+	var cluster map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/%s/cluster/%s", service, clusterId), &cluster)
+	if isOVHErrorCode(err, http.StatusNotFound) {
+		return fmt.Errorf("%s cluster %s does not exist", service, clusterId)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s cluster %s: %w", service, clusterId, err)
+	}
+
+	if status := getString(cluster, "status"); status != "READY" {
+		return fmt.Errorf("%s cluster %s is %s, expected READY", service, clusterId, status)
+	}
+	return nil
+}
+
+// updateCluster waits for a cluster to be READY and then PUTs updateConfig,
+// retrying while OVH reports that another operation is still in progress. It
+// returns the API response, which holds the ID of the operation started by
+// the update.
+func updateCluster(ctx context.Context, config *Config, service, clusterId string, updateConfig map[string]interface{}, timeout time.Duration) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := putWhenReady(ctx, config, service, clusterId, "", updateConfig, &result, timeout)
+	return result, err
+}
+
+// updateClusterTags is updateTags for a cluster, waiting for it to be READY
+// and retrying while another operation is in progress like updateCluster.
+func updateClusterTags(ctx context.Context, config *Config, service, clusterId string, d *schema.ResourceData) error {
+	return putWhenReady(ctx, config, service, clusterId, "/tags", map[string]interface{}{"tags": d.Get("tags")}, nil, d.Timeout(schema.TimeoutUpdate))
+}
+
+// putWhenReady waits for a cluster to be READY and then PUTs reqBody to
+// subPath under it, retrying while OVH reports that another operation is
+// still in progress.
+func putWhenReady(ctx context.Context, config *Config, service, clusterId, subPath string, reqBody, resType interface{}, timeout time.Duration) error {
+	if err := waitForClusterReady(ctx, config, service, clusterId); err != nil {
+		return fmt.Errorf("cluster is not ready for update: %w", err)
+	}
+
+	path := fmt.Sprintf("/cloud/project/%s/cluster/%s%s", service, clusterId, subPath)
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := config.OVHClient.Put(path, reqBody, resType)
+		if isOperationInProgress(err) {
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+}
+
+// isOperationInProgress reports whether err is OVH refusing a change because
+// the cluster is still busy with a previous operation.
+func isOperationInProgress(err error) bool {
+	var apiErr *ovh.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusConflict {
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Message), "in progress")
+}
+
+// unhealthyClusterStatuses are the statuses a cluster does not recover from
+// on its own.
+var unhealthyClusterStatuses = []string{"FAILED", "DEGRADED"}
+
+func recreateIfUnhealthySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Replace the cluster on the next apply when it is read in the FAILED or DEGRADED status. Its data is lost unless a snapshot is restored",
+	}
+}
+
+// planRecreateIfUnhealthy plans the replacement of a cluster that the last
+// read found FAILED or DEGRADED, when recreate_if_unhealthy is set.
+func planRecreateIfUnhealthy(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.Get("recreate_if_unhealthy").(bool) {
+		return nil
+	}
+
+	status, _ := d.GetChange("status")
+	unhealthy := false
+	for _, s := range unhealthyClusterStatuses {
+		unhealthy = unhealthy || status.(string) == s
+	}
+	if !unhealthy {
+		return nil
+	}
+
+	if err := d.SetNewComputed("status"); err != nil {
+		return err
+	}
+	return d.ForceNew("status")
+}
+
+func waitForReadySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Wait for the cluster to be READY before reading, for up to the read timeout",
+	}
+}
+
+// waitForReadyIfRequested blocks a data source read until the cluster is
+// READY when its wait_for_ready attribute is set.
+func waitForReadyIfRequested(ctx context.Context, d *schema.ResourceData, config *Config, service, clusterId string) error {
+	if !d.Get("wait_for_ready").(bool) {
+		return nil
+	}
+	path := fmt.Sprintf("/cloud/project/%s/cluster/%s", service, clusterId)
+	if err := waitForStatus(ctx, config, path, "READY", d.Timeout(schema.TimeoutRead), config.pollInterval(clusterReadyPollInterval)); err != nil {
+		return fmt.Errorf("%s cluster %s is not ready: %w", service, clusterId, err)
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestConsulClusterUpdate_waitsAndRetries checks that an update waits for the
+// cluster to leave PROVISIONING and retries the PUT while an operation is in progress
+func TestConsulClusterUpdate_waitsAndRetries(t *testing.T) {
+	interval := clusterReadyPollInterval
+	clusterReadyPollInterval = 10 * time.Millisecond
+	defer func() { clusterReadyPollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "PROVISIONING"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(409, `{"class": "Client::Conflict", "message": "An operation is already in progress on this cluster"}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "serverCount": 5}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		http.MethodGet,
+		http.MethodGet,
+		http.MethodPut,
+		http.MethodPut,
+		http.MethodGet,
+		http.MethodGet,
+	}
+	if len(mock.Requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, r := range mock.Requests {
+		if r.Method != expected[i] {
+			t.Errorf("request %d: expected %s, got %s", i, expected[i], r.Method)
+		}
+	}
+	if got := d.Get("server_count").(int); got != 5 {
+		t.Errorf("expected server_count 5 after refresh, got %d", got)
+	}
+}
+
+// TestConsulClusterUpdate_readyStatusOnly checks that an update only waits
+// for the READY status, not for every node to have joined, so that it can
+// repair a cluster whose nodes have not
+func TestConsulClusterUpdate_readyStatusOnly(t *testing.T) {
+	interval := clusterReadyPollInterval
+	clusterReadyPollInterval = 10 * time.Millisecond
+	defer func() { clusterReadyPollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY", "serverCount": 3, "nodes": [{"id": "node-1", "status": "RUNNING"}, {"id": "node-2", "status": "FAILED"}]}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if r := mock.Requests[1]; r.Method != http.MethodPut {
+		t.Errorf("expected the cluster to be updated right away, got %s %s", r.Method, r.URL.Path)
+	}
+}
+
+// TestVaultClusterUpdate_tagsOnlyWaitsAndRetries checks that a change
+// limited to tags also waits for the cluster to leave PROVISIONING and
+// retries while an operation is in progress
+func TestVaultClusterUpdate_tagsOnlyWaitsAndRetries(t *testing.T) {
+	interval := clusterReadyPollInterval
+	clusterReadyPollInterval = 10 * time.Millisecond
+	defer func() { clusterReadyPollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "status": "PROVISIONING"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+	mock.AddResponse(409, `{"class": "Client::Conflict", "message": "An operation is already in progress on this cluster"}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "nodeCount": 3, "status": "READY", "tags": {"Environment": "staging"}}`, nil)
+
+	r := resourceVaultCluster()
+	state := &sdkterraform.InstanceState{
+		ID: "vault-123",
+		Attributes: map[string]string{
+			"id":               "vault-123",
+			"name":             "test-vault",
+			"region":           "GRA",
+			"node_count":       "3",
+			"instance_type":    "c2-15",
+			"auto_unseal":      "true",
+			"tags.%":           "1",
+			"tags.Environment": "test",
+		},
+	}
+	raw := testVaultClusterRawConfig()
+	raw["tags"] = map[string]interface{}{"Environment": "staging"}
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	if diags := resourceVaultClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		http.MethodGet + " /cloud/project/vault/cluster/vault-123",
+		http.MethodGet + " /cloud/project/vault/cluster/vault-123",
+		http.MethodPut + " /cloud/project/vault/cluster/vault-123/tags",
+		http.MethodPut + " /cloud/project/vault/cluster/vault-123/tags",
+	}
+	if len(mock.Requests) < len(expected) {
+		t.Fatalf("expected at least %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, want := range expected {
+		if got := mock.Requests[i].Method + " " + mock.Requests[i].URL.Path; got != want {
+			t.Errorf("request %d: expected %s, got %s", i, want, got)
+		}
+	}
+	if got := d.Get("tags").(map[string]interface{})["Environment"]; got != "staging" {
+		t.Errorf("expected tags.Environment staging after refresh, got %v", got)
+	}
+}
+
+func TestConsulClusterUpdate_nonRetryableError(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(400, `{"class": "Client::BadRequest", "message": "invalid serverCount"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if got := mock.GetRequestCount(); got != 2 {
+		t.Errorf("expected the PUT not to be retried, got %d requests", got)
+	}
+}
+
+func TestCheckClusterReady(t *testing.T) {
+	cases := map[string]struct {
+		statusCode  int
+		body        string
+		expectError bool
+	}{
+		"ready":        {statusCode: 200, body: `{"id": "vault-123", "status": "READY"}`},
+		"provisioning": {statusCode: 200, body: `{"id": "vault-123", "status": "PROVISIONING"}`, expectError: true},
+		"missing":      {statusCode: 404, body: `{"message": "This cluster does not exist"}`, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.statusCode, tc.body, nil)
+
+			err := checkClusterReady(mock.NewConfig(t), "vault", "vault-123")
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/vault/cluster/vault-123" {
+				t.Errorf("unexpected request path %s", got)
+			}
+		})
+	}
+}
+
+func TestWaitForStatus(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(500, `{"message": "Internal Server Error"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "status": "PROVISIONING"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+
+	err := waitForStatus(context.Background(), mock.NewConfig(t), "/cloud/project/vault/cluster/vault-123", "READY", time.Minute, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := mock.GetRequestCount(); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestWaitForStatus_timeout(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	for i := 0; i < 100; i++ {
+		mock.AddResponse(200, `{"id": "vault-123", "status": "PROVISIONING"}`, nil)
+	}
+
+	err := waitForStatus(context.Background(), mock.NewConfig(t), "/cloud/project/vault/cluster/vault-123", "READY", 50*time.Millisecond, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if !strings.Contains(err.Error(), "last status was PROVISIONING") {
+		t.Errorf("expected the error to hold the last status, got %q", err)
+	}
+}
+
+// TestWaitForStatus_contextDeadline checks that a wait ends at the deadline
+// of its context, set from the resource timeout, with the last status
+func TestWaitForStatus_contextDeadline(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	for i := 0; i < 100; i++ {
+		mock.AddResponse(200, `{"id": "vault-123", "status": "PROVISIONING"}`, nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := waitForStatus(ctx, mock.NewConfig(t), "/cloud/project/vault/cluster/vault-123", "READY", time.Minute, 10*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the wait to end at the context deadline, took %s", elapsed)
+	}
+	if !strings.Contains(err.Error(), "last status was PROVISIONING") {
+		t.Errorf("expected the error to hold the last status, got %q", err)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	interval := 30 * time.Second
+	low := time.Duration(float64(interval) * (1 - pollJitter))
+	high := time.Duration(float64(interval) * (1 + pollJitter))
+
+	spread := false
+	for i := 0; i < 1000; i++ {
+		got := jitter(interval)
+		if got < low || got > high {
+			t.Fatalf("expected a poll interval between %s and %s, got %s", low, high, got)
+		}
+		if got != interval {
+			spread = true
+		}
+	}
+	if !spread {
+		t.Error("expected the poll interval to be spread")
+	}
+}
+
+// TestVaultCluster_recreateIfUnhealthy checks that a cluster read in a
+// terminal unhealthy status is planned for replacement only when
+// recreate_if_unhealthy is set
+func TestVaultCluster_recreateIfUnhealthy(t *testing.T) {
+	cases := map[string]struct {
+		status        string
+		recreate      bool
+		expectReplace bool
+	}{
+		"failed":                {status: "FAILED", recreate: true, expectReplace: true},
+		"degraded":              {status: "DEGRADED", recreate: true, expectReplace: true},
+		"ready":                 {status: "READY", recreate: true},
+		"failed without opt-in": {status: "FAILED"},
+		"provisioning":          {status: "PROVISIONING", recreate: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			state := &sdkterraform.InstanceState{
+				ID: "vault-123",
+				Attributes: map[string]string{
+					"id":                    "vault-123",
+					"name":                  "test-vault",
+					"region":                "GRA",
+					"node_count":            "3",
+					"instance_type":         "c2-15",
+					"storage_type":          "consul",
+					"auto_unseal":           "true",
+					"status":                tc.status,
+					"recreate_if_unhealthy": strconv.FormatBool(tc.recreate),
+				},
+			}
+			raw := testVaultClusterRawConfig()
+			raw["recreate_if_unhealthy"] = tc.recreate
+
+			diff, err := resourceVaultCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected diff error: %s", err)
+			}
+			if replace := diff != nil && diff.RequiresNew(); replace != tc.expectReplace {
+				t.Errorf("expected replacement %t, got %t", tc.expectReplace, replace)
+			}
+		})
+	}
+}
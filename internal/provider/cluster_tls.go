@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func caCertificateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "PEM encoded CA certificate that clients must trust to connect to the cluster over TLS",
+	}
+}
+
+func clientCertificateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "PEM encoded client certificate signed by ca_certificate, for CLIs and SDKs connecting to the cluster with mutual TLS. Empty when tls_enabled is false",
+	}
+}
+
+func clientKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "PEM encoded private key of client_certificate. Empty when tls_enabled is false",
+	}
+}
+
+// setClusterCertificates reads the CA certificate of a cluster and, with
+// clientCertificates, the client certificate and key it issued. The PKI
+// endpoints are unavailable while a cluster is provisioning, and the client
+// key is only returned in full when it is issued, so failed reads and masked
+// values keep the certificates already in state.
+func setClusterCertificates(config *Config, d *schema.ResourceData, service, clusterId string, clientCertificates bool) {
+	path := fmt.Sprintf("/cloud/project/%s/cluster/%s/pki", service, clusterId)
+
+	var ca map[string]interface{}
+	if err := config.OVHClient.Get(path+"/ca", &ca); err == nil {
+		setWriteOnceString(d, "ca_certificate", ca, "certificate")
+	}
+
+	if !clientCertificates {
+		return
+	}
+
+	var client map[string]interface{}
+	if err := config.OVHClient.Get(path+"/client", &client); err == nil {
+		setWriteOnceString(d, "client_certificate", client, "certificate")
+		setWriteOnceString(d, "client_key", client, "key")
+	}
+}
+
+// setTLSCertificates sets the certificates of a Nomad or Consul cluster, which
+// only has some when tls_enabled is true.
+func setTLSCertificates(config *Config, d *schema.ResourceData, service, clusterId string) {
+	if !d.Get("tls_enabled").(bool) {
+		d.Set("ca_certificate", "")
+		d.Set("client_certificate", "")
+		d.Set("client_key", "")
+		return
+	}
+	setClusterCertificates(config, d, service, clusterId, true)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestConsulClusterRead_tlsCertificates checks that the certificates of a
+// TLS-enabled cluster are read, and kept when the client key is masked
+func TestConsulClusterRead_tlsCertificates(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "tlsEnabled": true}`, nil)
+	mock.AddResponse(200, `{"certificate": "ca-pem"}`, nil)
+	mock.AddResponse(200, `{"certificate": "client-pem", "key": "key-pem"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "tlsEnabled": true}`, nil)
+	mock.AddResponse(200, `{"certificate": "ca-pem"}`, nil)
+	mock.AddResponse(200, `{"certificate": "client-pem", "key": "********"}`, nil)
+
+	config := mock.NewConfig(t)
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	for i := 0; i < 2; i++ {
+		if diags := resourceConsulClusterRead(context.Background(), d, config); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+
+		expected := map[string]string{
+			"ca_certificate":     "ca-pem",
+			"client_certificate": "client-pem",
+			"client_key":         "key-pem",
+		}
+		for key, want := range expected {
+			if got := d.Get(key).(string); got != want {
+				t.Errorf("read %d: expected %s %q, got %q", i+1, key, want, got)
+			}
+		}
+	}
+
+	if got := mock.Requests[2].URL.Path; got != "/cloud/project/consul/cluster/consul-123/pki/client" {
+		t.Errorf("unexpected client certificate path %s", got)
+	}
+}
+
+// TestNomadClusterRead_tlsDisabled checks that a cluster without TLS has no
+// certificates and that the PKI endpoints are not called
+func TestNomadClusterRead_tlsDisabled(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "nomad-123", "name": "test-nomad", "status": "READY", "tlsEnabled": false}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, map[string]interface{}{
+		"name":          "test-nomad",
+		"region":        "GRA",
+		"server_count":  3,
+		"instance_type": "c2-15",
+	})
+	d.SetId("nomad-123")
+
+	if diags := resourceNomadClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	for _, r := range mock.Requests {
+		if r.URL.Path != "/cloud/project/nomad/cluster/nomad-123" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}
+	if got := d.Get("ca_certificate").(string); got != "" {
+		t.Errorf("expected no CA certificate, got %q", got)
+	}
+}
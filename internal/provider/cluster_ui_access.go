@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// uiAllowedCidrsSchema describes the ingress allowlist for a cluster's web UI.
+func uiAllowedCidrsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "CIDR blocks allowed to reach the cluster UI, the UI is open to any address when unset",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.IsCIDR,
+		},
+	}
+}
+
+// openUIWarning is returned on create for sensitive services whose UI is left
+// reachable from any address.
+func openUIWarning(service string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s UI is reachable from any address", service),
+		Detail: fmt.Sprintf("No ui_allowed_cidrs are set, so the %s UI accepts connections from the whole internet. "+
+			"Consider restricting it to the networks your operators connect from.", service),
+	}
+}
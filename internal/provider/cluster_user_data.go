@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxUserDataBytes is the largest user_data payload OVH accepts for an instance.
+const maxUserDataBytes = 64 * 1024
+
+var base64Pattern = regexp.MustCompile(`^[A-Za-z0-9+/]+={0,2}$`)
+
+// userDataSchema describes the cloud-init user data passed to every node of a
+// cluster at creation. Nodes only run it on first boot, so changing it forces
+// the cluster to be recreated.
+func userDataSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Description:  "Cloud-init user data run on every node at first boot, either raw or base64 encoded. Changing it recreates the cluster nodes",
+		ValidateFunc: validateUserData,
+	}
+}
+
+func userDataHashSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "SHA-256 hash of user_data",
+	}
+}
+
+// validateUserData checks user_data against the OVH size limit and, when the
+// value looks base64 encoded, that it decodes.
+func validateUserData(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if len(value) > maxUserDataBytes {
+		errors = append(errors, fmt.Errorf("%q must be at most %d bytes, got %d", k, maxUserDataBytes, len(value)))
+		return
+	}
+
+	if isBase64(value) {
+		if _, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value)); err != nil {
+			errors = append(errors, fmt.Errorf("%q looks base64 encoded but does not decode: %s", k, err))
+		}
+	}
+
+	return
+}
+
+// isBase64 reports whether s only contains base64 characters. Raw scripts and
+// cloud-config documents always contain spaces or newlines, so they never match.
+func isBase64(s string) bool {
+	return base64Pattern.MatchString(strings.TrimSpace(s))
+}
+
+func userDataHash(userData string) string {
+	if userData == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(userData))
+	return hex.EncodeToString(sum[:])
+}
+
+// setUserData adds user_data to a cluster create payload and records its hash.
+func setUserData(d *schema.ResourceData, clusterConfig map[string]interface{}) {
+	userData := d.Get("user_data").(string)
+	if userData != "" {
+		clusterConfig["userData"] = userData
+	}
+	d.Set("user_data_hash", userDataHash(userData))
+}
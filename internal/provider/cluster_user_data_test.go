@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestValidateUserData(t *testing.T) {
+	cases := map[string]struct {
+		value       string
+		expectError bool
+	}{
+		"raw script":     {value: "#!/bin/bash\napt-get install -y datadog-agent\n"},
+		"cloud-config":   {value: "#cloud-config\npackages:\n  - nfs-common\n"},
+		"base64":         {value: "IyEvYmluL2Jhc2gKZWNobyBoZWxsbwo="},
+		"invalid base64": {value: "IyEvYmluL2Jhc2gKZWNobyBoZWxsbwo", expectError: true},
+		"at limit":       {value: "# " + strings.Repeat("a", maxUserDataBytes-2)},
+		"too large":      {value: "# " + strings.Repeat("a", maxUserDataBytes), expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, errs := validateUserData(tc.value, "user_data")
+			if tc.expectError && len(errs) == 0 {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestUserDataHash(t *testing.T) {
+	if got := userDataHash(""); got != "" {
+		t.Errorf("expected no hash for empty user data, got %q", got)
+	}
+	if userDataHash("#!/bin/sh\n") == userDataHash("#!/bin/bash\n") {
+		t.Error("expected different user data to hash differently")
+	}
+}
+
+// TestVaultClusterCreate_userData checks that user_data is sent in the create
+// payload and its hash is recorded in state
+func TestVaultClusterCreate_userData(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY"}`, nil)
+
+	userData := "#!/bin/bash\necho hello\n"
+	raw := testVaultClusterRawConfig()
+	raw["user_data"] = userData
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+	if diags := resourceVaultClusterCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[1]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if body["userData"] != userData {
+		t.Errorf("expected userData in the create payload, got %v", body["userData"])
+	}
+	if got := d.Get("user_data_hash").(string); got != userDataHash(userData) {
+		t.Errorf("expected user_data_hash %q, got %q", userDataHash(userData), got)
+	}
+}
+
+func TestUserDataSchema_forceNew(t *testing.T) {
+	for name, r := range map[string]*schema.Resource{
+		"nomad":    resourceNomadCluster(),
+		"vault":    resourceVaultCluster(),
+		"consul":   resourceConsulCluster(),
+		"boundary": resourceBoundaryCluster(),
+	} {
+		if !r.Schema["user_data"].ForceNew {
+			t.Errorf("expected user_data on the %s cluster to force a new resource", name)
+		}
+	}
+}
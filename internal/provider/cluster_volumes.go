@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var volumeTypes = []string{"classic", "high-speed", "nvme"}
+
+// additionalVolumesSchema describes block storage volumes attached to every
+// node of a cluster at creation.
+func additionalVolumesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "Additional block storage volumes attached to each node at creation",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"size_gb": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "Volume size in GB",
+					ValidateFunc: validateIntBetween(10, 10000),
+				},
+				"type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					Default:      "classic",
+					Description:  "Volume type (classic, high-speed, nvme)",
+					ValidateFunc: validation.StringInSlice(volumeTypes, false),
+				},
+				"mount_point": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ForceNew:     true,
+					Description:  "Absolute path the volume is mounted at on each node",
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/[^\s]+$`), "must be an absolute path other than /"),
+				},
+			},
+		},
+	}
+}
+
+func volumeIdsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "IDs of the additional volumes created for the cluster nodes",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// validateAdditionalVolumes checks that no two additional volumes share a mount point.
+func validateAdditionalVolumes(d *schema.ResourceDiff) error {
+	seen := map[string]bool{}
+	for _, v := range d.Get("additional_volumes").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		mountPoint := v.(map[string]interface{})["mount_point"].(string)
+		if mountPoint == "" {
+			continue
+		}
+		if seen[mountPoint] {
+			return fmt.Errorf("additional_volumes mount_point %q is used more than once", mountPoint)
+		}
+		seen[mountPoint] = true
+	}
+	return nil
+}
+
+func expandAdditionalVolumes(l []interface{}) []interface{} {
+	volumes := make([]interface{}, 0, len(l))
+	for _, v := range l {
+		if v == nil {
+			continue
+		}
+		raw := v.(map[string]interface{})
+		volumes = append(volumes, map[string]interface{}{
+			"sizeGb":     raw["size_gb"].(int),
+			"type":       raw["type"].(string),
+			"mountPoint": raw["mount_point"].(string),
+		})
+	}
+	return volumes
+}
+
+func flattenAdditionalVolumes(cluster map[string]interface{}) []interface{} {
+	items, ok := cluster["additionalVolumes"].([]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+
+	volumes := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		volume, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		volumes = append(volumes, map[string]interface{}{
+			"size_gb":     getInt(volume, "sizeGb"),
+			"type":        getString(volume, "type"),
+			"mount_point": getString(volume, "mountPoint"),
+		})
+	}
+	return volumes
+}
+
+// setAdditionalVolumes reads the volumes back from a cluster API response.
+// Older API responses omit additionalVolumes, in which case the configured
+// block is kept.
+func setAdditionalVolumes(d *schema.ResourceData, cluster map[string]interface{}) {
+	if _, ok := cluster["additionalVolumes"]; ok {
+		d.Set("additional_volumes", flattenAdditionalVolumes(cluster))
+	}
+	d.Set("volume_ids", getStringList(cluster, "volumeIds"))
+}
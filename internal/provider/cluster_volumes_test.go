@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAdditionalVolumes_validation(t *testing.T) {
+	cases := map[string]struct {
+		volumes     []interface{}
+		expectError bool
+	}{
+		"valid": {
+			volumes: []interface{}{
+				map[string]interface{}{"size_gb": 100, "type": "nvme", "mount_point": "/opt/consul/data"},
+				map[string]interface{}{"size_gb": 500, "mount_point": "/var/lib/scratch"},
+			},
+		},
+		"too small": {
+			volumes:     []interface{}{map[string]interface{}{"size_gb": 5, "mount_point": "/data"}},
+			expectError: true,
+		},
+		"too large": {
+			volumes:     []interface{}{map[string]interface{}{"size_gb": 20000, "mount_point": "/data"}},
+			expectError: true,
+		},
+		"unknown type": {
+			volumes:     []interface{}{map[string]interface{}{"size_gb": 100, "type": "ssd", "mount_point": "/data"}},
+			expectError: true,
+		},
+		"relative mount point": {
+			volumes:     []interface{}{map[string]interface{}{"size_gb": 100, "mount_point": "data"}},
+			expectError: true,
+		},
+		"duplicate mount point": {
+			volumes: []interface{}{
+				map[string]interface{}{"size_gb": 100, "mount_point": "/data"},
+				map[string]interface{}{"size_gb": 200, "mount_point": "/data"},
+			},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testVaultClusterRawConfig()
+			raw["additional_volumes"] = tc.volumes
+			config := sdkterraform.NewResourceConfigRaw(raw)
+			r := resourceVaultCluster()
+
+			diags := r.Validate(config)
+			var err error
+			if !diags.HasError() {
+				_, err = r.Diff(context.Background(), nil, config, nil)
+			}
+
+			failed := diags.HasError() || err != nil
+			if tc.expectError && !failed {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && failed {
+				t.Errorf("unexpected error: %v %v", diags, err)
+			}
+		})
+	}
+}
+
+func TestExpandAdditionalVolumes(t *testing.T) {
+	got := expandAdditionalVolumes([]interface{}{
+		map[string]interface{}{"size_gb": 100, "type": "high-speed", "mount_point": "/opt/vault/data"},
+	})
+	expected := []interface{}{
+		map[string]interface{}{"sizeGb": 100, "type": "high-speed", "mountPoint": "/opt/vault/data"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestFlattenAdditionalVolumes(t *testing.T) {
+	got := flattenAdditionalVolumes(map[string]interface{}{
+		"additionalVolumes": []interface{}{
+			map[string]interface{}{"sizeGb": 100.0, "type": "nvme", "mountPoint": "/data"},
+		},
+	})
+	expected := []interface{}{
+		map[string]interface{}{"size_gb": 100, "type": "nvme", "mount_point": "/data"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
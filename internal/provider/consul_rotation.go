@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// rotateConsulGossipKey replaces the gossip encryption key of a Consul
+// cluster. Agents only talk to each other when they share a key, so the new
+// key is first installed next to the current one on every agent, then made
+// the primary key used for encryption, and only then is the old key removed.
+// Each step is waited for, so no agent is ever left without a key its peers
+// use. It returns the new key.
+func rotateConsulGossipKey(ctx context.Context, config *Config, clusterId, oldKey string) (string, error) {
+	path := fmt.Sprintf("/cloud/project/consul/cluster/%s/gossip/keyring", clusterId)
+
+	if err := waitForClusterReady(ctx, config, "consul", clusterId); err != nil {
+		return "", fmt.Errorf("cluster is not ready for gossip key rotation: %w", err)
+	}
+
+	var installed map[string]interface{}
+	if err := config.OVHClient.Post(path, nil, &installed); err != nil {
+		return "", fmt.Errorf("failed to install new gossip key: %w", err)
+	}
+	newKey := getString(installed, "key")
+	if newKey == "" {
+		return "", fmt.Errorf("failed to install new gossip key: no key returned")
+	}
+	if err := waitForClusterOperation(ctx, config, "consul", clusterId, getString(installed, "operationId")); err != nil {
+		return "", fmt.Errorf("failed to install new gossip key: %w", err)
+	}
+
+	var primary map[string]interface{}
+	if err := config.OVHClient.Put(path+"/primary", map[string]interface{}{"key": newKey}, &primary); err != nil {
+		return "", fmt.Errorf("failed to make the new gossip key primary: %w", err)
+	}
+	if err := waitForClusterOperation(ctx, config, "consul", clusterId, getString(primary, "operationId")); err != nil {
+		return "", fmt.Errorf("failed to make the new gossip key primary: %w", err)
+	}
+
+	if oldKey == "" || oldKey == newKey {
+		return newKey, nil
+	}
+
+	var removed map[string]interface{}
+	if err := config.OVHClient.Delete(fmt.Sprintf("%s/%s", path, url.PathEscape(oldKey)), &removed); err != nil {
+		return "", fmt.Errorf("failed to remove the previous gossip key: %w", err)
+	}
+	if err := waitForClusterOperation(ctx, config, "consul", clusterId, getString(removed, "operationId")); err != nil {
+		return "", fmt.Errorf("failed to remove the previous gossip key: %w", err)
+	}
+	return newKey, nil
+}
+
+// rotateConsulACLTokens issues a new ACL master token for a Consul cluster and
+// revokes the previous one once the new token is active on every server. It
+// returns the new token.
+func rotateConsulACLTokens(ctx context.Context, config *Config, clusterId string) (string, error) {
+	if err := waitForClusterReady(ctx, config, "consul", clusterId); err != nil {
+		return "", fmt.Errorf("cluster is not ready for ACL token rotation: %w", err)
+	}
+
+	var result map[string]interface{}
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/consul/cluster/%s/acl/token/rotate", clusterId), nil, &result)
+	if err != nil {
+		return "", fmt.Errorf("failed to rotate ACL tokens: %w", err)
+	}
+	if err := waitForClusterOperation(ctx, config, "consul", clusterId, getString(result, "operationId")); err != nil {
+		return "", fmt.Errorf("failed to rotate ACL tokens: %w", err)
+	}
+	return getString(result, "masterToken"), nil
+}
+
+// rotateConsulConnectCA replaces the root certificate of the Connect CA of a
+// Consul cluster. The new root is cross-signed by the current one, so leaf
+// certificates issued by either are trusted while services pick up new leaves,
+// and the old root is only dropped once no leaf depends on it.
+func rotateConsulConnectCA(ctx context.Context, config *Config, clusterId string) error {
+	if err := waitForClusterReady(ctx, config, "consul", clusterId); err != nil {
+		return fmt.Errorf("cluster is not ready for Connect CA rotation: %w", err)
+	}
+
+	var result map[string]interface{}
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/consul/cluster/%s/connect/ca/rotate", clusterId), nil, &result)
+	if err != nil {
+		return fmt.Errorf("failed to rotate Connect CA: %w", err)
+	}
+	if err := waitForClusterOperation(ctx, config, "consul", clusterId, getString(result, "operationId")); err != nil {
+		return fmt.Errorf("failed to rotate Connect CA: %w", err)
+	}
+	return nil
+}
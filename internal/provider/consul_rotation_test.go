@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testConsulClusterUpdateData plans raw against a READY Consul cluster whose
+// rotation generations are 0
+func testConsulClusterUpdateData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	r := resourceConsulCluster()
+	state := &sdkterraform.InstanceState{
+		ID: "consul-123",
+		Attributes: map[string]string{
+			"id":                 "consul-123",
+			"name":               "test-consul",
+			"region":             "GRA",
+			"server_count":       "3",
+			"client_count":       "3",
+			"instance_type":      "c2-15",
+			"datacenter":         "dc1",
+			"acl_enabled":        "true",
+			"encryption_enabled": "true",
+			"connect_enabled":    "true",
+			"tls_enabled":        "true",
+			"gossip_key":         "old-key",
+			"master_token":       "old-token",
+			"rotate_gossip_key":  "0",
+			"rotate_acl_tokens":  "0",
+			"rotate_ca":          "0",
+		},
+	}
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+	return d
+}
+
+// TestConsulClusterUpdate_rotateGossipKey checks that bumping
+// rotate_gossip_key installs the new key, makes it primary and only then
+// removes the old one
+func TestConsulClusterUpdate_rotateGossipKey(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"key": "new-key", "operationId": "op-1"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-2"}`, nil)
+	mock.AddResponse(200, `{"id": "op-2", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-3"}`, nil)
+	mock.AddResponse(200, `{"id": "op-3", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "gossipKey": "********"}`, nil)
+
+	raw := testConsulClusterRawConfig()
+	raw["rotate_gossip_key"] = 1
+	d := testConsulClusterUpdateData(t, raw)
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []struct{ method, path string }{
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123"},
+		{http.MethodPost, "/cloud/project/consul/cluster/consul-123/gossip/keyring"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123/operation/op-1"},
+		{http.MethodPut, "/cloud/project/consul/cluster/consul-123/gossip/keyring/primary"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123/operation/op-2"},
+		{http.MethodDelete, "/cloud/project/consul/cluster/consul-123/gossip/keyring/old-key"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123/operation/op-3"},
+		{http.MethodGet, "/cloud/project/consul/cluster/consul-123"},
+	}
+	if len(mock.Requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, r := range mock.Requests {
+		if r.Method != expected[i].method || r.URL.Path != expected[i].path {
+			t.Errorf("request %d: expected %s %s, got %s %s", i, expected[i].method, expected[i].path, r.Method, r.URL.Path)
+		}
+	}
+	if body := mock.RequestBodies[3]; body != `{"key":"new-key"}` {
+		t.Errorf("expected the new key to be made primary, got %s", body)
+	}
+	if got := d.Get("gossip_key").(string); got != "new-key" {
+		t.Errorf("expected gossip_key to be the rotated key, got %q", got)
+	}
+}
+
+func TestConsulClusterUpdate_rotateACLTokens(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-1", "masterToken": "new-token"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY"}`, nil)
+
+	raw := testConsulClusterRawConfig()
+	raw["rotate_acl_tokens"] = 1
+	d := testConsulClusterUpdateData(t, raw)
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if r := mock.Requests[1]; r.Method != http.MethodPost || r.URL.Path != "/cloud/project/consul/cluster/consul-123/acl/token/rotate" {
+		t.Errorf("expected the ACL token rotation endpoint to be called, got %s %s", r.Method, r.URL.Path)
+	}
+	if got := mock.GetRequestCount(); got != 4 {
+		t.Errorf("expected no gossip key rotation, got %d requests", got)
+	}
+	if got := d.Get("master_token").(string); got != "new-token" {
+		t.Errorf("expected master_token to be the rotated token, got %q", got)
+	}
+}
+
+// TestConsulClusterUpdate_rotationFailure checks that a failed rotation keeps
+// its trigger unconsumed, so that the next apply rotates again
+func TestConsulClusterUpdate_rotationFailure(t *testing.T) {
+	for _, key := range []string{"rotate_gossip_key", "rotate_acl_tokens", "rotate_ca"} {
+		t.Run(key, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+			mock.AddResponse(403, `{"message": "This call has not been granted"}`, nil)
+
+			raw := testConsulClusterRawConfig()
+			raw[key] = 1
+			d := testConsulClusterUpdateData(t, raw)
+
+			if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			if got := d.State().Attributes[key]; got != "0" {
+				t.Errorf("expected %s to stay 0, got %q", key, got)
+			}
+		})
+	}
+}
+
+// TestConsulClusterUpdate_rotateCA checks that bumping rotate_ca rotates the
+// Connect CA and refreshes ca_expires_at
+func TestConsulClusterUpdate_rotateCA(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-1"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "name": "test-consul", "status": "READY", "caExpiresAt": "2036-10-15T00:00:00Z"}`, nil)
+
+	raw := testConsulClusterRawConfig()
+	raw["rotate_ca"] = 1
+	d := testConsulClusterUpdateData(t, raw)
+
+	if diags := resourceConsulClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if r := mock.Requests[1]; r.Method != http.MethodPost || r.URL.Path != "/cloud/project/consul/cluster/consul-123/connect/ca/rotate" {
+		t.Errorf("expected the Connect CA rotation endpoint to be called, got %s %s", r.Method, r.URL.Path)
+	}
+	if got := mock.GetRequestCount(); got != 4 {
+		t.Errorf("expected no other rotation or update, got %d requests", got)
+	}
+	if got := d.Get("ca_expires_at").(string); got != "2036-10-15T00:00:00Z" {
+		t.Errorf("expected ca_expires_at to be refreshed, got %q", got)
+	}
+}
+
+// TestConsulCluster_rotationRequiresFeature checks that rotating a secret the
+// cluster does not use is rejected at plan time
+func TestConsulCluster_rotationRequiresFeature(t *testing.T) {
+	r := resourceConsulCluster()
+	state := &sdkterraform.InstanceState{
+		ID: "consul-123",
+		Attributes: map[string]string{
+			"id":                 "consul-123",
+			"encryption_enabled": "false",
+			"rotate_gossip_key":  "0",
+		},
+	}
+
+	raw := testConsulClusterRawConfig()
+	raw["encryption_enabled"] = false
+	raw["rotate_gossip_key"] = 1
+
+	if _, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestConsulCluster_rotateCARequiresConnect(t *testing.T) {
+	r := resourceConsulCluster()
+	state := &sdkterraform.InstanceState{
+		ID: "consul-123",
+		Attributes: map[string]string{
+			"id":              "consul-123",
+			"connect_enabled": "false",
+			"rotate_ca":       "0",
+		},
+	}
+
+	raw := testConsulClusterRawConfig()
+	raw["connect_enabled"] = false
+	raw["rotate_ca"] = 1
+
+	if _, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,6 +26,7 @@ func dataSourceBoundaryClusters() *schema.Resource {
 				Optional:    true,
 				Description: "Filter clusters by status",
 			},
+			"only_healthy": onlyHealthySchema(),
 			"clusters": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -38,6 +38,7 @@ func dataSourceBoundaryClusters() *schema.Resource {
 							Computed:    true,
 							Description: "Cluster ID",
 						},
+						"import_id": importIDSchema("hashicorp_ovh_boundary_cluster"),
 						"name": {
 							Type:        schema.TypeString,
 							Computed:    true,
@@ -106,7 +107,7 @@ func dataSourceBoundaryClustersRead(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 
 	var clusters []map[string]interface{}
-	err := config.OVHClient.Get("/cloud/project/boundary/cluster", &clusters)
+	err := config.getList("/cloud/project/boundary/cluster", &clusters)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to read Boundary clusters: %w", err))
 	}
@@ -116,28 +117,39 @@ func dataSourceBoundaryClustersRead(ctx context.Context, d *schema.ResourceData,
 
 	var filteredClusters []map[string]interface{}
 	for _, cluster := range clusters {
-		if region != "" && cluster["region"].(string) != region {
+		if region != "" && getString(cluster, "region") != region {
 			continue
 		}
-		if status != "" && cluster["status"].(string) != status {
+		if status != "" && getString(cluster, "status") != status {
 			continue
 		}
 		filteredClusters = append(filteredClusters, cluster)
 	}
 
+	onlyHealthy := d.Get("only_healthy").(bool)
+	if onlyHealthy {
+		filteredClusters, err = filterHealthyClusters(config, "boundary", filteredClusters)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to read Boundary clusters: %w", err))
+		}
+	}
+
 	clusterList := make([]interface{}, len(filteredClusters))
+	ids := make([]string, len(filteredClusters))
 	for i, cluster := range filteredClusters {
+		ids[i] = getString(cluster, "id")
 		clusterMap := map[string]interface{}{
-			"id":                   cluster["id"],
-			"name":                 cluster["name"],
-			"region":               cluster["region"],
-			"controller_count":     cluster["controllerCount"],
-			"worker_count":         cluster["workerCount"],
-			"instance_type":        cluster["instanceType"],
-			"vault_integration":    cluster["vaultIntegration"],
-			"controller_endpoints": cluster["controllerEndpoints"],
-			"ui_url":               cluster["uiUrl"],
-			"status":               cluster["status"],
+			"id":                   getString(cluster, "id"),
+			"import_id":            importID(cluster),
+			"name":                 getString(cluster, "name"),
+			"region":               getString(cluster, "region"),
+			"controller_count":     getInt(cluster, "controllerCount"),
+			"worker_count":         getInt(cluster, "workerCount"),
+			"instance_type":        getString(cluster, "instanceType"),
+			"vault_integration":    getBool(cluster, "vaultIntegration"),
+			"controller_endpoints": getStringList(cluster, "controllerEndpoints"),
+			"ui_url":               getString(cluster, "uiUrl"),
+			"status":               getString(cluster, "status"),
 		}
 
 		if tags, ok := cluster["tags"].(map[string]interface{}); ok {
@@ -148,7 +160,7 @@ func dataSourceBoundaryClustersRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	d.Set("clusters", clusterList)
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(listID(ids, "boundary", region, status, strconv.FormatBool(onlyHealthy)))
 
 	return diags
 }
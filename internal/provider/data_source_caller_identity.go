@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCallerIdentity() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the OVH endpoint, project and API credential the provider resolved, without exposing secrets",
+
+		ReadContext: dataSourceCallerIdentityRead,
+
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "OVH API endpoint the provider is configured for",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "OVH Public Cloud project ID the provider is configured for",
+			},
+			"credential_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the API credential used to authenticate",
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the API application the credential belongs to",
+			},
+			"credential_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the API credential",
+			},
+		},
+	}
+}
+
+func dataSourceCallerIdentityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	var diags diag.Diagnostics
+
+	var credential map[string]interface{}
+	err := config.OVHClient.Get("/auth/currentCredential", &credential)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read current OVH credential: %w", err))
+	}
+
+	credentialId := strconv.Itoa(getInt(credential, "credentialId"))
+
+	d.Set("endpoint", config.Endpoint)
+	d.Set("project_id", config.ProjectID)
+	d.Set("credential_id", credentialId)
+	d.Set("application_id", strconv.Itoa(getInt(credential, "applicationId")))
+	d.Set("credential_status", getString(credential, "status"))
+	d.SetId(credentialId)
+
+	return diags
+}
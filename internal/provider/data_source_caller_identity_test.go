@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCallerIdentityRead(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "credentialId": 123456,
+  "applicationId": 7890,
+  "status": "validated",
+  "rules": [{"method": "GET", "path": "/*"}]
+}`, nil)
+
+	config := mock.NewConfig(t)
+	config.Endpoint = "ovh-eu"
+	config.ProjectID = "project-123"
+
+	d := schema.TestResourceDataRaw(t, dataSourceCallerIdentity().Schema, map[string]interface{}{})
+
+	if diags := dataSourceCallerIdentityRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := map[string]string{
+		"endpoint":          "ovh-eu",
+		"project_id":        "project-123",
+		"credential_id":     "123456",
+		"application_id":    "7890",
+		"credential_status": "validated",
+	}
+	for key, value := range expected {
+		if got := d.Get(key).(string); got != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, got)
+		}
+	}
+	if d.Id() != "123456" {
+		t.Errorf("expected ID 123456, got %q", d.Id())
+	}
+}
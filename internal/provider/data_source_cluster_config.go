@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNomadClusterConfig() *schema.Resource {
+	return clusterConfigDataSource("nomad", "Nomad")
+}
+
+func dataSourceVaultClusterConfig() *schema.Resource {
+	return clusterConfigDataSource("vault", "Vault")
+}
+
+func dataSourceConsulClusterConfig() *schema.Resource {
+	return clusterConfigDataSource("consul", "Consul")
+}
+
+// clusterConfigDataSource builds a data source returning the address, CA
+// certificate and a short-lived token needed to connect a client or the
+// official product provider to a cluster of the given service.
+func clusterConfigDataSource(service, product string) *schema.Resource {
+	return &schema.Resource{
+		Description: fmt.Sprintf("Retrieves a connection bundle with a short-lived token for a %s cluster", product),
+
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return clusterConfigRead(ctx, d, meta, service, product)
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: fmt.Sprintf("ID of the %s cluster", product),
+			},
+			"wait_for_ready": waitForReadySchema(),
+			"address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: fmt.Sprintf("%s API address", product),
+			},
+			"ca_cert": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "PEM encoded CA certificate of the cluster",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Short-lived %s token, a new one is issued on every read", product),
+			},
+			"token_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry time of the token in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func clusterConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}, service, product string) diag.Diagnostics {
+	config := meta.(*Config)
+	var diags diag.Diagnostics
+
+	clusterId := d.Get("cluster_id").(string)
+	if err := waitForReadyIfRequested(ctx, d, config, service, clusterId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var bundle map[string]interface{}
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/%s/cluster/%s/connection", service, clusterId), nil, &bundle)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read %s cluster connection details: %w", product, err))
+	}
+
+	d.Set("address", getString(bundle, "address"))
+	d.Set("ca_cert", getString(bundle, "caCert"))
+	d.Set("token", getString(bundle, "token"))
+	d.Set("token_expires_at", getString(bundle, "expiresAt"))
+	d.SetId(clusterId)
+
+	return diags
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNomadClusterConfigRead(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "address": "https://nomad-123.nomad.ovh.net:4646",
+  "caCert": "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+  "token": "s.short-lived",
+  "expiresAt": "2026-10-15T12:00:00Z"
+}`, nil)
+
+	r := dataSourceNomadClusterConfig()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_id": "nomad-123",
+	})
+
+	if diags := r.ReadContext(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	req := mock.GetLastRequest()
+	if req.Method != http.MethodPost || req.URL.Path != "/cloud/project/nomad/cluster/nomad-123/connection" {
+		t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	}
+
+	expected := map[string]string{
+		"address":          "https://nomad-123.nomad.ovh.net:4646",
+		"ca_cert":          "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+		"token":            "s.short-lived",
+		"token_expires_at": "2026-10-15T12:00:00Z",
+	}
+	for key, value := range expected {
+		if got := d.Get(key).(string); got != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, got)
+		}
+	}
+	if d.Id() != "nomad-123" {
+		t.Errorf("expected ID nomad-123, got %q", d.Id())
+	}
+}
+
+func TestClusterConfigDataSources_sensitive(t *testing.T) {
+	for name, r := range map[string]*schema.Resource{
+		"nomad":  dataSourceNomadClusterConfig(),
+		"vault":  dataSourceVaultClusterConfig(),
+		"consul": dataSourceConsulClusterConfig(),
+	} {
+		for _, key := range []string{"ca_cert", "token"} {
+			if !r.Schema[key].Sensitive {
+				t.Errorf("expected %s on the %s cluster config data source to be sensitive", key, name)
+			}
+		}
+	}
+}
+
+func TestClusterConfigRead_waitForReady(t *testing.T) {
+	interval := clusterReadyPollInterval
+	clusterReadyPollInterval = 10 * time.Millisecond
+	defer func() { clusterReadyPollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "consul-123", "status": "PROVISIONING"}`, nil)
+	mock.AddResponse(200, `{"id": "consul-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"address": "https://consul-123.consul.ovh.net:8501", "token": "s.short-lived"}`, nil)
+
+	r := dataSourceConsulClusterConfig()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"cluster_id":     "consul-123",
+		"wait_for_ready": true,
+	})
+
+	if diags := r.ReadContext(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{
+		http.MethodGet + " /cloud/project/consul/cluster/consul-123",
+		http.MethodGet + " /cloud/project/consul/cluster/consul-123",
+		http.MethodPost + " /cloud/project/consul/cluster/consul-123/connection",
+	}
+	if len(mock.Requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, r := range mock.Requests {
+		if got := r.Method + " " + r.URL.Path; got != expected[i] {
+			t.Errorf("request %d: expected %s, got %s", i, expected[i], got)
+		}
+	}
+}
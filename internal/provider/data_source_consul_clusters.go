@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,6 +31,7 @@ func dataSourceConsulClusters() *schema.Resource {
 				Optional:    true,
 				Description: "Filter clusters by status",
 			},
+			"only_healthy": onlyHealthySchema(),
 			"clusters": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -43,6 +43,7 @@ func dataSourceConsulClusters() *schema.Resource {
 							Computed:    true,
 							Description: "Cluster ID",
 						},
+						"import_id": importIDSchema("hashicorp_ovh_consul_cluster"),
 						"name": {
 							Type:        schema.TypeString,
 							Computed:    true,
@@ -121,7 +122,7 @@ func dataSourceConsulClustersRead(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 
 	var clusters []map[string]interface{}
-	err := config.OVHClient.Get("/cloud/project/consul/cluster", &clusters)
+	err := config.getList("/cloud/project/consul/cluster", &clusters)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to read Consul clusters: %w", err))
 	}
@@ -132,33 +133,44 @@ func dataSourceConsulClustersRead(ctx context.Context, d *schema.ResourceData, m
 
 	var filteredClusters []map[string]interface{}
 	for _, cluster := range clusters {
-		if region != "" && cluster["region"].(string) != region {
+		if region != "" && getString(cluster, "region") != region {
 			continue
 		}
-		if datacenter != "" && cluster["datacenter"].(string) != datacenter {
+		if datacenter != "" && getString(cluster, "datacenter") != datacenter {
 			continue
 		}
-		if status != "" && cluster["status"].(string) != status {
+		if status != "" && getString(cluster, "status") != status {
 			continue
 		}
 		filteredClusters = append(filteredClusters, cluster)
 	}
 
+	onlyHealthy := d.Get("only_healthy").(bool)
+	if onlyHealthy {
+		filteredClusters, err = filterHealthyClusters(config, "consul", filteredClusters)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to read Consul clusters: %w", err))
+		}
+	}
+
 	clusterList := make([]interface{}, len(filteredClusters))
+	ids := make([]string, len(filteredClusters))
 	for i, cluster := range filteredClusters {
+		ids[i] = getString(cluster, "id")
 		clusterMap := map[string]interface{}{
-			"id":               cluster["id"],
-			"name":             cluster["name"],
-			"region":           cluster["region"],
-			"server_count":     cluster["serverCount"],
-			"client_count":     cluster["clientCount"],
-			"instance_type":    cluster["instanceType"],
-			"datacenter":       cluster["datacenter"],
-			"connect_enabled":  cluster["connectEnabled"],
-			"acl_enabled":      cluster["aclEnabled"],
-			"server_endpoints": cluster["serverEndpoints"],
-			"ui_url":           cluster["uiUrl"],
-			"status":           cluster["status"],
+			"id":               getString(cluster, "id"),
+			"import_id":        importID(cluster),
+			"name":             getString(cluster, "name"),
+			"region":           getString(cluster, "region"),
+			"server_count":     getInt(cluster, "serverCount"),
+			"client_count":     getInt(cluster, "clientCount"),
+			"instance_type":    getString(cluster, "instanceType"),
+			"datacenter":       getString(cluster, "datacenter"),
+			"connect_enabled":  getBool(cluster, "connectEnabled"),
+			"acl_enabled":      getBool(cluster, "aclEnabled"),
+			"server_endpoints": getStringList(cluster, "serverEndpoints"),
+			"ui_url":           getString(cluster, "uiUrl"),
+			"status":           getString(cluster, "status"),
 		}
 
 		if tags, ok := cluster["tags"].(map[string]interface{}); ok {
@@ -169,7 +181,7 @@ func dataSourceConsulClustersRead(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	d.Set("clusters", clusterList)
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(listID(ids, "consul", region, datacenter, status, strconv.FormatBool(onlyHealthy)))
 
 	return diags
 }
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceImage() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up an OVH Public Cloud image by name and region, such as the source_image of a Packer template",
+
+		ReadContext: dataSourceImageRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Exact name of the image, such as Ubuntu 22.04",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "OpenStack region of the image, such as GRA11",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only match images with this visibility (public, private, shared)",
+				ValidateFunc: validation.StringInSlice([]string{"public", "private", "shared"}, false),
+			},
+			"flavor_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only match images for this type of flavor, such as baremetal",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the image, active once it can be booted",
+			},
+			"min_disk": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum disk size in GB of the instances booting the image",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the image was created, in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	if config.ProjectID == "" {
+		return diag.Errorf("no OVH project configured to list images from")
+	}
+
+	name := d.Get("name").(string)
+	region := d.Get("region").(string)
+	visibility := d.Get("visibility").(string)
+
+	query := url.Values{}
+	query.Set("region", region)
+	if flavorType := d.Get("flavor_type").(string); flavorType != "" {
+		query.Set("flavorType", flavorType)
+	}
+
+	var images []map[string]interface{}
+	err := config.getList(fmt.Sprintf("/cloud/project/%s/image?%s", url.PathEscape(config.ProjectID), query.Encode()), &images)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to list images: %w", err))
+	}
+
+	// The API filters by region and flavor type only, the name and
+	// visibility are matched here.
+	var matches []map[string]interface{}
+	for _, image := range images {
+		if getString(image, "name") != name {
+			continue
+		}
+		if visibility != "" && getString(image, "visibility") != visibility {
+			continue
+		}
+		matches = append(matches, image)
+	}
+
+	switch len(matches) {
+	case 0:
+		return diag.Errorf("no image named %q found in region %s", name, region)
+	case 1:
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, image := range matches {
+			ids = append(ids, getString(image, "id"))
+		}
+		return diag.Errorf("%d images are named %q in region %s (%s), set visibility or flavor_type to select one", len(matches), name, region, strings.Join(ids, ", "))
+	}
+
+	image := matches[0]
+	d.SetId(getString(image, "id"))
+	d.Set("status", getString(image, "status"))
+	d.Set("min_disk", getInt(image, "minDisk"))
+	d.Set("created_at", getString(image, "creationDate"))
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testImages = `[
+  {"id": "img-ubuntu", "name": "Ubuntu 22.04", "region": "GRA", "visibility": "public", "status": "active", "minDisk": 10, "creationDate": "2024-05-02T08:00:00Z"},
+  {"id": "img-ubuntu-custom", "name": "Ubuntu 22.04", "region": "GRA", "visibility": "private", "status": "active", "minDisk": 20, "creationDate": "2024-06-10T08:00:00Z"},
+  {"id": "img-ubuntu-24", "name": "Ubuntu 24.04", "region": "GRA", "visibility": "public", "status": "active", "minDisk": 10, "creationDate": "2024-05-20T08:00:00Z"}
+]`
+
+func TestImageRead(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, testImages, nil)
+
+	config := mock.NewConfig(t)
+	config.ProjectID = "project-123"
+
+	d := schema.TestResourceDataRaw(t, dataSourceImage().Schema, map[string]interface{}{
+		"name":        "Ubuntu 22.04",
+		"region":      "GRA",
+		"visibility":  "public",
+		"flavor_type": "baremetal",
+	})
+
+	if diags := dataSourceImageRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "img-ubuntu" {
+		t.Errorf("expected ID img-ubuntu, got %q", d.Id())
+	}
+	if got := d.Get("min_disk").(int); got != 10 {
+		t.Errorf("expected min_disk 10, got %d", got)
+	}
+	if got := d.Get("created_at").(string); got != "2024-05-02T08:00:00Z" {
+		t.Errorf("expected created_at 2024-05-02T08:00:00Z, got %q", got)
+	}
+
+	request := mock.GetLastRequest()
+	if request.URL.Path != "/cloud/project/project-123/image" {
+		t.Errorf("expected the images of the project to be listed, got %s", request.URL.Path)
+	}
+	if query := request.URL.Query(); query.Get("region") != "GRA" || query.Get("flavorType") != "baremetal" {
+		t.Errorf("expected the region and flavor type to filter the list, got %s", request.URL.RawQuery)
+	}
+}
+
+func TestImageRead_noSingleMatch(t *testing.T) {
+	cases := map[string]struct {
+		name      string
+		expectErr string
+	}{
+		"no match":         {name: "Debian 12", expectErr: `no image named "Debian 12"`},
+		"multiple matches": {name: "Ubuntu 22.04", expectErr: "2 images are named"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, testImages, nil)
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "project-123"
+
+			d := schema.TestResourceDataRaw(t, dataSourceImage().Schema, map[string]interface{}{
+				"name":   tc.name,
+				"region": "GRA",
+			})
+
+			diags := dataSourceImageRead(context.Background(), d, config)
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.expectErr) {
+				t.Fatalf("expected an error containing %s, got %v", tc.expectErr, diags)
+			}
+		})
+	}
+}
+
+// TestImage_openStackRegion checks that region takes the OpenStack regions
+// images are listed by, such as GRA11
+func TestImage_openStackRegion(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":   "Ubuntu 22.04",
+		"region": "GRA11",
+	}
+	if diags := dataSourceImage().Validate(sdkterraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Errorf("unexpected validation error: %v", diags)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,6 +26,7 @@ func dataSourceNomadClusters() *schema.Resource {
 				Optional:    true,
 				Description: "Filter clusters by status",
 			},
+			"only_healthy": onlyHealthySchema(),
 			"clusters": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -38,6 +38,7 @@ func dataSourceNomadClusters() *schema.Resource {
 							Computed:    true,
 							Description: "Cluster ID",
 						},
+						"import_id": importIDSchema("hashicorp_ovh_nomad_cluster"),
 						"name": {
 							Type:        schema.TypeString,
 							Computed:    true,
@@ -121,7 +122,7 @@ func dataSourceNomadClustersRead(ctx context.Context, d *schema.ResourceData, me
 	var diags diag.Diagnostics
 
 	var clusters []map[string]interface{}
-	err := config.OVHClient.Get("/cloud/project/nomad/cluster", &clusters)
+	err := config.getList("/cloud/project/nomad/cluster", &clusters)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to read Nomad clusters: %w", err))
 	}
@@ -131,31 +132,42 @@ func dataSourceNomadClustersRead(ctx context.Context, d *schema.ResourceData, me
 
 	var filteredClusters []map[string]interface{}
 	for _, cluster := range clusters {
-		if region != "" && cluster["region"].(string) != region {
+		if region != "" && getString(cluster, "region") != region {
 			continue
 		}
-		if status != "" && cluster["status"].(string) != status {
+		if status != "" && getString(cluster, "status") != status {
 			continue
 		}
 		filteredClusters = append(filteredClusters, cluster)
 	}
 
+	onlyHealthy := d.Get("only_healthy").(bool)
+	if onlyHealthy {
+		filteredClusters, err = filterHealthyClusters(config, "nomad", filteredClusters)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to read Nomad clusters: %w", err))
+		}
+	}
+
 	clusterList := make([]interface{}, len(filteredClusters))
+	ids := make([]string, len(filteredClusters))
 	for i, cluster := range filteredClusters {
+		ids[i] = getString(cluster, "id")
 		clusterMap := map[string]interface{}{
-			"id":                 cluster["id"],
-			"name":               cluster["name"],
-			"region":             cluster["region"],
-			"server_count":       cluster["serverCount"],
-			"client_count":       cluster["clientCount"],
-			"instance_type":      cluster["instanceType"],
-			"datacenter":         cluster["datacenter"],
-			"vault_integration":  cluster["vaultIntegration"],
-			"consul_integration": cluster["consulIntegration"],
-			"server_endpoints":   cluster["serverEndpoints"],
-			"ui_url":             cluster["uiUrl"],
-			"status":             cluster["status"],
-			"created_at":         cluster["createdAt"],
+			"id":                 getString(cluster, "id"),
+			"import_id":          importID(cluster),
+			"name":               getString(cluster, "name"),
+			"region":             getString(cluster, "region"),
+			"server_count":       getInt(cluster, "serverCount"),
+			"client_count":       getInt(cluster, "clientCount"),
+			"instance_type":      getString(cluster, "instanceType"),
+			"datacenter":         getString(cluster, "datacenter"),
+			"vault_integration":  getBool(cluster, "vaultIntegration"),
+			"consul_integration": getBool(cluster, "consulIntegration"),
+			"server_endpoints":   getStringList(cluster, "serverEndpoints"),
+			"ui_url":             getString(cluster, "uiUrl"),
+			"status":             getString(cluster, "status"),
+			"created_at":         getString(cluster, "createdAt"),
 		}
 
 		if tags, ok := cluster["tags"].(map[string]interface{}); ok {
@@ -166,7 +178,7 @@ func dataSourceNomadClustersRead(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	d.Set("clusters", clusterList)
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(listID(ids, "nomad", region, status, strconv.FormatBool(onlyHealthy)))
 
 	return diags
 }
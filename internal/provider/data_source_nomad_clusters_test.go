@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestNomadClustersRead_stableID checks that repeated reads with the same
+// filters keep the same ID, including when no cluster matches
+func TestNomadClustersRead_stableID(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `[]`, nil)
+
+	config := mock.NewConfig(t)
+	read := func(filters map[string]interface{}) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, dataSourceNomadClusters().Schema, filters)
+		if diags := dataSourceNomadClustersRead(context.Background(), d, config); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return d
+	}
+
+	first := read(map[string]interface{}{"region": "GRA"})
+	second := read(map[string]interface{}{"region": "GRA"})
+	other := read(map[string]interface{}{"region": "SBG"})
+
+	if first.Id() == "" || first.Id() != second.Id() {
+		t.Errorf("expected identical reads to share an ID, got %q and %q", first.Id(), second.Id())
+	}
+	if first.Id() == other.Id() {
+		t.Errorf("expected different filters to yield different IDs, both got %q", first.Id())
+	}
+	if clusters := first.Get("clusters").([]interface{}); len(clusters) != 0 {
+		t.Errorf("expected no clusters, got %v", clusters)
+	}
+}
+
+// TestNomadClustersRead_idFollowsResults checks that the ID changes with the
+// returned clusters but not with the order the API lists them in
+func TestNomadClustersRead_idFollowsResults(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[{"id": "nomad-1"}, {"id": "nomad-2"}]`, nil)
+	mock.AddResponse(200, `[{"id": "nomad-2"}, {"id": "nomad-1"}]`, nil)
+	mock.AddResponse(200, `[{"id": "nomad-1"}]`, nil)
+
+	// Each read runs in its own provider instance, as in separate plans, so
+	// that the list is not served from the cache.
+	read := func() string {
+		d := schema.TestResourceDataRaw(t, dataSourceNomadClusters().Schema, map[string]interface{}{})
+		if diags := dataSourceNomadClustersRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		return d.Id()
+	}
+
+	first, reordered, shrunk := read(), read(), read()
+	if first != reordered {
+		t.Errorf("expected reordered results to keep the ID, got %q and %q", first, reordered)
+	}
+	if first == shrunk {
+		t.Errorf("expected a changed result set to change the ID, both got %q", first)
+	}
+}
+
+// TestNomadClustersRead_onlyHealthy checks that only_healthy reads each READY
+// cluster and drops those without a leader, without reading the others
+func TestNomadClustersRead_onlyHealthy(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[{"id": "nomad-1", "status": "READY"}, {"id": "nomad-2", "status": "READY"}, {"id": "nomad-3", "status": "UPDATING"}]`, nil)
+	mock.AddResponse(200, `{"id": "nomad-1", "status": "READY", "leader": "node-1"}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-2", "status": "READY", "leader": ""}`, nil)
+
+	d := schema.TestResourceDataRaw(t, dataSourceNomadClusters().Schema, map[string]interface{}{"only_healthy": true})
+	if diags := dataSourceNomadClustersRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	clusters := d.Get("clusters").([]interface{})
+	if len(clusters) != 1 || clusters[0].(map[string]interface{})["id"] != "nomad-1" {
+		t.Errorf("expected only nomad-1, got %v", clusters)
+	}
+	if got := mock.GetRequestCount(); got != 3 {
+		t.Errorf("expected the list and a read of each READY cluster, got %d requests", got)
+	}
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNomadJob() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the status of a job on a Nomad cluster, including jobs not managed by Terraform",
+
+		ReadContext: dataSourceNomadJobRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Nomad cluster",
+			},
+			"job_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the job",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the job, such as pending, running or dead",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the job, such as service, batch or system",
+			},
+			"running_allocations": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of running allocations of the job",
+			},
+			"failed_allocations": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of failed allocations of the job",
+			},
+			"latest_deployment_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the latest deployment of the job, such as running, successful or failed. Empty when the job has no deployment",
+			},
+		},
+	}
+}
+
+func dataSourceNomadJobRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	var diags diag.Diagnostics
+
+	clusterId := d.Get("cluster_id").(string)
+	jobId := d.Get("job_id").(string)
+
+	var job map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/nomad/cluster/%s/job/%s", clusterId, jobId), &job)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read Nomad job %s: %w", jobId, err))
+	}
+
+	d.Set("status", getString(job, "status"))
+	d.Set("type", getString(job, "type"))
+	d.Set("running_allocations", getInt(job, "allocationCount"))
+	d.Set("failed_allocations", getInt(job, "failedAllocationCount"))
+	latestDeploymentStatus := ""
+	if deployment, ok := job["deployment"].(map[string]interface{}); ok {
+		latestDeploymentStatus = getString(deployment, "status")
+	}
+	d.Set("latest_deployment_status", latestDeploymentStatus)
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, jobId))
+
+	return diags
+}
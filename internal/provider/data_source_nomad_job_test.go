@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNomadJobDataSourceRead(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"jobId": "web", "status": "running", "type": "service", "allocationCount": 3, "failedAllocationCount": 1, "deployment": {"status": "successful"}}`, nil)
+
+	d := schema.TestResourceDataRaw(t, dataSourceNomadJob().Schema, map[string]interface{}{
+		"cluster_id": "nomad-123",
+		"job_id":     "web",
+	})
+
+	if diags := dataSourceNomadJobRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/nomad/cluster/nomad-123/job/web" {
+		t.Errorf("unexpected request path %s", got)
+	}
+	if d.Id() != "nomad-123/web" {
+		t.Errorf("expected ID nomad-123/web, got %q", d.Id())
+	}
+	expected := map[string]interface{}{
+		"status":                   "running",
+		"type":                     "service",
+		"running_allocations":      3,
+		"failed_allocations":       1,
+		"latest_deployment_status": "successful",
+	}
+	for key, want := range expected {
+		if got := d.Get(key); got != want {
+			t.Errorf("expected %s %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestNomadJobDataSourceRead_noDeployment(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"jobId": "cleanup", "status": "dead", "type": "batch"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, dataSourceNomadJob().Schema, map[string]interface{}{
+		"cluster_id": "nomad-123",
+		"job_id":     "cleanup",
+	})
+
+	if diags := dataSourceNomadJobRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("latest_deployment_status").(string); got != "" {
+		t.Errorf("expected no deployment status, got %q", got)
+	}
+	if got := d.Get("running_allocations").(int); got != 0 {
+		t.Errorf("expected no running allocations, got %d", got)
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePackerTemplates() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves information about Packer templates on OVH infrastructure",
+
+		ReadContext: dataSourcePackerTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter templates by OVH region",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter templates by status",
+			},
+			"templates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of Packer templates",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Template ID",
+						},
+						"import_id": importIDSchema("hashicorp_ovh_packer_template"),
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Template name",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "OVH region",
+						},
+						"source_image": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Source image",
+						},
+						"instance_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Instance type",
+						},
+						"auto_build": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Automatic builds enabled",
+						},
+						"last_build_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Last successful build ID",
+						},
+						"image_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Generated image ID",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Template status",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Template tags",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePackerTemplatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	var diags diag.Diagnostics
+
+	var templates []map[string]interface{}
+	err := config.getList("/cloud/project/packer/template", &templates)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read Packer templates: %w", err))
+	}
+
+	region := d.Get("region").(string)
+	status := d.Get("status").(string)
+
+	var filteredTemplates []map[string]interface{}
+	for _, template := range templates {
+		if region != "" && getString(template, "region") != region {
+			continue
+		}
+		if status != "" && getString(template, "status") != status {
+			continue
+		}
+		filteredTemplates = append(filteredTemplates, template)
+	}
+
+	templateList := make([]interface{}, len(filteredTemplates))
+	ids := make([]string, len(filteredTemplates))
+	for i, template := range filteredTemplates {
+		ids[i] = getString(template, "id")
+		templateMap := map[string]interface{}{
+			"id":            getString(template, "id"),
+			"import_id":     importID(template),
+			"name":          getString(template, "name"),
+			"region":        getString(template, "region"),
+			"source_image":  getString(template, "sourceImage"),
+			"instance_type": getString(template, "instanceType"),
+			"auto_build":    getBool(template, "autoBuild"),
+			"last_build_id": getString(template, "lastBuildId"),
+			"image_id":      getString(template, "imageId"),
+			"status":        getString(template, "status"),
+		}
+
+		if tags, ok := template["tags"].(map[string]interface{}); ok {
+			templateMap["tags"] = tags
+		}
+
+		templateList[i] = templateMap
+	}
+
+	d.Set("templates", templateList)
+	d.SetId(listID(ids, "packer", region, status))
+
+	return diags
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVaultCACert() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the CA certificate of a Vault cluster, to configure clients that connect to it over TLS",
+
+		ReadContext: dataSourceVaultCACertRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Vault cluster",
+			},
+			"wait_for_ready": waitForReadySchema(),
+			"ca_certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded CA certificate",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 fingerprint of the CA certificate, as colon separated hex",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry time of the CA certificate in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func dataSourceVaultCACertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	var diags diag.Diagnostics
+
+	clusterId := d.Get("cluster_id").(string)
+	if err := waitForReadyIfRequested(ctx, d, config, "vault", clusterId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var ca map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/vault/cluster/%s/pki/ca", clusterId), &ca)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read Vault cluster CA certificate: %w", err))
+	}
+
+	certificate := getString(ca, "certificate")
+	cert, err := parsePEMCertificate(certificate)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("invalid CA certificate returned for Vault cluster %s: %w", clusterId, err))
+	}
+
+	d.Set("ca_certificate", certificate)
+	d.Set("fingerprint", certificateFingerprint(cert))
+	d.Set("expires_at", cert.NotAfter.UTC().Format(time.RFC3339))
+	d.SetId(clusterId)
+
+	return diags
+}
+
+// parsePEMCertificate decodes the first certificate of a PEM bundle.
+func parsePEMCertificate(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of cert in the
+// AA:BB:... form printed by openssl x509 -fingerprint.
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	hexSum := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	pairs := make([]string, 0, len(sum))
+	for i := 0; i < len(hexSum); i += 2 {
+		pairs = append(pairs, hexSum[i:i+2])
+	}
+	return strings.Join(pairs, ":")
+}
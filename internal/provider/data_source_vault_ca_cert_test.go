@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testCACertificatePEM(t *testing.T, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "vault-123 CA"},
+		NotBefore:             notAfter.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestVaultCACertRead(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	certificate := testCACertificatePEM(t, notAfter)
+	body, _ := json.Marshal(map[string]string{"certificate": certificate})
+	mock.AddResponse(200, string(body), nil)
+
+	d := schema.TestResourceDataRaw(t, dataSourceVaultCACert().Schema, map[string]interface{}{
+		"cluster_id": "vault-123",
+	})
+
+	if diags := dataSourceVaultCACertRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/vault/cluster/vault-123/pki/ca" {
+		t.Errorf("unexpected request path %s", got)
+	}
+	if got := d.Get("ca_certificate").(string); got != certificate {
+		t.Errorf("expected the PEM certificate, got %q", got)
+	}
+	if got := d.Get("expires_at").(string); got != "2030-01-02T03:04:05Z" {
+		t.Errorf("expected expires_at 2030-01-02T03:04:05Z, got %q", got)
+	}
+	if got := d.Get("fingerprint").(string); !regexp.MustCompile(`^([0-9A-F]{2}:){31}[0-9A-F]{2}$`).MatchString(got) {
+		t.Errorf("unexpected fingerprint %q", got)
+	}
+}
+
+func TestVaultCACertRead_invalidCertificate(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"certificate": "not a certificate"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, dataSourceVaultCACert().Schema, map[string]interface{}{
+		"cluster_id": "vault-123",
+	})
+
+	if diags := dataSourceVaultCACertRead(context.Background(), d, mock.NewConfig(t)); !diags.HasError() {
+		t.Error("expected an error")
+	}
+}
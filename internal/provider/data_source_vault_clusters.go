@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,6 +26,7 @@ func dataSourceVaultClusters() *schema.Resource {
 				Optional:    true,
 				Description: "Filter clusters by status",
 			},
+			"only_healthy": onlyHealthySchema(),
 			"clusters": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -38,6 +38,7 @@ func dataSourceVaultClusters() *schema.Resource {
 							Computed:    true,
 							Description: "Cluster ID",
 						},
+						"import_id": importIDSchema("hashicorp_ovh_vault_cluster"),
 						"name": {
 							Type:        schema.TypeString,
 							Computed:    true,
@@ -108,7 +109,7 @@ func dataSourceVaultClustersRead(ctx context.Context, d *schema.ResourceData, me
 	var diags diag.Diagnostics
 
 	var clusters []map[string]interface{}
-	err := config.OVHClient.Get("/cloud/project/vault/cluster", &clusters)
+	err := config.getList("/cloud/project/vault/cluster", &clusters)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to read Vault clusters: %w", err))
 	}
@@ -118,29 +119,40 @@ func dataSourceVaultClustersRead(ctx context.Context, d *schema.ResourceData, me
 
 	var filteredClusters []map[string]interface{}
 	for _, cluster := range clusters {
-		if region != "" && cluster["region"].(string) != region {
+		if region != "" && getString(cluster, "region") != region {
 			continue
 		}
-		if status != "" && cluster["status"].(string) != status {
+		if status != "" && getString(cluster, "status") != status {
 			continue
 		}
 		filteredClusters = append(filteredClusters, cluster)
 	}
 
+	onlyHealthy := d.Get("only_healthy").(bool)
+	if onlyHealthy {
+		filteredClusters, err = filterHealthyClusters(config, "vault", filteredClusters)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to read Vault clusters: %w", err))
+		}
+	}
+
 	clusterList := make([]interface{}, len(filteredClusters))
+	ids := make([]string, len(filteredClusters))
 	for i, cluster := range filteredClusters {
+		ids[i] = getString(cluster, "id")
 		clusterMap := map[string]interface{}{
-			"id":            cluster["id"],
-			"name":          cluster["name"],
-			"region":        cluster["region"],
-			"node_count":    cluster["nodeCount"],
-			"instance_type": cluster["instanceType"],
-			"storage_type":  cluster["storageType"],
-			"auto_unseal":   cluster["autoUnseal"],
-			"audit_enabled": cluster["auditEnabled"],
-			"cluster_url":   cluster["clusterUrl"],
-			"ui_url":        cluster["uiUrl"],
-			"status":        cluster["status"],
+			"id":            getString(cluster, "id"),
+			"import_id":     importID(cluster),
+			"name":          getString(cluster, "name"),
+			"region":        getString(cluster, "region"),
+			"node_count":    getInt(cluster, "nodeCount"),
+			"instance_type": getString(cluster, "instanceType"),
+			"storage_type":  getString(cluster, "storageType"),
+			"auto_unseal":   getBool(cluster, "autoUnseal"),
+			"audit_enabled": getBool(cluster, "auditEnabled"),
+			"cluster_url":   getString(cluster, "clusterUrl"),
+			"ui_url":        getString(cluster, "uiUrl"),
+			"status":        getString(cluster, "status"),
 		}
 
 		if tags, ok := cluster["tags"].(map[string]interface{}); ok {
@@ -151,7 +163,7 @@ func dataSourceVaultClustersRead(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	d.Set("clusters", clusterList)
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.SetId(listID(ids, "vault", region, status, strconv.FormatBool(onlyHealthy)))
 
 	return diags
 }
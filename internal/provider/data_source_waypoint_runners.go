@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceWaypointRunners() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves information about Waypoint runners on OVH infrastructure",
+
+		ReadContext: dataSourceWaypointRunnersRead,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter runners by OVH region",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter runners by status",
+			},
+			"runners": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of Waypoint runners",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Runner ID",
+						},
+						"import_id": importIDSchema("hashicorp_ovh_waypoint_runner"),
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Runner name",
+						},
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "OVH region",
+						},
+						"instance_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Instance type",
+						},
+						"runner_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of runner",
+						},
+						"capacity": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Maximum concurrent jobs",
+						},
+						"runner_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Waypoint runner ID",
+						},
+						"endpoint": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Runner endpoint URL",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Runner status",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Runner tags",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWaypointRunnersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	var diags diag.Diagnostics
+
+	var runners []map[string]interface{}
+	err := config.getList("/cloud/project/waypoint/runner", &runners)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read Waypoint runners: %w", err))
+	}
+
+	region := d.Get("region").(string)
+	status := d.Get("status").(string)
+
+	var filteredRunners []map[string]interface{}
+	for _, runner := range runners {
+		if region != "" && getString(runner, "region") != region {
+			continue
+		}
+		if status != "" && getString(runner, "status") != status {
+			continue
+		}
+		filteredRunners = append(filteredRunners, runner)
+	}
+
+	runnerList := make([]interface{}, len(filteredRunners))
+	ids := make([]string, len(filteredRunners))
+	for i, runner := range filteredRunners {
+		ids[i] = getString(runner, "id")
+		runnerMap := map[string]interface{}{
+			"id":            getString(runner, "id"),
+			"import_id":     importID(runner),
+			"name":          getString(runner, "name"),
+			"region":        getString(runner, "region"),
+			"instance_type": getString(runner, "instanceType"),
+			"runner_type":   getString(runner, "runnerType"),
+			"capacity":      getInt(runner, "capacity"),
+			"runner_id":     getString(runner, "runnerId"),
+			"endpoint":      getString(runner, "endpoint"),
+			"status":        getString(runner, "status"),
+		}
+
+		if tags, ok := runner["tags"].(map[string]interface{}); ok {
+			runnerMap["tags"] = tags
+		}
+
+		runnerList[i] = runnerMap
+	}
+
+	d.Set("runners", runnerList)
+	d.SetId(listID(ids, "waypoint", region, status))
+
+	return diags
+}
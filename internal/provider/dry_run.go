@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// keepStateOnDryRun wraps the update of a resource so that an update failed
+// by a dry run keeps the previous state. Otherwise the planned values would be
+// stored, consuming triggers such as the rotate_* attributes and
+// confirmations such as allow_seal_migration for changes that were not made.
+func keepStateOnDryRun(update schema.UpdateContextFunc) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := update(ctx, d, meta)
+		if config, ok := meta.(*Config); ok && diags.HasError() && config.OVHClient != nil && config.OVHClient.dryRun {
+			d.Partial(true)
+		}
+		return diags
+	}
+}
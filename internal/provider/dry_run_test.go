@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestVaultClusterUpdate_dryRun checks that an update stopped by a dry run
+// keeps the previous state, including allow_seal_migration
+func TestVaultClusterUpdate_dryRun(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+
+	r := resourceVaultCluster()
+	state := testVaultClusterSealState()
+	raw := testVaultClusterRawConfig()
+	raw["node_count"] = 5
+	raw["allow_seal_migration"] = true
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+	config := mock.NewConfig(t)
+	config.OVHClient.dryRun = true
+
+	if diags := r.UpdateContext(context.Background(), d, config); !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	for _, r := range mock.Requests {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected only reads, got %s %s", r.Method, r.URL.Path)
+		}
+	}
+	attributes := d.State().Attributes
+	if attributes["node_count"] != "3" || attributes["allow_seal_migration"] == "true" {
+		t.Errorf("expected the previous state to be kept, got node_count %q and allow_seal_migration %q", attributes["node_count"], attributes["allow_seal_migration"])
+	}
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getString returns the string stored under key, or "" if it is missing or not a string.
+func getString(m map[string]interface{}, key string) string {
+	if v, ok := m[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// getInt returns the integer stored under key. JSON numbers are decoded as
+// float64, so whole values are converted back to int; anything else yields 0.
+func getInt(m map[string]interface{}, key string) int {
+	switch v := m[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		if math.IsNaN(v) || v != math.Trunc(v) || v >= math.MaxInt || v < math.MinInt {
+			return 0
+		}
+		return int(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+	}
+	return 0
+}
+
+// getBool returns the boolean stored under key. Some OVH endpoints return
+// booleans as "true"/"false" strings or as 0/1 numbers, which are coerced;
+// anything else yields false.
+func getBool(m map[string]interface{}, key string) bool {
+	switch v := m[key].(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "true", "1":
+			return true
+		}
+	case float64:
+		return v == 1
+	case int:
+		return v == 1
+	case json.Number:
+		return v.String() == "1"
+	}
+	return false
+}
+
+// getStringList returns the list stored under key as a []string, skipping
+// elements that are not strings.
+func getStringList(m map[string]interface{}, key string) []string {
+	items, ok := m[key].([]interface{})
+	if !ok {
+		return []string{}
+	}
+
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// parseTwoPartID splits an ID of the form "<first>/<second>", as used by
+// resources nested under a cluster.
+func parseTwoPartID(id, first, second string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format for ID (%s), expected %s/%s", id, first, second)
+	}
+	return parts[0], parts[1], nil
+}
+
+// parseThreePartID splits an ID of the form "<first>/<second>/<third>".
+func parseThreePartID(id, first, second, third string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected format for ID (%s), expected %s/%s/%s", id, first, second, third)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// validateDuration checks that a string attribute parses as a Go duration such as "5m".
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if _, err := time.ParseDuration(value); err != nil {
+		errors = append(errors, fmt.Errorf("%s must be a valid duration such as 30s or 5m, got %q", k, value))
+	}
+	return
+}
+
+// validateIntBetween is like validation.IntBetween but reports errors as
+// "<attribute> must be between <min> and <max>".
+func validateIntBetween(min, max int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value, ok := v.(int)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %s to be integer", k))
+			return
+		}
+
+		if value < min || value > max {
+			errors = append(errors, fmt.Errorf("%s must be between %d and %d, got %d", k, min, max, value))
+		}
+		return
+	}
+}
+
+var resourceNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+
+// checkResourceName enforces the naming rule shared by all resources: 3 to 50
+// characters, starting with a letter, containing only letters, numbers and hyphens.
+func checkResourceName(label, name string) error {
+	if len(name) < 3 || len(name) > 50 {
+		return fmt.Errorf("%s must be between 3 and 50 characters", label)
+	}
+	if !resourceNamePattern.MatchString(name) {
+		return fmt.Errorf("%s must start with a letter and contain only letters, numbers, and hyphens", label)
+	}
+	return nil
+}
+
+// validateClusterName is a SchemaValidateFunc applying checkResourceName to a name attribute.
+func validateClusterName(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if err := checkResourceName(k, value); err != nil {
+		errors = append(errors, err)
+	}
+	return
+}
+
+var datacenterPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// validateDatacenter checks a Nomad or Consul datacenter name: lowercase
+// letters, numbers, hyphens and underscores, at most 64 characters.
+func validateDatacenter(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !datacenterPattern.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%s must be 1 to 64 lowercase letters, numbers, hyphens or underscores, got %q", k, value))
+	}
+	return
+}
+
+// datacenterDisallowed matches the characters validateDatacenter rejects.
+var datacenterDisallowed = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// datacenterFromRegion derives a datacenter name that validateDatacenter
+// accepts from region: lowercased, with other characters replaced by
+// hyphens, starting with a letter or number and cut to 64 characters. It is
+// empty when no such name remains.
+func datacenterFromRegion(region string) string {
+	name := datacenterDisallowed.ReplaceAllString(strings.ToLower(region), "-")
+	name = strings.TrimLeft(name, "_-")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// setDefaultDatacenter plans datacenter as derived from the region by
+// datacenterFromRegion when it is not set in the configuration. Existing
+// clusters keep their stored value, and a region no name can be derived from
+// leaves the datacenter to the API.
+func setDefaultDatacenter(d *schema.ResourceDiff) error {
+	if d.Get("datacenter").(string) != "" || !d.NewValueKnown("region") {
+		return nil
+	}
+	name := datacenterFromRegion(d.Get("region").(string))
+	if name == "" {
+		return nil
+	}
+	return d.SetNew("datacenter", name)
+}
+
+// stableID returns a deterministic ID derived from parts, so data sources
+// read repeatedly with the same inputs keep the same ID.
+func stableID(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// listID returns a deterministic ID for a list data source from its filter
+// values and the IDs of the items it returned, whatever order the API used.
+func listID(ids []string, filters ...string) string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	return stableID(append(filters, sorted...)...)
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetInt(t *testing.T) {
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"serverCount": 3, "ratio": 1.5, "name": "x"}`), &decoded); err != nil {
+		t.Fatalf("failed to decode test body: %s", err)
+	}
+
+	cases := map[string]int{
+		"serverCount": 3,
+		"ratio":       0,
+		"name":        0,
+		"missing":     0,
+	}
+
+	for key, expected := range cases {
+		if got := getInt(decoded, key); got != expected {
+			t.Errorf("getInt(%q): expected %d, got %d", key, expected, got)
+		}
+	}
+}
+
+func TestGetString(t *testing.T) {
+	m := map[string]interface{}{"name": "nomad", "count": 2.0}
+
+	if got := getString(m, "name"); got != "nomad" {
+		t.Errorf("expected nomad, got %q", got)
+	}
+	if got := getString(m, "count"); got != "" {
+		t.Errorf("expected empty string for non-string value, got %q", got)
+	}
+}
+
+func TestGetBool(t *testing.T) {
+	var m map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+  "bool": true,
+  "boolFalse": false,
+  "string": "true",
+  "stringUpper": "TRUE",
+  "stringFalse": "false",
+  "one": 1,
+  "zero": 0,
+  "oneString": "1",
+  "two": 2,
+  "other": "yes",
+  "null": null
+}`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]bool{
+		"bool":        true,
+		"boolFalse":   false,
+		"string":      true,
+		"stringUpper": true,
+		"stringFalse": false,
+		"one":         true,
+		"zero":        false,
+		"oneString":   true,
+		"two":         false,
+		"other":       false,
+		"null":        false,
+		"missing":     false,
+	}
+	for key, want := range expected {
+		if got := getBool(m, key); got != want {
+			t.Errorf("expected %s to read as %t, got %t", key, want, got)
+		}
+	}
+}
+
+func TestGetStringList(t *testing.T) {
+	m := map[string]interface{}{
+		"serverEndpoints": []interface{}{"10.0.0.1:4646", 42.0, "10.0.0.2:4646"},
+	}
+
+	expected := []string{"10.0.0.1:4646", "10.0.0.2:4646"}
+	if got := getStringList(m, "serverEndpoints"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := getStringList(m, "missing"); len(got) != 0 {
+		t.Errorf("expected empty list, got %v", got)
+	}
+}
+
+func TestParseTwoPartID(t *testing.T) {
+	clusterId, name, err := parseTwoPartID("nomad-123/team-a", "cluster_id", "namespace")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if clusterId != "nomad-123" || name != "team-a" {
+		t.Errorf("unexpected result: %q, %q", clusterId, name)
+	}
+
+	for _, id := range []string{"nomad-123", "/team-a", "nomad-123/"} {
+		if _, _, err := parseTwoPartID(id, "cluster_id", "namespace"); err == nil {
+			t.Errorf("expected an error for ID %q", id)
+		}
+	}
+}
+
+func TestValidateClusterName(t *testing.T) {
+	cases := map[string]string{
+		"test-cluster": "",
+		"ab":           "name must be between 3 and 50 characters",
+		"1cluster":     "name must start with a letter and contain only letters, numbers, and hyphens",
+		"bad_name":     "name must start with a letter and contain only letters, numbers, and hyphens",
+	}
+
+	for value, expected := range cases {
+		_, errs := validateClusterName(value, "name")
+		if expected == "" {
+			if len(errs) != 0 {
+				t.Errorf("%q: unexpected errors: %v", value, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || errs[0].Error() != expected {
+			t.Errorf("%q: expected %q, got %v", value, expected, errs)
+		}
+	}
+}
+
+func TestValidateIntBetween(t *testing.T) {
+	validate := validateIntBetween(0, 100)
+
+	if _, errs := validate(50, "client_count"); len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	_, errs := validate(101, "client_count")
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "client_count must be between 0 and 100") {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestParseThreePartID(t *testing.T) {
+	clusterId, source, destination, err := parseThreePartID("consul-123/web/db", "cluster_id", "source", "destination")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if clusterId != "consul-123" || source != "web" || destination != "db" {
+		t.Errorf("unexpected result: %q, %q, %q", clusterId, source, destination)
+	}
+
+	for _, id := range []string{"consul-123/web", "consul-123//db", "consul-123/web/db/extra"} {
+		if _, _, _, err := parseThreePartID(id, "cluster_id", "source", "destination"); err == nil {
+			t.Errorf("expected an error for ID %q", id)
+		}
+	}
+}
+
+func TestListID(t *testing.T) {
+	id := listID([]string{"a", "b"}, "nomad", "GRA", "")
+	if got := listID([]string{"b", "a"}, "nomad", "GRA", ""); got != id {
+		t.Errorf("expected the ID to ignore item order, got %q and %q", id, got)
+	}
+	if got := listID([]string{"a"}, "nomad", "GRA", ""); got == id {
+		t.Error("expected a different item set to yield a different ID")
+	}
+	if got := listID([]string{"a", "b"}, "nomad", "SBG", ""); got == id {
+		t.Error("expected different filters to yield a different ID")
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// importNamePrefix marks an import ID holding the name of the object rather
+// than its OVH ID, as in "name:prod-vault".
+const importNamePrefix = "name:"
+
+// importStateByName returns an importer accepting either the OVH ID of an
+// object listed under listPath or its name prefixed with importNamePrefix.
+// kind names the object in errors.
+func importStateByName(listPath, kind string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		name, ok := strings.CutPrefix(d.Id(), importNamePrefix)
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		id, err := lookupIDByName(meta.(*Config), listPath, kind, name)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// lookupIDByName returns the ID of the single object named name under
+// listPath. The name is also matched client-side, as the API treats the name
+// filter as a prefix.
+func lookupIDByName(config *Config, listPath, kind, name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("unexpected format for import ID, expected %s<name>", importNamePrefix)
+	}
+
+	var items []map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("%s?name=%s", listPath, url.QueryEscape(name)), &items)
+	if err != nil {
+		return "", fmt.Errorf("failed to list %ss: %w", kind, err)
+	}
+
+	ids := []string{}
+	for _, item := range items {
+		if getString(item, "name") == name {
+			ids = append(ids, getString(item, "id"))
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q found", kind, name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d %ss are named %q (%s), import by ID instead", len(ids), kind, name, strings.Join(ids, ", "))
+	}
+}
+
+// importIDSchema describes the ID under which a listed object is imported as
+// a resourceType resource, to generate import blocks from list data sources.
+func importIDSchema(resourceType string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: fmt.Sprintf("ID to import the object as a %s resource", resourceType),
+	}
+}
+
+// importID returns the ID importStateByName expects for an object listed by
+// the OVH API. The OVH ID is used rather than the name, which may be shared.
+func importID(item map[string]interface{}) string {
+	return getString(item, "id")
+}
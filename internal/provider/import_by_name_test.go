@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestImportStateByName(t *testing.T) {
+	cases := map[string]struct {
+		importID    string
+		response    string
+		expectedID  string
+		expectError string
+	}{
+		"raw ID": {
+			importID:   "vault-123",
+			expectedID: "vault-123",
+		},
+		"name": {
+			importID:   "name:prod-vault",
+			response:   `[{"id": "vault-123", "name": "prod-vault"}, {"id": "vault-456", "name": "prod-vault-dr"}]`,
+			expectedID: "vault-123",
+		},
+		"not found": {
+			importID:    "name:prod-vault",
+			response:    `[{"id": "vault-456", "name": "prod-vault-dr"}]`,
+			expectError: `no Vault cluster named "prod-vault" found`,
+		},
+		"ambiguous": {
+			importID:    "name:prod-vault",
+			response:    `[{"id": "vault-123", "name": "prod-vault"}, {"id": "vault-456", "name": "prod-vault"}]`,
+			expectError: `2 Vault clusters are named "prod-vault" (vault-123, vault-456)`,
+		},
+		"empty name": {
+			importID:    "name:",
+			expectError: "expected name:<name>",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			if tc.response != "" {
+				mock.AddResponse(200, tc.response, nil)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, map[string]interface{}{})
+			d.SetId(tc.importID)
+
+			importer := importStateByName("/cloud/project/vault/cluster", "Vault cluster")
+			results, err := importer(context.Background(), d, mock.NewConfig(t))
+
+			if tc.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectError) {
+					t.Fatalf("expected an error containing %q, got %v", tc.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := results[0].Id(); got != tc.expectedID {
+				t.Errorf("expected ID %s, got %s", tc.expectedID, got)
+			}
+			if tc.response != "" {
+				if got := mock.GetLastRequest().URL.Query().Get("name"); got != "prod-vault" {
+					t.Errorf("expected the list to be filtered by name, got %q", got)
+				}
+			}
+		})
+	}
+}
+
+// TestImportID_roundTrip checks that the import_id of a listed cluster is
+// imported as that cluster without any lookup
+func TestImportID_roundTrip(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[{"id": "vault-123", "name": "prod-vault", "region": "GRA"}]`, nil)
+	config := mock.NewConfig(t)
+
+	list := schema.TestResourceDataRaw(t, dataSourceVaultClusters().Schema, map[string]interface{}{})
+	if diags := dataSourceVaultClustersRead(context.Background(), list, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	importID := list.Get("clusters.0.import_id").(string)
+
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, map[string]interface{}{})
+	d.SetId(importID)
+
+	results, err := resourceVaultCluster().Importer.StateContext(context.Background(), d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := results[0].Id(); got != "vault-123" {
+		t.Errorf("expected ID vault-123, got %q", got)
+	}
+	if mock.GetRequestCount() != 1 {
+		t.Errorf("expected only the list request, got %d requests", mock.GetRequestCount())
+	}
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Some features first had a boolean attribute and then gained a block for
+// their settings, such as kata_containers and kata. The deprecated boolean
+// keeps working: set without the block, it stands for the block enabled with
+// its defaults. The API takes the block under the name of the block
+// attribute, and the boolean as whether the block is enabled.
+
+// expandLegacyBlock builds the payload of a block replacing a legacy boolean
+// with expand. When the block is not set, a true legacy boolean maps to
+// legacyPayload, and otherwise there is no payload.
+func expandLegacyBlock(l []interface{}, legacyEnabled bool, legacyPayload map[string]interface{}, expand func(raw map[string]interface{}) map[string]interface{}) map[string]interface{} {
+	if len(l) == 0 || l[0] == nil {
+		if legacyEnabled {
+			return legacyPayload
+		}
+		return nil
+	}
+	return expand(l[0].(map[string]interface{}))
+}
+
+// addLegacyBlock adds payload, built by expandLegacyBlock, to an API request
+// under field, and whether it is enabled under legacyField. Without a
+// payload the boolean is sent false and, on update, the block is sent
+// disabled, so that removing both turns the feature off.
+func addLegacyBlock(request map[string]interface{}, field, legacyField string, payload map[string]interface{}, update bool) {
+	if payload == nil {
+		request[legacyField] = false
+		if update {
+			request[field] = map[string]interface{}{"enabled": false}
+		}
+		return
+	}
+	request[field] = payload
+	request[legacyField] = payload["enabled"]
+}
+
+// setLegacyBlock reads the block key replacing the boolean legacyKey back
+// from the API object m. Resources configured with the legacy boolean keep
+// using it rather than gaining a block on refresh.
+func setLegacyBlock(d *schema.ResourceData, key, legacyKey string, m map[string]interface{}, flatten func(map[string]interface{}) []interface{}) {
+	block, ok := m[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if len(d.Get(key).([]interface{})) > 0 || (getBool(block, "enabled") && !d.Get(legacyKey).(bool)) {
+		d.Set(key, flatten(block))
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// listCacheTTL is how long a list fetched by a data source is served from
+// the cache, enough for the data sources of one plan or apply to share it.
+const listCacheTTL = 30 * time.Second
+
+// listCacheEntry is a cached list response. Its mutex is held while the list
+// is fetched, so that concurrent reads of the same list wait for one call
+// rather than each calling the API.
+type listCacheEntry struct {
+	mu        sync.Mutex
+	body      json.RawMessage
+	fetchedAt time.Time
+}
+
+// getList is OVHClient.Get for the list endpoints read by data sources. The
+// response is cached for listCacheTTL, keyed by path including its query
+// string, so that data sources listing the same objects with the same
+// filters within a plan or apply share a single API call. Each caller gets
+// its own copy of the response. Failed calls are not cached, and changes
+// made through the client drop the cached lists of their service.
+func (c *Config) getList(path string, resType interface{}) error {
+	return c.OVHClient.getList(path, resType)
+}
+
+func (c *lockedClient) getList(path string, resType interface{}) error {
+	c.listCacheMu.Lock()
+	if c.listCache == nil {
+		c.listCache = map[string]*listCacheEntry{}
+	}
+	entry, ok := c.listCache[path]
+	if !ok {
+		entry = &listCacheEntry{}
+		c.listCache[path] = entry
+	}
+	c.listCacheMu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.body == nil || time.Since(entry.fetchedAt) >= listCacheTTL {
+		var body json.RawMessage
+		if err := c.Get(path, &body); err != nil {
+			return err
+		}
+		entry.body = body
+		entry.fetchedAt = time.Now()
+	}
+	return json.Unmarshal(entry.body, resType)
+}
+
+// listCacheService returns the part of path naming the service it belongs
+// to, such as /cloud/project/nomad for the Nomad clusters or
+// /cloud/project/<id> for the OpenStack objects of a project.
+func listCacheService(path string) string {
+	rest, ok := strings.CutPrefix(path, "/cloud/project/")
+	if !ok {
+		return path
+	}
+	if i := strings.IndexAny(rest, "/?"); i >= 0 {
+		rest = rest[:i]
+	}
+	return "/cloud/project/" + rest
+}
+
+// invalidateLists drops the cached lists of the service of path, so that
+// data sources read after a create, update or delete see it.
+func (c *lockedClient) invalidateLists(path string) {
+	service := listCacheService(path)
+
+	c.listCacheMu.Lock()
+	defer c.listCacheMu.Unlock()
+	for cached := range c.listCache {
+		if listCacheService(cached) == service {
+			delete(c.listCache, cached)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestVaultClustersRead_cached checks that identical list data sources read
+// within one provider instance share a single API call
+func TestVaultClustersRead_cached(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[{"id": "vault-1", "name": "prod-vault", "region": "GRA", "status": "READY"}]`, nil)
+
+	config := mock.NewConfig(t)
+	for i := 0; i < 2; i++ {
+		d := schema.TestResourceDataRaw(t, dataSourceVaultClusters().Schema, map[string]interface{}{})
+		if diags := dataSourceVaultClustersRead(context.Background(), d, config); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if clusters := d.Get("clusters").([]interface{}); len(clusters) != 1 {
+			t.Fatalf("read %d: expected 1 cluster, got %v", i, clusters)
+		}
+	}
+
+	if got := mock.GetRequestCount(); got != 1 {
+		t.Errorf("expected a single API call, got %d", got)
+	}
+}
+
+func TestConfigGetList(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(500, `{"message": "Internal server error"}`, nil)
+	mock.AddResponse(200, `[{"id": "img-1"}]`, nil)
+	mock.AddResponse(200, `[{"id": "img-2"}]`, nil)
+
+	config := mock.NewConfig(t)
+	var items []map[string]interface{}
+
+	if err := config.getList("/cloud/project/p/image?region=GRA", &items); err == nil {
+		t.Fatal("expected the error of the API call")
+	}
+
+	// A failed call is not cached, and concurrent reads share one call.
+	var wg sync.WaitGroup
+	results := make([][]map[string]interface{}, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := config.getList("/cloud/project/p/image?region=GRA", &results[i]); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, result := range results {
+		if len(result) != 1 || result[0]["id"] != "img-1" {
+			t.Errorf("expected the cached list, got %v", result)
+		}
+	}
+
+	// Other filters are another cache entry.
+	if err := config.getList("/cloud/project/p/image?region=SBG", &items); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(items) != 1 || items[0]["id"] != "img-2" {
+		t.Errorf("expected the list for the other filters, got %v", items)
+	}
+
+	if got := mock.GetRequestCount(); got != 3 {
+		t.Errorf("expected 3 API calls, got %d", got)
+	}
+
+	// A new provider instance starts with an empty cache.
+	mock.AddResponse(200, `[]`, nil)
+	if err := mock.NewConfig(t).getList("/cloud/project/p/image?region=GRA", &items); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := mock.GetRequestCount(); got != 4 {
+		t.Errorf("expected a new provider instance to call the API, got %d calls", got)
+	}
+}
+
+// TestConfigGetList_invalidated checks that creating, updating or deleting
+// an object drops the cached lists of its service, and only of its service
+func TestConfigGetList_invalidated(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	config := mock.NewConfig(t)
+	var items []map[string]interface{}
+	read := func(path, want string) {
+		t.Helper()
+		if err := config.getList(path, &items); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(items) != 1 || items[0]["id"] != want {
+			t.Errorf("expected %s to list %s, got %v", path, want, items)
+		}
+	}
+
+	mock.AddResponse(200, `[{"id": "nomad-1"}]`, nil)
+	mock.AddResponse(200, `[{"id": "vault-1"}]`, nil)
+	read("/cloud/project/nomad/cluster", "nomad-1")
+	read("/cloud/project/vault/cluster", "vault-1")
+
+	for _, mutate := range []func() error{
+		func() error {
+			return config.OVHClient.Post("/cloud/project/nomad/cluster", map[string]interface{}{}, nil)
+		},
+		func() error {
+			return config.OVHClient.Put("/cloud/project/nomad/cluster/nomad-2", map[string]interface{}{}, nil)
+		},
+		func() error { return config.OVHClient.Delete("/cloud/project/nomad/cluster/nomad-2", nil) },
+	} {
+		mock.AddResponse(200, `{}`, nil)
+		if err := mutate(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		mock.AddResponse(200, `[{"id": "nomad-2"}]`, nil)
+		read("/cloud/project/nomad/cluster", "nomad-2")
+		read("/cloud/project/vault/cluster", "vault-1")
+	}
+
+	if got := mock.GetRequestCount(); got != 8 {
+		t.Errorf("expected 8 API calls, got %d", got)
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nomadIntegrations lists the services a Nomad cluster integrates with, each
+// with a <service>_integration flag, a <service>_token and a
+// <service>_integration_status attribute.
+var nomadIntegrations = []string{"vault", "consul"}
+
+// nomadStepIntegrationTokens is the create step requesting the integration
+// tokens, resumed by the next update when it fails.
+const nomadStepIntegrationTokens = "integration_tokens"
+
+// validateNomadIntegrationClusters checks that integrated clusters are only
+// supplied for enabled integrations. The clusters OVH provisions or discovers
+// are read into state, so only configured values are checked.
+func validateNomadIntegrationClusters(d *schema.ResourceDiff) error {
+	for _, service := range nomadIntegrations {
+		clusterKey := service + "_cluster_id"
+		if d.Id() != "" && !d.HasChange(clusterKey) {
+			continue
+		}
+		if d.Get(clusterKey).(string) != "" && !d.Get(service+"_integration").(bool) {
+			return fmt.Errorf("%s can only be set when %s_integration is true", clusterKey, service)
+		}
+	}
+	return nil
+}
+
+// validateNomadIntegrationTokens checks that tokens are only supplied for
+// enabled integrations. Tokens issued by OVH are kept in state when an
+// integration is disabled, so only configured values are checked.
+func validateNomadIntegrationTokens(d *schema.ResourceDiff) error {
+	for _, service := range nomadIntegrations {
+		tokenKey := service + "_token"
+		if d.Id() != "" && !d.HasChange(tokenKey) {
+			continue
+		}
+		if d.Get(tokenKey).(string) != "" && !d.Get(service+"_integration").(bool) {
+			return fmt.Errorf("%s can only be set when %s_integration is true", tokenKey, service)
+		}
+	}
+	return nil
+}
+
+// requestNomadIntegrationTokens asks OVH to issue a token for every enabled
+// integration without one, and stores it in state. It runs after create and
+// on every update, so that clusters whose create did not complete get their
+// tokens when the next apply resumes.
+func requestNomadIntegrationTokens(config *Config, d *schema.ResourceData, clusterId string) error {
+	for _, service := range nomadIntegrations {
+		tokenKey := service + "_token"
+		if !d.Get(service+"_integration").(bool) || d.Get(tokenKey).(string) != "" {
+			continue
+		}
+
+		var result map[string]interface{}
+		err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/nomad/cluster/%s/integration/%s/token", clusterId, service), nil, &result)
+		if err != nil {
+			return fmt.Errorf("failed to request %s integration token: %w", service, err)
+		}
+		d.Set(tokenKey, getString(result, "token"))
+	}
+	return nil
+}
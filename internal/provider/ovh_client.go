@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ovh/go-ovh/ovh"
+)
+
+// lockedClient shares an *ovh.Client between concurrently running resource
+// operations. go-ovh writes to the client's underlying http.Client whenever
+// it builds a request, so requests are built under a lock, while they are
+// sent and their responses read concurrently.
+type lockedClient struct {
+	mu      sync.Mutex
+	client  *ovh.Client
+	breaker *circuitBreaker
+
+	// disabled, when set before the client is shared, fails every call with
+	// it, as with validate_only.
+	disabled error
+
+	// dryRun, when set before the client is shared, fails every call that
+	// would change something at OVH with a *dryRunError, as with dry_run.
+	dryRun bool
+
+	// services holds the clients of the services that service_endpoints
+	// routes to another base URL, keyed by service name.
+	services map[string]*ovh.Client
+
+	listCacheMu sync.Mutex
+	listCache   map[string]*listCacheEntry
+}
+
+// ovhServices are the services whose /cloud/project/<service> paths can be
+// routed to another base URL by service_endpoints.
+var ovhServices = []string{"boundary", "consul", "kms", "nomad", "packer", "vault", "waypoint"}
+
+// errDryRun is matched by the errors of the calls skipped by dry_run.
+var errDryRun = errors.New("skipped by dry_run")
+
+// dryRunError describes a call that a client configured with dry_run did not
+// send, as what the operation that made it would have done.
+type dryRunError struct {
+	Method string
+	Path   string
+	Body   interface{}
+}
+
+func (e *dryRunError) Error() string {
+	msg := fmt.Sprintf("the provider is configured with dry_run = true, so %s %s was not sent", e.Method, e.Path)
+	if e.Body == nil {
+		return msg
+	}
+	body, err := json.MarshalIndent(e.Body, "", "  ")
+	if err != nil {
+		return msg
+	}
+	return fmt.Sprintf("%s. Its body would have been:\n%s", msg, body)
+}
+
+func (e *dryRunError) Is(target error) bool {
+	return target == errDryRun
+}
+
+func newLockedClient(client *ovh.Client) *lockedClient {
+	return &lockedClient{client: client}
+}
+
+// call runs fn unless the client is disabled or the circuit breaker is open,
+// and records its outcome with the breaker.
+func (c *lockedClient) call(fn func() error) error {
+	if c.disabled != nil {
+		return c.disabled
+	}
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	c.breaker.record(err)
+	return err
+}
+
+// send builds a signed request of method to path on client, with the given
+// headers, and sends it. Only building the request holds the lock. It is sent
+// with a copy of the client's http.Client taken then, which the requests
+// built meanwhile do not write to.
+func (c *lockedClient) send(client *ovh.Client, method, path string, reqBody interface{}, header http.Header) (*http.Response, error) {
+	c.mu.Lock()
+	req, err := client.NewRequest(method, path, reqBody, true)
+	httpClient := *client.Client
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	return httpClient.Do(req)
+}
+
+// callAPI sends a request like send and unmarshals its response into
+// resType.
+func (c *lockedClient) callAPI(client *ovh.Client, method, path string, reqBody, resType interface{}, header http.Header) error {
+	resp, err := c.send(client, method, path, reqBody, header)
+	if err != nil {
+		return err
+	}
+	return client.UnmarshalResponse(resp, resType)
+}
+
+// mutate runs fn like call, unless the client is a dry run, in which case
+// method is not sent to url and a *dryRunError describes it instead. OVH has
+// no endpoint validating a request without carrying it out, so nothing is
+// sent in its place.
+func (c *lockedClient) mutate(method, url string, reqBody interface{}, fn func() error) error {
+	if c.dryRun && c.disabled == nil {
+		return &dryRunError{Method: method, Path: url, Body: reqBody}
+	}
+	// A failed call may still have changed something, so the cached lists
+	// are dropped either way.
+	defer c.invalidateLists(url)
+	return c.call(fn)
+}
+
+// route returns the client to call url with, and the path to call on it.
+// Paths of a service with a service endpoint go to its client, relative to
+// its base URL, which replaces the /cloud/project/<service> prefix.
+func (c *lockedClient) route(url string) (*ovh.Client, string) {
+	for service, client := range c.services {
+		rest, ok := strings.CutPrefix(url, "/cloud/project/"+service)
+		if ok && (rest == "" || rest[0] == '/' || rest[0] == '?') {
+			return client, rest
+		}
+	}
+	return c.client, url
+}
+
+func (c *lockedClient) Get(url string, resType interface{}) error {
+	client, path := c.route(url)
+	return c.call(func() error { return c.callAPI(client, http.MethodGet, path, nil, resType, nil) })
+}
+
+// paginationCursorHeader carries the cursor of the page of a list to get,
+// and paginationNextHeader the cursor of the page after the one returned.
+// Neither is part of the request signature.
+const (
+	paginationCursorHeader = "X-Pagination-Cursor"
+	paginationNextHeader   = "X-Pagination-Cursor-Next"
+)
+
+// GetAll gets the list at url and appends its items to items, following the
+// cursors OVH returns for paginated lists until the last page. A list that is
+// not paginated is returned whole by the first request.
+func (c *lockedClient) GetAll(url string, items *[]map[string]interface{}) error {
+	client, path := c.route(url)
+	cursor := ""
+	for {
+		var page []map[string]interface{}
+		next := ""
+		err := c.call(func() error {
+			header := http.Header{}
+			if cursor != "" {
+				header.Set(paginationCursorHeader, cursor)
+			}
+
+			resp, err := c.send(client, http.MethodGet, path, nil, header)
+			if err != nil {
+				return err
+			}
+			next = resp.Header.Get(paginationNextHeader)
+			return client.UnmarshalResponse(resp, &page)
+		})
+		if err != nil {
+			return err
+		}
+		*items = append(*items, page...)
+
+		// A cursor repeating the last one would loop forever.
+		if next == "" || next == cursor {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (c *lockedClient) Post(url string, reqBody, resType interface{}) error {
+	client, path := c.route(url)
+	return c.mutate(http.MethodPost, url, reqBody, func() error {
+		return c.callAPI(client, http.MethodPost, path, reqBody, resType, nil)
+	})
+}
+
+// idempotencyKeyHeader carries the key identifying retries of a create.
+const idempotencyKeyHeader = "X-Idempotency-Key"
+
+// PostIdempotent is Post sending key in the X-Idempotency-Key header, so that
+// OVH recognizes a retried create as the same one. The header is not part of
+// the request signature.
+func (c *lockedClient) PostIdempotent(url, key string, reqBody, resType interface{}) error {
+	client, path := c.route(url)
+	header := http.Header{}
+	header.Set(idempotencyKeyHeader, key)
+	return c.mutate(http.MethodPost, url, reqBody, func() error {
+		return c.callAPI(client, http.MethodPost, path, reqBody, resType, header)
+	})
+}
+
+func (c *lockedClient) Put(url string, reqBody, resType interface{}) error {
+	client, path := c.route(url)
+	return c.mutate(http.MethodPut, url, reqBody, func() error {
+		return c.callAPI(client, http.MethodPut, path, reqBody, resType, nil)
+	})
+}
+
+func (c *lockedClient) Delete(url string, resType interface{}) error {
+	client, path := c.route(url)
+	return c.mutate(http.MethodDelete, url, nil, func() error {
+		return c.callAPI(client, http.MethodDelete, path, nil, resType, nil)
+	})
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// defaultOVHConfigFiles are the ovh.conf files read by the OVH API libraries,
+// by increasing priority.
+var defaultOVHConfigFiles = []string{
+	"/etc/ovh.conf",
+	"~/.ovh.conf",
+	"./ovh.conf",
+}
+
+// loadOVHConfigFile reads an ovh.conf file, in the INI format shared by the
+// OVH API libraries: a "default" section naming the endpoint and one section
+// per endpoint holding its credentials. An empty path loads the default files
+// that exist.
+func loadOVHConfigFile(path string) (*ini.File, error) {
+	if path != "" {
+		cfg, err := ini.Load(expandHome(path))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load %s: %w", path, err)
+		}
+		return cfg, nil
+	}
+
+	paths := make([]interface{}, 0, len(defaultOVHConfigFiles))
+	for _, path := range defaultOVHConfigFiles {
+		paths = append(paths, expandHome(path))
+	}
+	cfg, err := ini.LooseLoad(paths[0], paths[1:]...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load ovh.conf: %w", err)
+	}
+	return cfg, nil
+}
+
+// fillFromConfigFile sets *value to key from section of cfg unless it is
+// already set, so that the provider block and environment take precedence.
+func fillFromConfigFile(cfg *ini.File, section, key string, value *string) {
+	if *value != "" || !cfg.HasSection(section) {
+		return
+	}
+	*value = cfg.Section(section).Key(key).String()
+}
+
+// expandHome replaces a leading "~/" in path with the user's home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
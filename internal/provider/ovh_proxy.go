@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxySchemes are the schemes of the proxy URLs the OVH clients can use.
+var proxySchemes = []string{"http", "https", "socks5"}
+
+// validateProxyURL checks that u is an absolute URL of a proxy, with one of
+// proxySchemes and a host.
+func validateProxyURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	valid := false
+	for _, scheme := range proxySchemes {
+		valid = valid || parsed.Scheme == scheme
+	}
+	if !valid {
+		return fmt.Errorf("scheme must be http, https or socks5")
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("host is missing")
+	}
+	return nil
+}
+
+// proxyTransport returns a copy of http.DefaultTransport sending requests
+// through the proxy that proxy chooses for their URL. Unlike the default
+// transport, which reads the environment once per process, it uses proxy as
+// given, so that the provider attributes can override the environment.
+func proxyTransport(proxy *httpproxy.Config) *http.Transport {
+	proxyFunc := proxy.ProxyFunc()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	return transport
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// packerVariableSpecSchema declares the variables of a Packer template, so
+// that variables can be checked at plan time rather than failing the build.
+func packerVariableSpecSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Variable declared by the template. When any is declared, variables may only set declared variables and is validated against them at plan time",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Name of the variable",
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				"type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "string",
+					Description:  "Type the value of the variable must be coercible to (string, number, bool)",
+					ValidateFunc: validation.StringInSlice([]string{"string", "number", "bool"}, false),
+				},
+				"required": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether variables must set the variable, unless it has a default",
+				},
+				"default": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Value used by the template when variables does not set the variable",
+				},
+			},
+		},
+	}
+}
+
+// validatePackerVariables checks variables against variable_spec: every
+// variable must be declared, required variables without a default must be
+// set, and values and defaults must be coercible to the declared type.
+// Values that are unknown until apply are checked by the build instead, and
+// the SDK reads variables as empty when any of its values is unknown, which
+// only variables.% reveals.
+func validatePackerVariables(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("variable_spec") || !d.NewValueKnown("variables.%") {
+		return nil
+	}
+	specs := d.Get("variable_spec").([]interface{})
+	if len(specs) == 0 {
+		return nil
+	}
+	variables := d.Get("variables").(map[string]interface{})
+
+	declared := make(map[string]bool, len(specs))
+	for _, s := range specs {
+		spec := s.(map[string]interface{})
+		name := spec["name"].(string)
+		varType := spec["type"].(string)
+		defaultValue := spec["default"].(string)
+
+		if declared[name] {
+			return fmt.Errorf("variable_spec declares variable %q more than once", name)
+		}
+		declared[name] = true
+
+		if defaultValue != "" {
+			if err := checkPackerVariableType(varType, defaultValue); err != nil {
+				return fmt.Errorf("default of variable %q: %w", name, err)
+			}
+		}
+
+		value, ok := variables[name]
+		if !ok {
+			if spec["required"].(bool) && defaultValue == "" {
+				return fmt.Errorf("variable %q is required by variable_spec but not set in variables", name)
+			}
+			continue
+		}
+		if err := checkPackerVariableType(varType, value.(string)); err != nil {
+			return fmt.Errorf("variable %q: %w", name, err)
+		}
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !declared[name] {
+			return fmt.Errorf("variable %q is not declared in variable_spec", name)
+		}
+	}
+	return nil
+}
+
+// checkPackerVariableType checks that value, as passed to Packer, is
+// coercible to varType.
+func checkPackerVariableType(varType, value string) error {
+	switch varType {
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a bool", value)
+		}
+	}
+	return nil
+}
+
+func expandPackerVariableSpec(l []interface{}) []interface{} {
+	specs := make([]interface{}, 0, len(l))
+	for _, s := range l {
+		raw := s.(map[string]interface{})
+		spec := map[string]interface{}{
+			"name":     raw["name"].(string),
+			"type":     raw["type"].(string),
+			"required": raw["required"].(bool),
+		}
+		if defaultValue := raw["default"].(string); defaultValue != "" {
+			spec["default"] = defaultValue
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+func flattenPackerVariableSpec(template map[string]interface{}) []interface{} {
+	specs := []interface{}{}
+	for _, spec := range packerObjects(template, "variableSpec") {
+		specs = append(specs, map[string]interface{}{
+			"name":     getString(spec, "name"),
+			"type":     getString(spec, "type"),
+			"required": getBool(spec, "required"),
+			"default":  getString(spec, "default"),
+		})
+	}
+	return specs
+}
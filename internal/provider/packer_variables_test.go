@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testUnknownValue is how the SDK represents configuration values that are
+// unknown until apply.
+const testUnknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// TestPackerTemplateDiff_variableSpec checks that variables are validated
+// against variable_spec at plan time
+func TestPackerTemplateDiff_variableSpec(t *testing.T) {
+	spec := []interface{}{
+		map[string]interface{}{"name": "region", "required": true},
+		map[string]interface{}{"name": "disk_size", "type": "number", "default": "20"},
+		map[string]interface{}{"name": "debug", "type": "bool"},
+	}
+
+	cases := map[string]struct {
+		variables map[string]interface{}
+		spec      []interface{}
+		expectErr string
+	}{
+		"valid variables":    {variables: map[string]interface{}{"region": "GRA", "disk_size": "40", "debug": "true"}, spec: spec},
+		"defaults used":      {variables: map[string]interface{}{"region": "GRA"}, spec: spec},
+		"no variable_spec":   {variables: map[string]interface{}{"anything": "goes"}},
+		"missing required":   {variables: map[string]interface{}{"disk_size": "40"}, spec: spec, expectErr: `"region" is required`},
+		"not a number":       {variables: map[string]interface{}{"region": "GRA", "disk_size": "large"}, spec: spec, expectErr: `"large" is not a number`},
+		"not a bool":         {variables: map[string]interface{}{"region": "GRA", "debug": "maybe"}, spec: spec, expectErr: `"maybe" is not a bool`},
+		"undeclared":         {variables: map[string]interface{}{"region": "GRA", "regoin": "SBG"}, spec: spec, expectErr: `"regoin" is not declared`},
+		"unknown value":      {variables: map[string]interface{}{"region": "GRA", "disk_size": testUnknownValue}, spec: spec},
+		"invalid default":    {variables: map[string]interface{}{}, spec: []interface{}{map[string]interface{}{"name": "count", "type": "number", "default": "two"}}, expectErr: `default of variable "count"`},
+		"duplicate variable": {variables: map[string]interface{}{}, spec: []interface{}{map[string]interface{}{"name": "region"}, map[string]interface{}{"name": "region"}}, expectErr: "more than once"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testPackerTemplateRawConfig()
+			raw["builder"] = []interface{}{map[string]interface{}{"type": "openstack"}}
+			raw["variables"] = tc.variables
+			if tc.spec != nil {
+				raw["variable_spec"] = tc.spec
+			}
+
+			_, err := resourcePackerTemplate().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectErr == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tc.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tc.expectErr)) {
+				t.Fatalf("expected an error containing %s, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
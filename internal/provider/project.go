@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func projectIdSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: "ID of the OVH public cloud project to create the resource in, defaults to the provider ovh_project_id. Changing it replaces the resource",
+	}
+}
+
+// setProjectID sets project_id from the projectId of an API resource. Older
+// resources were created without one, so an empty value keeps the project
+// already in state rather than planning a replacement.
+func setProjectID(d *schema.ResourceData, resource map[string]interface{}) {
+	if projectId := getString(resource, "projectId"); projectId != "" {
+		d.Set("project_id", projectId)
+	}
+}
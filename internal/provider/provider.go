@@ -2,40 +2,143 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ovh/go-ovh/ovh"
+	"golang.org/x/net/http/httpproxy"
 )
 
 type HashiCorpOVHProvider struct {
 	version string
+	commit  string
 }
 
 type HashiCorpOVHProviderModel struct {
-	OVHEndpoint          types.String `tfsdk:"ovh_endpoint"`
-	OVHApplicationKey    types.String `tfsdk:"ovh_application_key"`
-	OVHApplicationSecret types.String `tfsdk:"ovh_application_secret"`
-	OVHConsumerKey       types.String `tfsdk:"ovh_consumer_key"`
+	OVHEndpoint              types.String `tfsdk:"ovh_endpoint"`
+	OVHApplicationKey        types.String `tfsdk:"ovh_application_key"`
+	OVHApplicationSecret     types.String `tfsdk:"ovh_application_secret"`
+	OVHConsumerKey           types.String `tfsdk:"ovh_consumer_key"`
+	DelegatedConsumerKey     types.String `tfsdk:"delegated_consumer_key"`
+	OVHAccessToken           types.String `tfsdk:"ovh_access_token"`
+	OVHClientID              types.String `tfsdk:"ovh_client_id"`
+	OVHClientSecret          types.String `tfsdk:"ovh_client_secret"`
+	OVHProjectID             types.String `tfsdk:"ovh_project_id"`
+	APIBaseURL               types.String `tfsdk:"api_base_url"`
+	ServiceEndpoints         types.Map    `tfsdk:"service_endpoints"`
+	CircuitBreakerThreshold  types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown   types.String `tfsdk:"circuit_breaker_cooldown"`
+	PollInterval             types.String `tfsdk:"poll_interval"`
+	ConfigFile               types.String `tfsdk:"config_file"`
+	DefaultInstanceType      types.String `tfsdk:"default_instance_type"`
+	SkipCredentialValidation types.Bool   `tfsdk:"skip_credential_validation"`
+	ValidateOnly             types.Bool   `tfsdk:"validate_only"`
+	DryRun                   types.Bool   `tfsdk:"dry_run"`
+	HTTPProxy                types.String `tfsdk:"http_proxy"`
+	HTTPSProxy               types.String `tfsdk:"https_proxy"`
+	NoProxy                  types.String `tfsdk:"no_proxy"`
 }
 
+// validOVHEndpoints lists the OVH API endpoint names accepted by ovh_endpoint.
+var validOVHEndpoints = []string{
+	"ovh-eu",
+	"ovh-us",
+	"ovh-ca",
+	"kimsufi-eu",
+	"kimsufi-ca",
+	"soyoustart-eu",
+	"soyoustart-ca",
+	"runabove-ca",
+}
+
+// Config is shared by every resource and data source operation, which
+// Terraform runs concurrently. Exported fields are set once in Configure and
+// must not be modified afterwards; state that changes later, such as the
+// cached project check and flavor prices or the list cache and circuit
+// breaker of OVHClient, is guarded by a mutex.
 type Config struct {
-	OVHClient *ovh.Client
+	OVHClient *lockedClient
+	Endpoint  string
+	ProjectID string
+
+	// PollInterval is how often wait loops poll the API, zero meaning each
+	// loop's default.
+	PollInterval time.Duration
+
+	// DefaultInstanceType is the instance type of resources that do not set
+	// instance_type, empty when there is none.
+	DefaultInstanceType string
+
+	projectMu      sync.Mutex
+	projectChecked bool
+
+	pricesMu sync.Mutex
+	prices   *flavorPrices
 }
 
-func New(version string) func() provider.Provider {
+// errUnknownFlavor is returned by checkFlavor for instance types that the
+// public cloud project does not offer.
+var errUnknownFlavor = errors.New("unknown instance type")
+
+// errProjectSuspended is returned by checkProject when the public cloud
+// project is suspended or expired.
+var errProjectSuspended = errors.New("project is suspended")
+
+// errUnknownProject is returned by checkProjectID for public cloud projects
+// that do not exist or are not visible to the configured credentials.
+var errUnknownProject = errors.New("project does not exist")
+
+// errInvalidCredentials is returned by checkCredentials when the OVH API
+// rejects the configured credentials.
+var errInvalidCredentials = errors.New("OVH credentials are invalid or lack permissions")
+
+// errValidateOnly is returned by every API call of a provider configured
+// with validate_only.
+var errValidateOnly = errors.New("the provider is configured with validate_only = true, which disables resource and data source operations")
+
+// minPollInterval and maxPollInterval bound the poll_interval attribute.
+const (
+	minPollInterval = 5 * time.Second
+	maxPollInterval = 5 * time.Minute
+)
+
+// statusServiceExpired is the HTTP status OVH answers with for calls on an
+// expired or suspended service.
+const statusServiceExpired = 460
+
+func New(version, commit string) func() provider.Provider {
 	return func() provider.Provider {
 		return &HashiCorpOVHProvider{
 			version: version,
+			commit:  commit,
 		}
 	}
 }
 
+// userAgent identifies the provider build to the OVH API. The go-ovh client
+// sends it as a comment after its own product token.
+func (p *HashiCorpOVHProvider) userAgent() string {
+	userAgent := "terraform-provider-hashicorp-ovh/" + p.version
+	if p.commit != "" {
+		userAgent += fmt.Sprintf(" (commit %s)", p.commit)
+	}
+	return userAgent
+}
+
 func (p *HashiCorpOVHProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "hashicorp-ovh"
 	resp.Version = p.version
@@ -45,23 +148,99 @@ func (p *HashiCorpOVHProvider) Schema(ctx context.Context, req provider.SchemaRe
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"ovh_endpoint": schema.StringAttribute{
-				Description: "OVH API endpoint (ovh-eu, ovh-us, ovh-ca, kimsufi-eu, kimsufi-ca, soyoustart-eu, soyoustart-ca, runabove-ca)",
-				Required:    true,
+				Description: "OVH API endpoint (ovh-eu, ovh-us, ovh-ca, kimsufi-eu, kimsufi-ca, soyoustart-eu, soyoustart-ca, runabove-ca). Can also be set with the OVH_ENDPOINT environment variable or the endpoint of an ovh.conf file",
+				Optional:    true,
 			},
 			"ovh_application_key": schema.StringAttribute{
 				Description: "OVH API application key",
-				Required:    true,
+				Optional:    true,
 			},
 			"ovh_application_secret": schema.StringAttribute{
 				Description: "OVH API application secret",
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 			},
 			"ovh_consumer_key": schema.StringAttribute{
 				Description: "OVH API consumer key",
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"delegated_consumer_key": schema.StringAttribute{
+				Description: "OVH API consumer key validated by another OVH account for ovh_application_key, used instead of ovh_consumer_key to manage that account's resources",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"ovh_access_token": schema.StringAttribute{
+				Description: "OVH API OAuth2 access token, used instead of the application key, secret and consumer key",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"ovh_client_id": schema.StringAttribute{
+				Description: "OVH API OAuth2 client ID, used with ovh_client_secret instead of the application key, secret and consumer key",
+				Optional:    true,
+			},
+			"ovh_client_secret": schema.StringAttribute{
+				Description: "OVH API OAuth2 client secret",
+				Optional:    true,
 				Sensitive:   true,
 			},
+			"ovh_project_id": schema.StringAttribute{
+				Description: "OVH Public Cloud project ID",
+				Optional:    true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "Path of an ovh.conf file to read the endpoint and credentials from when they are not set in the provider block or OVH_* environment variables. Without it, the default ovh.conf files are read if the credentials are incomplete",
+				Optional:    true,
+			},
+			"api_base_url": schema.StringAttribute{
+				Description: "Base URL of the OVH API, overriding the one derived from ovh_endpoint, for staging or mock APIs",
+				Optional:    true,
+			},
+			"service_endpoints": schema.MapAttribute{
+				Description: "Base URLs replacing the /cloud/project/<service> path of the OVH API for some services, keyed by service name (" + strings.Join(ovhServices, ", ") + "), for API gateways and proxies",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				Description: "Number of consecutive OVH API calls failing with a server or network error after which further calls are skipped for circuit_breaker_cooldown, 0 disables the circuit breaker. Defaults to 5",
+				Optional:    true,
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				Description: "How long OVH API calls are skipped once the circuit breaker opens, as a duration such as 30s or 2m. Defaults to 1m",
+				Optional:    true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: "How often to poll the OVH API while waiting for asynchronous operations, as a duration between 5s and 5m. Defaults to 30s",
+				Optional:    true,
+			},
+			"default_instance_type": schema.StringAttribute{
+				Description: "OVH instance type used by resources that do not set instance_type. It must be offered in the ovh_project_id project",
+				Optional:    true,
+			},
+			"skip_credential_validation": schema.BoolAttribute{
+				Description: "Skip checking the credentials against the OVH API when the provider is configured. Plans still call the API to refresh resources and check the project, instance types and quota. Defaults to false",
+				Optional:    true,
+			},
+			"validate_only": schema.BoolAttribute{
+				Description: "Only validate the endpoint, credentials and project when the provider is configured, reporting checks that could not be completed as warnings, and fail every resource and data source operation. Overrides skip_credential_validation. Defaults to false",
+				Optional:    true,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Read from the OVH API and run the checks of resource operations, but send none of the requests that would create, update or delete anything. Each such request fails its operation with an error describing it, so applies change nothing at OVH or in state. Ignored with validate_only. Defaults to false",
+				Optional:    true,
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "URL of the proxy for OVH API requests over http, such as http://proxy.example.com:3128. Overrides the HTTP_PROXY environment variable. Cannot be set with OAuth2 client credentials",
+				Optional:    true,
+			},
+			"https_proxy": schema.StringAttribute{
+				Description: "URL of the proxy for OVH API requests over https, which the standard OVH endpoints use. Overrides the HTTPS_PROXY environment variable. Cannot be set with OAuth2 client credentials",
+				Optional:    true,
+			},
+			"no_proxy": schema.StringAttribute{
+				Description: "Comma-separated hosts, domains and CIDR ranges that OVH API requests reach without a proxy, as in the NO_PROXY environment variable, which it overrides. An empty string proxies every host. Cannot be set with OAuth2 client credentials",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -96,40 +275,280 @@ func (p *HashiCorpOVHProvider) Configure(ctx context.Context, req provider.Confi
 		ovhConsumerKey = config.OVHConsumerKey.ValueString()
 	}
 
+	delegatedConsumerKey := config.DelegatedConsumerKey.ValueString()
+
+	ovhAccessToken := os.Getenv("OVH_ACCESS_TOKEN")
+	if !config.OVHAccessToken.IsNull() {
+		ovhAccessToken = config.OVHAccessToken.ValueString()
+	}
+
+	ovhClientID := os.Getenv("OVH_CLIENT_ID")
+	if !config.OVHClientID.IsNull() {
+		ovhClientID = config.OVHClientID.ValueString()
+	}
+
+	ovhClientSecret := os.Getenv("OVH_CLIENT_SECRET")
+	if !config.OVHClientSecret.IsNull() {
+		ovhClientSecret = config.OVHClientSecret.ValueString()
+	}
+
+	ovhProjectID := os.Getenv("OVH_PROJECT_ID")
+	if !config.OVHProjectID.IsNull() {
+		ovhProjectID = config.OVHProjectID.ValueString()
+	}
+
+	apiBaseURL := os.Getenv("OVH_API_BASE_URL")
+	if !config.APIBaseURL.IsNull() {
+		apiBaseURL = config.APIBaseURL.ValueString()
+	}
+
+	serviceEndpoints := map[string]string{}
+	if !config.ServiceEndpoints.IsNull() {
+		resp.Diagnostics.Append(config.ServiceEndpoints.ElementsAs(ctx, &serviceEndpoints, false)...)
+	}
+
+	// ovh.conf comes last in precedence, after the provider block and the
+	// environment, and only supplies the endpoint or credentials when those
+	// are missing.
+	credentialsComplete := (ovhApplicationKey != "" && ovhApplicationSecret != "" && (ovhConsumerKey != "" || delegatedConsumerKey != "")) ||
+		ovhAccessToken != "" ||
+		(ovhClientID != "" && ovhClientSecret != "")
+	configFile := config.ConfigFile.ValueString()
+	if configFile != "" || !credentialsComplete || ovhEndpoint == "" {
+		cfg, err := loadOVHConfigFile(configFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config_file"),
+				"Invalid OVH Config File",
+				"While configuring the provider, the OVH config file could not be read: "+err.Error()+".",
+			)
+		} else {
+			fillFromConfigFile(cfg, "default", "endpoint", &ovhEndpoint)
+			if !credentialsComplete {
+				fillFromConfigFile(cfg, ovhEndpoint, "application_key", &ovhApplicationKey)
+				fillFromConfigFile(cfg, ovhEndpoint, "application_secret", &ovhApplicationSecret)
+				fillFromConfigFile(cfg, ovhEndpoint, "consumer_key", &ovhConsumerKey)
+				fillFromConfigFile(cfg, ovhEndpoint, "access_token", &ovhAccessToken)
+				fillFromConfigFile(cfg, ovhEndpoint, "client_id", &ovhClientID)
+				fillFromConfigFile(cfg, ovhEndpoint, "client_secret", &ovhClientSecret)
+			}
+		}
+	}
+
+	breakerThreshold := defaultCircuitBreakerThreshold
+	if !config.CircuitBreakerThreshold.IsNull() {
+		breakerThreshold = int(config.CircuitBreakerThreshold.ValueInt64())
+		if breakerThreshold < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("circuit_breaker_threshold"),
+				"Invalid Circuit Breaker Threshold",
+				"While configuring the provider, circuit_breaker_threshold was negative. "+
+					"Set it to 0 to disable the circuit breaker.",
+			)
+		}
+	}
+
+	breakerCooldown := defaultCircuitBreakerCooldown
+	if !config.CircuitBreakerCooldown.IsNull() {
+		cooldown, err := time.ParseDuration(config.CircuitBreakerCooldown.ValueString())
+		if err != nil || cooldown <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("circuit_breaker_cooldown"),
+				"Invalid Circuit Breaker Cooldown",
+				"While configuring the provider, circuit_breaker_cooldown \""+config.CircuitBreakerCooldown.ValueString()+"\" "+
+					"is not a positive duration such as 30s or 2m.",
+			)
+		}
+		breakerCooldown = cooldown
+	}
+
+	var pollInterval time.Duration
+	if !config.PollInterval.IsNull() {
+		interval, err := time.ParseDuration(config.PollInterval.ValueString())
+		if err != nil || interval < minPollInterval || interval > maxPollInterval {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_interval"),
+				"Invalid Poll Interval",
+				"While configuring the provider, poll_interval \""+config.PollInterval.ValueString()+"\" "+
+					"is not a duration between "+minPollInterval.String()+" and "+maxPollInterval.String()+".",
+			)
+		}
+		pollInterval = interval
+	}
+
+	defaultInstanceType := config.DefaultInstanceType.ValueString()
+
+	// Each proxy attribute overrides its environment variable on its own, so
+	// that setting https_proxy keeps the NO_PROXY of the environment.
+	proxyConfig := httpproxy.FromEnvironment()
+	proxySet := false
+	proxyAttribute := ""
+	for _, proxy := range []struct {
+		attribute string
+		value     types.String
+		setting   *string
+	}{
+		{"http_proxy", config.HTTPProxy, &proxyConfig.HTTPProxy},
+		{"https_proxy", config.HTTPSProxy, &proxyConfig.HTTPSProxy},
+		{"no_proxy", config.NoProxy, &proxyConfig.NoProxy},
+	} {
+		if proxy.value.IsNull() {
+			continue
+		}
+		if !proxySet {
+			proxyAttribute = proxy.attribute
+		}
+		proxySet = true
+		*proxy.setting = proxy.value.ValueString()
+		if proxy.attribute == "no_proxy" || *proxy.setting == "" {
+			continue
+		}
+		if err := validateProxyURL(*proxy.setting); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(proxy.attribute),
+				"Invalid Proxy URL",
+				"While configuring the provider, the "+proxy.attribute+" URL \""+*proxy.setting+"\" is not valid: "+err.Error()+".",
+			)
+		}
+	}
+
 	if ovhEndpoint == "" {
 		resp.Diagnostics.AddError(
 			"Missing OVH Endpoint Configuration",
 			"While configuring the provider, the OVH endpoint was not found in "+
-				"the OVH_ENDPOINT environment variable or provider "+
-				"configuration block ovh_endpoint attribute.",
+				"the OVH_ENDPOINT environment variable, the provider "+
+				"configuration block ovh_endpoint attribute or an ovh.conf file.",
 		)
 	}
 
-	if ovhApplicationKey == "" {
-		resp.Diagnostics.AddError(
-			"Missing OVH Application Key Configuration",
-			"While configuring the provider, the OVH application key was not found in "+
-				"the OVH_APPLICATION_KEY environment variable or provider "+
-				"configuration block ovh_application_key attribute.",
-		)
+	if ovhEndpoint != "" {
+		normalizedEndpoint, ok := normalizeOVHEndpoint(ovhEndpoint)
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ovh_endpoint"),
+				"Invalid OVH Endpoint",
+				"While configuring the provider, the OVH endpoint \""+ovhEndpoint+"\" was not recognized. "+
+					"Valid values are: "+strings.Join(validOVHEndpoints, ", ")+".",
+			)
+		}
+		ovhEndpoint = normalizedEndpoint
 	}
 
-	if ovhApplicationSecret == "" {
-		resp.Diagnostics.AddError(
-			"Missing OVH Application Secret Configuration",
-			"While configuring the provider, the OVH application secret was not found in "+
-				"the OVH_APPLICATION_SECRET environment variable or provider "+
-				"configuration block ovh_application_secret attribute.",
-		)
+	if apiBaseURL != "" {
+		normalizedURL, err := normalizeAPIBaseURL(apiBaseURL)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_base_url"),
+				"Invalid OVH API Base URL",
+				"While configuring the provider, the OVH API base URL \""+apiBaseURL+"\" is not valid: "+err.Error()+".",
+			)
+		}
+		apiBaseURL = normalizedURL
+	}
+
+	for service, endpoint := range serviceEndpoints {
+		if !slices.Contains(ovhServices, service) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("service_endpoints"),
+				"Invalid OVH Service Endpoint",
+				"While configuring the provider, service_endpoints has an endpoint for the unknown service \""+service+"\". "+
+					"Valid services are: "+strings.Join(ovhServices, ", ")+".",
+			)
+			continue
+		}
+		normalizedURL, err := normalizeAPIBaseURL(endpoint)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("service_endpoints"),
+				"Invalid OVH Service Endpoint",
+				"While configuring the provider, the "+service+" service endpoint \""+endpoint+"\" is not valid: "+err.Error()+".",
+			)
+		}
+		serviceEndpoints[service] = normalizedURL
 	}
 
-	if ovhConsumerKey == "" {
+	legacyCredentials := ovhApplicationKey != "" || ovhApplicationSecret != "" || ovhConsumerKey != "" || delegatedConsumerKey != ""
+	accessTokenCredentials := ovhAccessToken != ""
+	oauth2Credentials := ovhClientID != "" || ovhClientSecret != ""
+
+	credentialSchemes := 0
+	for _, set := range []bool{legacyCredentials, accessTokenCredentials, oauth2Credentials} {
+		if set {
+			credentialSchemes++
+		}
+	}
+
+	switch {
+	case credentialSchemes > 1:
 		resp.Diagnostics.AddError(
-			"Missing OVH Consumer Key Configuration",
-			"While configuring the provider, the OVH consumer key was not found in "+
-				"the OVH_CONSUMER_KEY environment variable or provider "+
-				"configuration block ovh_consumer_key attribute.",
+			"Conflicting OVH Credentials Configuration",
+			"While configuring the provider, more than one set of OVH credentials was found. "+
+				"Use exactly one of: ovh_application_key, ovh_application_secret and ovh_consumer_key "+
+				"or delegated_consumer_key; "+
+				"ovh_access_token; or ovh_client_id and ovh_client_secret. "+
+				"Check both the provider configuration block and the OVH_* environment variables.",
+		)
+	case oauth2Credentials && (ovhClientID == "" || ovhClientSecret == ""):
+		resp.Diagnostics.AddError(
+			"Incomplete OVH OAuth2 Configuration",
+			"While configuring the provider, only one of the OVH OAuth2 client ID and client secret was found. "+
+				"Both the ovh_client_id and ovh_client_secret attributes (or the OVH_CLIENT_ID and "+
+				"OVH_CLIENT_SECRET environment variables) must be set.",
+		)
+	case oauth2Credentials && apiBaseURL != "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_base_url"),
+			"Unsupported OVH API Base URL",
+			"While configuring the provider, api_base_url was set together with OAuth2 credentials. "+
+				"OAuth2 tokens can only be issued by the standard OVH endpoints, use ovh_application_key, "+
+				"ovh_application_secret and ovh_consumer_key or ovh_access_token instead.",
 		)
+	case oauth2Credentials && len(serviceEndpoints) > 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("service_endpoints"),
+			"Unsupported OVH Service Endpoints",
+			"While configuring the provider, service_endpoints was set together with OAuth2 credentials. "+
+				"OAuth2 tokens can only be issued by the standard OVH endpoints, use ovh_application_key, "+
+				"ovh_application_secret and ovh_consumer_key or ovh_access_token instead.",
+		)
+	case oauth2Credentials && proxySet:
+		// go-ovh requests OAuth2 tokens with the default HTTP client, which
+		// only follows the proxy of the environment.
+		resp.Diagnostics.AddAttributeError(
+			path.Root(proxyAttribute),
+			"Unsupported Proxy Configuration",
+			"While configuring the provider, "+proxyAttribute+" was set together with OAuth2 credentials. "+
+				"OAuth2 tokens are requested through the proxy of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY "+
+				"environment variables only, set them instead or use ovh_application_key, "+
+				"ovh_application_secret and ovh_consumer_key or ovh_access_token.",
+		)
+	case !accessTokenCredentials && !oauth2Credentials:
+		if ovhApplicationKey == "" {
+			resp.Diagnostics.AddError(
+				"Missing OVH Application Key Configuration",
+				"While configuring the provider, the OVH application key was not found in "+
+					"the OVH_APPLICATION_KEY environment variable or provider "+
+					"configuration block ovh_application_key attribute.",
+			)
+		}
+
+		if ovhApplicationSecret == "" {
+			resp.Diagnostics.AddError(
+				"Missing OVH Application Secret Configuration",
+				"While configuring the provider, the OVH application secret was not found in "+
+					"the OVH_APPLICATION_SECRET environment variable or provider "+
+					"configuration block ovh_application_secret attribute.",
+			)
+		}
+
+		if ovhConsumerKey == "" && delegatedConsumerKey == "" {
+			resp.Diagnostics.AddError(
+				"Missing OVH Consumer Key Configuration",
+				"While configuring the provider, the OVH consumer key was not found in "+
+					"the OVH_CONSUMER_KEY environment variable or provider "+
+					"configuration block ovh_consumer_key attribute.",
+			)
+		}
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -138,17 +557,56 @@ func (p *HashiCorpOVHProvider) Configure(ctx context.Context, req provider.Confi
 
 	ctx = tflog.SetField(ctx, "ovh_endpoint", ovhEndpoint)
 	ctx = tflog.SetField(ctx, "ovh_application_key", ovhApplicationKey)
+	ctx = tflog.SetField(ctx, "ovh_client_id", ovhClientID)
+	ctx = tflog.SetField(ctx, "ovh_project_id", ovhProjectID)
+	ctx = tflog.SetField(ctx, "api_base_url", apiBaseURL)
+	ctx = tflog.SetField(ctx, "proxy_configured", proxySet)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "ovh_application_secret")
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "ovh_consumer_key")
+	ctx = tflog.SetField(ctx, "delegated", delegatedConsumerKey != "")
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "ovh_access_token")
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "ovh_client_secret")
 
 	tflog.Debug(ctx, "Creating OVH client")
 
-	ovhClient, err := ovh.NewClient(
-		ovhEndpoint,
-		ovhApplicationKey,
-		ovhApplicationSecret,
-		ovhConsumerKey,
-	)
+	// go-ovh treats an endpoint containing a "/" as the API URL itself.
+	clientEndpoint := ovhEndpoint
+	if apiBaseURL != "" {
+		clientEndpoint = apiBaseURL
+	}
+
+	newOVHClient := func(endpoint string) (*ovh.Client, error) {
+		var ovhClient *ovh.Client
+		var err error
+		switch {
+		case accessTokenCredentials:
+			ovhClient, err = ovh.NewAccessTokenClient(endpoint, ovhAccessToken)
+		case oauth2Credentials:
+			ovhClient, err = ovh.NewOAuth2Client(endpoint, ovhClientID, ovhClientSecret)
+		default:
+			// A delegated consumer key replaces the provider's own one.
+			consumerKey := ovhConsumerKey
+			if delegatedConsumerKey != "" {
+				consumerKey = delegatedConsumerKey
+			}
+			ovhClient, err = ovh.NewClient(
+				endpoint,
+				ovhApplicationKey,
+				ovhApplicationSecret,
+				consumerKey,
+			)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ovhClient.UserAgent = p.userAgent()
+		if proxySet {
+			ovhClient.Client.Transport = proxyTransport(proxyConfig)
+		}
+		return ovhClient, nil
+	}
+
+	ovhClient, err := newOVHClient(clientEndpoint)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create OVH API Client",
@@ -159,8 +617,112 @@ func (p *HashiCorpOVHProvider) Configure(ctx context.Context, req provider.Confi
 		return
 	}
 
+	client := newLockedClient(ovhClient)
+	client.breaker = newCircuitBreaker(breakerThreshold, breakerCooldown)
+
+	if len(serviceEndpoints) > 0 {
+		client.services = make(map[string]*ovh.Client, len(serviceEndpoints))
+		for service, endpoint := range serviceEndpoints {
+			serviceClient, err := newOVHClient(endpoint)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("service_endpoints"),
+					"Unable to Create OVH API Client",
+					"An unexpected error occurred when creating the OVH API client of the "+service+" service endpoint.\n\n"+
+						"OVH Client Error: "+err.Error(),
+				)
+				return
+			}
+			client.services[service] = serviceClient
+		}
+		ctx = tflog.SetField(ctx, "service_endpoints", serviceEndpoints)
+	}
+
 	providerConfig := &Config{
-		OVHClient: ovhClient,
+		OVHClient:    client,
+		Endpoint:     ovhEndpoint,
+		ProjectID:    ovhProjectID,
+		PollInterval: pollInterval,
+
+		DefaultInstanceType: defaultInstanceType,
+	}
+
+	// Checks that could not be completed are only logged, unless
+	// validate_only asks for them to be reported.
+	validateOnly := config.ValidateOnly.ValueBool()
+	warnUnverified := func(summary string, err error) {
+		if validateOnly {
+			resp.Diagnostics.AddWarning(summary, "While validating the provider configuration: "+err.Error())
+			return
+		}
+		tflog.Warn(ctx, summary, map[string]any{"error": err.Error()})
+	}
+
+	if delegatedConsumerKey != "" {
+		if err := providerConfig.checkDelegation(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("delegated_consumer_key"),
+				"Unusable OVH Delegated Consumer Key",
+				"While configuring the provider, the delegated consumer key could not be used: "+err.Error()+". "+
+					"Ask the account owner to validate a new credential request for ovh_application_key.",
+			)
+			return
+		}
+	}
+
+	if err := providerConfig.checkProject(); err != nil {
+		if errors.Is(err, errProjectSuspended) {
+			resp.Diagnostics.AddError(
+				"OVH Project Suspended",
+				"The OVH public cloud project "+ovhProjectID+" is suspended or expired, so no resources "+
+					"can be managed in it. Reactivate the project from the OVHcloud Control Panel, for "+
+					"example by settling outstanding invoices, then run Terraform again.",
+			)
+			return
+		}
+
+		warnUnverified("Unable to verify OVH project", err)
+	}
+
+	if defaultInstanceType != "" {
+		if err := providerConfig.checkFlavor(defaultInstanceType); err != nil {
+			if errors.Is(err, errUnknownFlavor) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("default_instance_type"),
+					"Invalid Default Instance Type",
+					"While configuring the provider, default_instance_type \""+defaultInstanceType+"\" "+
+						"was not found in the flavors of OVH project "+ovhProjectID+".",
+				)
+				return
+			}
+
+			warnUnverified("Unable to verify default instance type", err)
+		}
+	}
+
+	// A delegated consumer key was already checked above.
+	if (validateOnly || !config.SkipCredentialValidation.ValueBool()) && delegatedConsumerKey == "" {
+		if err := providerConfig.checkCredentials(); err != nil {
+			if errors.Is(err, errInvalidCredentials) {
+				resp.Diagnostics.AddError(
+					"Invalid OVH Credentials",
+					"While configuring the provider, the OVH API rejected the configured credentials: "+err.Error()+". "+
+						"Check that they belong to the "+ovhEndpoint+" endpoint and grant access to /auth/currentCredential, "+
+						"or set skip_credential_validation = true to skip this check.",
+				)
+				return
+			}
+
+			warnUnverified("Unable to verify OVH credentials", err)
+		}
+	}
+
+	if validateOnly {
+		client.disabled = errValidateOnly
+		tflog.Info(ctx, "Validated HashiCorp OVH provider configuration, resource operations are disabled by validate_only")
+	} else if config.DryRun.ValueBool() {
+		client.dryRun = true
+		tflog.Info(ctx, "Configured HashiCorp OVH provider for a dry run, requests changing resources are not sent")
 	}
 
 	resp.DataSourceData = providerConfig
@@ -169,6 +731,163 @@ func (p *HashiCorpOVHProvider) Configure(ctx context.Context, req provider.Confi
 	tflog.Info(ctx, "Configured HashiCorp OVH provider", map[string]any{"success": true})
 }
 
+// normalizeOVHEndpoint matches an endpoint name case-insensitively against
+// validOVHEndpoints and returns its canonical lower-case form.
+func normalizeOVHEndpoint(endpoint string) (string, bool) {
+	for _, valid := range validOVHEndpoints {
+		if strings.EqualFold(endpoint, valid) {
+			return valid, true
+		}
+	}
+	return endpoint, false
+}
+
+// normalizeAPIBaseURL checks that u is an absolute http or https URL and
+// strips trailing slashes, which go-ovh rejects.
+func normalizeAPIBaseURL(u string) (string, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return u, err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return u, fmt.Errorf("scheme must be http or https")
+	}
+	if parsed.Host == "" {
+		return u, fmt.Errorf("host is missing")
+	}
+	return strings.TrimRight(u, "/"), nil
+}
+
+// pollInterval returns the configured poll_interval, or fallback when it is
+// not set.
+func (c *Config) pollInterval(fallback time.Duration) time.Duration {
+	if c.PollInterval > 0 {
+		return c.PollInterval
+	}
+	return fallback
+}
+
+// instanceType returns value, the instance_type of a resource, or the
+// provider default_instance_type when it is empty.
+func (c *Config) instanceType(value string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+	if c.DefaultInstanceType != "" {
+		return c.DefaultInstanceType, nil
+	}
+	return "", fmt.Errorf("instance_type must be set, either on the resource or as default_instance_type on the provider")
+}
+
+// projectID returns value, the project_id of a resource, or the provider
+// ovh_project_id when it is empty. Configure only checks the provider project,
+// so any other project is checked here before resources are created in it.
+func (c *Config) projectID(value string) (string, error) {
+	if value == "" || value == c.ProjectID {
+		return c.ProjectID, nil
+	}
+	if err := c.checkProjectID(value); err != nil {
+		return "", fmt.Errorf("invalid project_id: %w", err)
+	}
+	return value, nil
+}
+
+// checkFlavor verifies that name is one of the instance flavors of the
+// configured public cloud project.
+func (c *Config) checkFlavor(name string) error {
+	if c.ProjectID == "" {
+		return fmt.Errorf("no OVH project configured to list flavors from")
+	}
+
+	var flavors []map[string]interface{}
+	err := c.OVHClient.Get(fmt.Sprintf("/cloud/project/%s/flavor", url.PathEscape(c.ProjectID)), &flavors)
+	if err != nil {
+		return fmt.Errorf("failed to list flavors of OVH project %s: %w", c.ProjectID, err)
+	}
+
+	for _, flavor := range flavors {
+		if getString(flavor, "name") == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q in OVH project %s", errUnknownFlavor, name, c.ProjectID)
+}
+
+// checkDelegation verifies that the configured consumer key is a validated,
+// unexpired credential, so that a pending or revoked delegation fails here
+// rather than on the first resource managed with it.
+func (c *Config) checkDelegation() error {
+	var credential map[string]interface{}
+	if err := c.OVHClient.Get("/auth/currentCredential", &credential); err != nil {
+		return fmt.Errorf("failed to read the delegated credential: %w", err)
+	}
+
+	if status := getString(credential, "status"); status != "validated" {
+		return fmt.Errorf("the delegated credential is %s, expected validated", status)
+	}
+	if expiration := getString(credential, "expiration"); expiration != "" {
+		expiresAt, err := time.Parse(time.RFC3339, expiration)
+		if err == nil && !expiresAt.After(time.Now()) {
+			return fmt.Errorf("the delegated credential expired at %s", expiration)
+		}
+	}
+	return nil
+}
+
+// checkCredentials verifies that the OVH API accepts the configured
+// credentials, so that invalid ones fail here rather than on the first
+// resource operation.
+func (c *Config) checkCredentials() error {
+	var credential map[string]interface{}
+	err := c.OVHClient.Get("/auth/currentCredential", &credential)
+	if isOVHErrorCode(err, http.StatusUnauthorized, http.StatusForbidden) {
+		return fmt.Errorf("%w: %s", errInvalidCredentials, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the current OVH credential: %w", err)
+	}
+	return nil
+}
+
+// checkProject verifies that the configured public cloud project is usable.
+// A successful check is cached so it only hits the API once per Config.
+func (c *Config) checkProject() error {
+	c.projectMu.Lock()
+	defer c.projectMu.Unlock()
+
+	if c.projectChecked || c.ProjectID == "" {
+		return nil
+	}
+
+	if err := c.checkProjectID(c.ProjectID); err != nil {
+		return err
+	}
+
+	c.projectChecked = true
+	return nil
+}
+
+// checkProjectID verifies that the public cloud project projectID exists and
+// is not suspended.
+func (c *Config) checkProjectID(projectID string) error {
+	var project map[string]interface{}
+	err := c.OVHClient.Get(fmt.Sprintf("/cloud/project/%s", url.PathEscape(projectID)), &project)
+	if isOVHErrorCode(err, http.StatusNotFound) {
+		return fmt.Errorf("OVH project %s: %w", projectID, errUnknownProject)
+	}
+	if isOVHErrorCode(err, statusServiceExpired) {
+		return fmt.Errorf("OVH project %s: %w", projectID, errProjectSuspended)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read OVH project %s: %w", projectID, err)
+	}
+
+	if getString(project, "status") == "suspended" {
+		return fmt.Errorf("OVH project %s: %w", projectID, errProjectSuspended)
+	}
+	return nil
+}
+
 func (p *HashiCorpOVHProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{}
 }
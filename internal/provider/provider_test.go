@@ -2,13 +2,23 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	frameworkprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -18,12 +28,12 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"hashicorp-ovh": providerserver.NewProtocol6WithError(New("test")()),
+	"hashicorp-ovh": providerserver.NewProtocol6WithError(New("test", "")()),
 }
 
 // TestProviderInitialization tests that the provider can be initialized
 func TestProviderInitialization(t *testing.T) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	if provider == nil {
 		t.Fatal("Expected provider to be initialized")
@@ -41,7 +51,7 @@ func TestProviderVersions(t *testing.T) {
 	
 	for _, version := range versions {
 		t.Run(version, func(t *testing.T) {
-			provider := New(version)()
+			provider := New(version, "")()
 			
 			if provider == nil {
 				t.Errorf("Provider should initialize with version %s", version)
@@ -62,7 +72,7 @@ func TestProviderVersions(t *testing.T) {
 
 // TestProviderMetadata tests that provider metadata is correctly set
 func TestProviderMetadata(t *testing.T) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	req := frameworkprovider.MetadataRequest{}
 	resp := &frameworkprovider.MetadataResponse{}
@@ -83,7 +93,7 @@ func TestProviderMetadata(t *testing.T) {
 
 // TestProviderSchema tests that the provider schema can be retrieved
 func TestProviderSchema(t *testing.T) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	req := frameworkprovider.SchemaRequest{}
 	resp := &frameworkprovider.SchemaResponse{}
@@ -108,29 +118,41 @@ func TestProviderSchema(t *testing.T) {
 		}
 	}
 	
-	// Verify required attributes are marked as required
-	requiredAttributes := []string{
+	// The endpoint and credentials are optional since they can come from the
+	// environment or an ovh.conf file, and only one credential scheme is used
+	optionalAttributes := []string{
 		"ovh_endpoint",
 		"ovh_application_key",
-		"ovh_application_secret", 
+		"ovh_application_secret",
 		"ovh_consumer_key",
+		"ovh_access_token",
+		"ovh_client_id",
+		"ovh_client_secret",
+		"api_base_url",
+		"service_endpoints",
+		"circuit_breaker_threshold",
+		"circuit_breaker_cooldown",
+		"poll_interval",
+		"skip_credential_validation",
 	}
-	
-	for _, attrName := range requiredAttributes {
+
+	for _, attrName := range optionalAttributes {
 		attr, exists := resp.Schema.Attributes[attrName]
 		if !exists {
-			t.Errorf("Required attribute %s not found", attrName)
+			t.Errorf("Optional attribute %s not found", attrName)
 			continue
 		}
-		if !attr.IsRequired() {
-			t.Errorf("Attribute %s should be required", attrName)
+		if !attr.IsOptional() {
+			t.Errorf("Attribute %s should be optional", attrName)
 		}
 	}
-	
+
 	// Verify sensitive attributes are marked as sensitive
 	sensitiveAttributes := []string{
 		"ovh_application_secret",
 		"ovh_consumer_key",
+		"ovh_access_token",
+		"ovh_client_secret",
 	}
 	
 	for _, attrName := range sensitiveAttributes {
@@ -149,7 +171,7 @@ func TestProviderSchema(t *testing.T) {
 func TestProviderConfigureWithValidConfig(t *testing.T) {
 	// Note: Full configure testing requires acceptance tests
 	// This test just verifies the provider can be instantiated
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	if provider == nil {
 		t.Error("Expected provider to be instantiated")
@@ -163,7 +185,7 @@ func TestProviderConfigureWithValidConfig(t *testing.T) {
 func TestProviderConfigureWithMissingConfig(t *testing.T) {
 	// Note: Full configure testing requires acceptance tests
 	// This test just verifies the provider behavior
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	if provider == nil {
 		t.Error("Expected provider to be instantiated")
@@ -173,9 +195,1347 @@ func TestProviderConfigureWithMissingConfig(t *testing.T) {
 	// where we can properly set up ConfigureRequest with tfsdk.Config
 }
 
+// testProviderConfigureRequest builds a ConfigureRequest from attribute values
+// given as strings, parsing those of bool attributes
+func testProviderConfigureRequest(t *testing.T, p frameworkprovider.Provider, values map[string]string) frameworkprovider.ConfigureRequest {
+	schemaResp := &frameworkprovider.SchemaResponse{}
+	p.Schema(context.Background(), frameworkprovider.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok && attrType.Is(tftypes.Bool) {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				t.Fatalf("invalid bool value %q for %s: %s", value, name, err)
+			}
+			attributes[name] = tftypes.NewValue(attrType, parsed)
+		} else if mapType, isMap := attrType.(tftypes.Map); ok && isMap {
+			// Map values are given as JSON objects of strings.
+			var entries map[string]string
+			if err := json.Unmarshal([]byte(value), &entries); err != nil {
+				t.Fatalf("invalid map value %q for %s: %s", value, name, err)
+			}
+			elements := make(map[string]tftypes.Value, len(entries))
+			for key, element := range entries {
+				elements[key] = tftypes.NewValue(mapType.ElementType, element)
+			}
+			attributes[name] = tftypes.NewValue(attrType, elements)
+		} else if ok {
+			attributes[name] = tftypes.NewValue(attrType, value)
+		} else {
+			attributes[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return frameworkprovider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		},
+	}
+}
+
+// TestProviderConfigureEndpointValidation tests that ovh_endpoint is validated case-insensitively
+func TestProviderConfigureEndpointValidation(t *testing.T) {
+	cases := map[string]bool{
+		"ovh-eu":           true,
+		"OVH-CA":           true,
+		"SoYouStart-eu":    true,
+		"invalid-endpoint": false,
+	}
+
+	for endpoint, valid := range cases {
+		t.Run(endpoint, func(t *testing.T) {
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, map[string]string{
+				"ovh_endpoint":               endpoint,
+				"ovh_application_key":        "test-app-key",
+				"ovh_application_secret":     "test-app-secret",
+				"ovh_consumer_key":           "test-consumer-key",
+				"skip_credential_validation": "true",
+			})
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if valid && resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			if !valid {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an Invalid OVH Endpoint error")
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); !strings.EqualFold(summary, "Invalid OVH Endpoint") {
+					t.Errorf("unexpected error summary: %s", summary)
+				}
+			}
+		})
+	}
+}
+
+// TestProviderConfigureCredentialSchemes tests that exactly one credential scheme must be provided
+func TestProviderConfigureCredentialSchemes(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	cases := map[string]struct {
+		values       map[string]string
+		errorSummary string
+	}{
+		"application credentials": {
+			values: map[string]string{
+				"ovh_application_key":    "test-app-key",
+				"ovh_application_secret": "test-app-secret",
+				"ovh_consumer_key":       "test-consumer-key",
+			},
+		},
+		"access token": {
+			values: map[string]string{
+				"ovh_access_token": "test-access-token",
+			},
+		},
+		"oauth2 client": {
+			values: map[string]string{
+				"ovh_client_id":     "test-client-id",
+				"ovh_client_secret": "test-client-secret",
+			},
+		},
+		"oauth2 client without secret": {
+			values: map[string]string{
+				"ovh_client_id": "test-client-id",
+			},
+			errorSummary: "Incomplete OVH OAuth2 Configuration",
+		},
+		"access token mixed with partial application credentials": {
+			values: map[string]string{
+				"ovh_access_token":    "test-access-token",
+				"ovh_application_key": "test-app-key",
+			},
+			errorSummary: "Conflicting OVH Credentials Configuration",
+		},
+		"no credentials": {
+			values:       map[string]string{},
+			errorSummary: "Missing OVH Application Key Configuration",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.values["ovh_endpoint"] = "ovh-eu"
+			tc.values["skip_credential_validation"] = "true"
+
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, tc.values)
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if tc.errorSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected a %q error", tc.errorSummary)
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tc.errorSummary {
+				t.Errorf("expected error %q, got %q", tc.errorSummary, summary)
+			}
+		})
+	}
+}
+
+// TestProviderConfigureAPIBaseURL tests that api_base_url is validated and
+// replaces the endpoint-derived URL on the OVH client
+func TestProviderConfigureAPIBaseURL(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	// A suspended project is only reported if the check reached the mock server.
+	mock.AddResponse(statusServiceExpired, `{"message": "This service is expired"}`, nil)
+
+	cases := map[string]struct {
+		values       map[string]string
+		errorSummary string
+	}{
+		"mock server": {
+			values: map[string]string{
+				"api_base_url":   mock.URL + "/",
+				"ovh_project_id": "project-123",
+			},
+			errorSummary: "OVH Project Suspended",
+		},
+		"missing scheme": {
+			values: map[string]string{
+				"api_base_url": "localhost:8080",
+			},
+			errorSummary: "Invalid OVH API Base URL",
+		},
+		"oauth2 client": {
+			values: map[string]string{
+				"api_base_url":      "https://staging.api.example.com/1.0",
+				"ovh_client_id":     "test-client-id",
+				"ovh_client_secret": "test-client-secret",
+			},
+			errorSummary: "Unsupported OVH API Base URL",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.values["ovh_endpoint"] = "ovh-eu"
+			if _, ok := tc.values["ovh_client_id"]; !ok {
+				tc.values["ovh_application_key"] = "test-app-key"
+				tc.values["ovh_application_secret"] = "test-app-secret"
+				tc.values["ovh_consumer_key"] = "test-consumer-key"
+			}
+
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, tc.values)
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected a %q error", tc.errorSummary)
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tc.errorSummary {
+				t.Errorf("expected error %q, got %q", tc.errorSummary, summary)
+			}
+		})
+	}
+
+	if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/project-123" {
+		t.Errorf("expected the project check to reach the mock server, got %s", got)
+	}
+}
+
+// TestProviderConfigureServiceEndpoints tests that service_endpoints is
+// validated and routes the paths of its services to their base URLs
+func TestProviderConfigureServiceEndpoints(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+	gateway := NewMockHTTPServer()
+	defer gateway.Close()
+
+	cases := map[string]struct {
+		serviceEndpoints string
+		errorSummary     string
+	}{
+		"routed":          {serviceEndpoints: `{"vault": "` + gateway.URL + `/gateway/vault/"}`},
+		"unknown service": {serviceEndpoints: `{"terraform": "https://gateway.example.com"}`, errorSummary: "Invalid OVH Service Endpoint"},
+		"invalid url":     {serviceEndpoints: `{"nomad": "gateway.example.com/nomad"}`, errorSummary: "Invalid OVH Service Endpoint"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, map[string]string{
+				"ovh_endpoint":               "ovh-eu",
+				"ovh_application_key":        "test-app-key",
+				"ovh_application_secret":     "test-app-secret",
+				"ovh_consumer_key":           "test-consumer-key",
+				"api_base_url":               mock.URL,
+				"service_endpoints":          tc.serviceEndpoints,
+				"skip_credential_validation": "true",
+			})
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if tc.errorSummary != "" {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected a %q error", tc.errorSummary)
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tc.errorSummary {
+					t.Errorf("expected error %q, got %q", tc.errorSummary, summary)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			config := resp.ResourceData.(*Config)
+			var result map[string]interface{}
+			if err := config.OVHClient.Get("/cloud/project/vault/cluster/vault-123", &result); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := config.OVHClient.Get("/cloud/project/vaults/cluster/vault-123", &result); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := config.OVHClient.Get("/cloud/project/consul/cluster?name=test", &result); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := gateway.GetRequestCount(); got != 1 {
+				t.Fatalf("expected 1 request to the vault endpoint, got %d", got)
+			}
+			if got := gateway.Requests[0].URL.Path; got != "/gateway/vault/cluster/vault-123" {
+				t.Errorf("expected the vault path relative to its endpoint, got %s", got)
+			}
+			if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/consul/cluster" {
+				t.Errorf("expected other services to use the API base URL, got %s", got)
+			}
+			if got := mock.Requests[len(mock.Requests)-2].URL.Path; got != "/cloud/project/vaults/cluster/vault-123" {
+				t.Errorf("expected only whole service segments to be routed, got %s", got)
+			}
+		})
+	}
+}
+
+// TestProviderConfigureDelegatedConsumerKey tests that a delegated consumer key
+// is probed at configuration and requires the application key and secret
+func TestProviderConfigureDelegatedConsumerKey(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	cases := map[string]struct {
+		values       map[string]string
+		credential   string
+		errorSummary string
+	}{
+		"validated delegation": {
+			values: map[string]string{
+				"ovh_application_key":    "test-app-key",
+				"ovh_application_secret": "test-app-secret",
+			},
+			credential: `{"credentialId": 42, "applicationId": 7, "status": "validated", "expiration": "2999-01-01T00:00:00+00:00"}`,
+		},
+		"pending delegation": {
+			values: map[string]string{
+				"ovh_application_key":    "test-app-key",
+				"ovh_application_secret": "test-app-secret",
+			},
+			credential:   `{"credentialId": 42, "applicationId": 7, "status": "pendingValidation"}`,
+			errorSummary: "Unusable OVH Delegated Consumer Key",
+		},
+		"expired delegation": {
+			values: map[string]string{
+				"ovh_application_key":    "test-app-key",
+				"ovh_application_secret": "test-app-secret",
+			},
+			credential:   `{"credentialId": 42, "applicationId": 7, "status": "validated", "expiration": "2020-01-01T00:00:00+00:00"}`,
+			errorSummary: "Unusable OVH Delegated Consumer Key",
+		},
+		"without application secret": {
+			values: map[string]string{
+				"ovh_application_key": "test-app-key",
+			},
+			errorSummary: "Missing OVH Application Secret Configuration",
+		},
+		"with access token": {
+			values: map[string]string{
+				"ovh_access_token": "test-access-token",
+			},
+			errorSummary: "Conflicting OVH Credentials Configuration",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, tc.credential, nil)
+
+			tc.values["ovh_endpoint"] = "ovh-eu"
+			tc.values["delegated_consumer_key"] = "test-delegated-key"
+			if _, ok := tc.values["ovh_access_token"]; !ok {
+				tc.values["api_base_url"] = mock.URL
+			}
+
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, tc.values)
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if tc.errorSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+				}
+				req := mock.GetLastRequest()
+				if req == nil || req.URL.Path != "/auth/currentCredential" {
+					t.Fatal("expected the delegated credential to be probed")
+				}
+				if got := req.Header.Get("X-Ovh-Consumer"); got != "test-delegated-key" {
+					t.Errorf("expected the delegated consumer key to be used, got %q", got)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected a %q error", tc.errorSummary)
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tc.errorSummary {
+				t.Errorf("expected error %q, got %q", tc.errorSummary, summary)
+			}
+		})
+	}
+}
+
+// TestProviderConfigureCredentialValidation tests that credentials rejected by
+// the OVH API fail the configuration unless skip_credential_validation is set
+func TestProviderConfigureCredentialValidation(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	cases := map[string]struct {
+		status       int
+		body         string
+		skip         string
+		expectCheck  bool
+		errorSummary string
+	}{
+		"valid credentials": {
+			status:      200,
+			body:        `{"credentialId": 42, "status": "validated"}`,
+			expectCheck: true,
+		},
+		"invalid credentials": {
+			status:       401,
+			body:         `{"message": "Invalid credentials"}`,
+			expectCheck:  true,
+			errorSummary: "Invalid OVH Credentials",
+		},
+		"missing permissions": {
+			status:       403,
+			body:         `{"message": "This call has not been granted"}`,
+			expectCheck:  true,
+			errorSummary: "Invalid OVH Credentials",
+		},
+		"api unavailable": {
+			status:      503,
+			body:        `{"message": "Service unavailable"}`,
+			expectCheck: true,
+		},
+		"skipped": {
+			status: 401,
+			body:   `{"message": "Invalid credentials"}`,
+			skip:   "true",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.status, tc.body, nil)
+
+			values := map[string]string{
+				"ovh_endpoint":           "ovh-eu",
+				"ovh_application_key":    "test-app-key",
+				"ovh_application_secret": "test-app-secret",
+				"ovh_consumer_key":       "test-consumer-key",
+				"api_base_url":           mock.URL,
+			}
+			if tc.skip != "" {
+				values["skip_credential_validation"] = tc.skip
+			}
+
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, values)
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			last := mock.GetLastRequest()
+			if checked := last != nil && last.URL.Path == "/auth/currentCredential"; checked != tc.expectCheck {
+				t.Errorf("expected credential check %t, got %t", tc.expectCheck, checked)
+			}
+
+			if tc.errorSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected a %q error", tc.errorSummary)
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != tc.errorSummary {
+				t.Errorf("expected error %q, got %q", tc.errorSummary, summary)
+			}
+		})
+	}
+}
+
+// TestProviderConfigureValidateOnly tests that validate_only checks the
+// credentials, reports unverified checks as warnings and disables the client
+func TestProviderConfigureValidateOnly(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	cases := map[string]struct {
+		status       int
+		body         string
+		errorSummary string
+		warnings     int
+	}{
+		"valid credentials":   {status: 200, body: `{"credentialId": 42, "status": "validated"}`},
+		"invalid credentials": {status: 401, body: `{"message": "Invalid credentials"}`, errorSummary: "Invalid OVH Credentials"},
+		"api unavailable":     {status: 503, body: `{"message": "Service unavailable"}`, warnings: 1},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.status, tc.body, nil)
+
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, map[string]string{
+				"ovh_endpoint":               "ovh-eu",
+				"ovh_application_key":        "test-app-key",
+				"ovh_application_secret":     "test-app-secret",
+				"ovh_consumer_key":           "test-consumer-key",
+				"api_base_url":               mock.URL,
+				"skip_credential_validation": "true",
+				"validate_only":              "true",
+			})
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if last := mock.GetLastRequest(); last == nil || last.URL.Path != "/auth/currentCredential" {
+				t.Error("expected validate_only to check the credentials despite skip_credential_validation")
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tc.warnings {
+				t.Errorf("expected %d warnings, got %d: %v", tc.warnings, got, resp.Diagnostics.Warnings())
+			}
+
+			if tc.errorSummary != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tc.errorSummary {
+					t.Fatalf("expected a %q error, got %v", tc.errorSummary, resp.Diagnostics.Errors())
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+
+			config := resp.ResourceData.(*Config)
+			requests := mock.GetRequestCount()
+			var cluster map[string]interface{}
+			if err := config.OVHClient.Get("/cloud/project/vault/cluster/vault-123", &cluster); !errors.Is(err, errValidateOnly) {
+				t.Errorf("expected resource operations to be disabled, got %v", err)
+			}
+			if got := mock.GetRequestCount(); got != requests {
+				t.Errorf("expected no request from a disabled client, got %d", got-requests)
+			}
+		})
+	}
+}
+
+// TestProviderConfigureDryRun tests that dry_run lets reads through and
+// skips every call that would change something
+func TestProviderConfigureDryRun(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	p := New("test", "")()
+	req := testProviderConfigureRequest(t, p, map[string]string{
+		"ovh_endpoint":               "ovh-eu",
+		"ovh_application_key":        "test-app-key",
+		"ovh_application_secret":     "test-app-secret",
+		"ovh_consumer_key":           "test-consumer-key",
+		"api_base_url":               mock.URL,
+		"skip_credential_validation": "true",
+		"dry_run":                    "true",
+	})
+	resp := &frameworkprovider.ConfigureResponse{}
+
+	p.Configure(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+	}
+
+	config := resp.ResourceData.(*Config)
+	requests := mock.GetRequestCount()
+	var cluster map[string]interface{}
+	if err := config.OVHClient.Get("/cloud/project/vault/cluster/vault-123", &cluster); err != nil {
+		t.Errorf("expected reads to be sent, got %v", err)
+	}
+	if got := mock.GetRequestCount(); got != requests+1 {
+		t.Fatalf("expected 1 read to be sent, got %d requests", got-requests)
+	}
+
+	body := map[string]interface{}{"name": "test"}
+	mutations := map[string]func() error{
+		"POST": func() error {
+			return config.OVHClient.Post("/cloud/project/vault/cluster", body, nil)
+		},
+		"POST idempotent": func() error {
+			return config.OVHClient.PostIdempotent("/cloud/project/vault/cluster", "key", body, nil)
+		},
+		"PUT": func() error {
+			return config.OVHClient.Put("/cloud/project/vault/cluster/vault-123", body, nil)
+		},
+		"DELETE": func() error {
+			return config.OVHClient.Delete("/cloud/project/vault/cluster/vault-123", nil)
+		},
+	}
+	for name, mutate := range mutations {
+		if err := mutate(); !errors.Is(err, errDryRun) {
+			t.Errorf("%s: expected a dry run error, got %v", name, err)
+		}
+	}
+	if got := mock.GetRequestCount(); got != requests+1 {
+		t.Errorf("expected no mutating request to be sent, got %d", got-requests-1)
+	}
+}
+
+// TestProviderConfigureConfigFile tests that credentials are taken from the
+// provider block, then the environment, then the ovh.conf file
+func TestProviderConfigureConfigFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "ovh.conf")
+	err := os.WriteFile(configFile, []byte(`[default]
+endpoint=ovh-eu
+
+[ovh-eu]
+application_key=file-app-key
+application_secret=file-app-secret
+consumer_key=file-consumer-key
+`), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write config file: %s", err)
+	}
+
+	cases := map[string]struct {
+		env              map[string]string
+		values           map[string]string
+		expectAppKey   string
+		expectConsumer string
+	}{
+		"config file": {
+			expectAppKey:   "file-app-key",
+			expectConsumer: "file-consumer-key",
+		},
+		"environment over config file": {
+			env: map[string]string{
+				"OVH_APPLICATION_KEY":    "env-app-key",
+				"OVH_APPLICATION_SECRET": "env-app-secret",
+				"OVH_CONSUMER_KEY":       "env-consumer-key",
+			},
+			expectAppKey:   "env-app-key",
+			expectConsumer: "env-consumer-key",
+		},
+		"block over environment": {
+			env: map[string]string{
+				"OVH_APPLICATION_KEY":    "env-app-key",
+				"OVH_APPLICATION_SECRET": "env-app-secret",
+				"OVH_CONSUMER_KEY":       "env-consumer-key",
+			},
+			values: map[string]string{
+				"ovh_application_key":    "block-app-key",
+				"ovh_application_secret": "block-app-secret",
+				"ovh_consumer_key":       "block-consumer-key",
+			},
+			expectAppKey:   "block-app-key",
+			expectConsumer: "block-consumer-key",
+		},
+		"config file completes partial credentials": {
+			env: map[string]string{
+				"OVH_APPLICATION_SECRET": "env-app-secret",
+			},
+			values: map[string]string{
+				"ovh_application_key": "block-app-key",
+			},
+			expectAppKey:   "block-app-key",
+			expectConsumer: "file-consumer-key",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, envVar := range []string{
+				"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+				"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+				"OVH_API_BASE_URL",
+			} {
+				t.Setenv(envVar, tc.env[envVar])
+			}
+
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `{"project_id": "project-123", "status": "ok"}`, nil)
+
+			values := map[string]string{
+				"ovh_endpoint":   "ovh-eu",
+				"config_file":    configFile,
+				"api_base_url":   mock.URL,
+				"ovh_project_id": "project-123",
+			}
+			for key, value := range tc.values {
+				values[key] = value
+			}
+
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, values)
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			last := mock.GetLastRequest()
+			if last == nil {
+				t.Fatal("expected the project check to reach the mock server")
+			}
+			if got := last.Header.Get("X-Ovh-Application"); got != tc.expectAppKey {
+				t.Errorf("expected application key %q, got %q", tc.expectAppKey, got)
+			}
+			if got := last.Header.Get("X-Ovh-Consumer"); got != tc.expectConsumer {
+				t.Errorf("expected consumer key %q, got %q", tc.expectConsumer, got)
+			}
+		})
+	}
+
+	t.Run("endpoint from config file", func(t *testing.T) {
+		for _, envVar := range []string{
+			"OVH_ENDPOINT", "OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+			"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+			"OVH_API_BASE_URL",
+		} {
+			t.Setenv(envVar, "")
+		}
+		// Complete credentials, so the file is only read for the endpoint
+		t.Setenv("OVH_APPLICATION_KEY", "env-app-key")
+		t.Setenv("OVH_APPLICATION_SECRET", "env-app-secret")
+		t.Setenv("OVH_CONSUMER_KEY", "env-consumer-key")
+
+		mock := NewMockHTTPServer()
+		defer mock.Close()
+
+		mock.AddResponse(200, `{"project_id": "project-123", "status": "ok"}`, nil)
+
+		// ovh_endpoint is left unset, which the schema must allow
+		p := New("test", "")()
+		req := testProviderConfigureRequest(t, p, map[string]string{
+			"config_file":    configFile,
+			"api_base_url":   mock.URL,
+			"ovh_project_id": "project-123",
+		})
+		server, err := providerserver.NewProtocol6WithError(p)()
+		if err != nil {
+			t.Fatalf("failed to create provider server: %s", err)
+		}
+		config, err := tfprotov6.NewDynamicValue(req.Config.Raw.Type(), req.Config.Raw)
+		if err != nil {
+			t.Fatalf("failed to encode provider configuration: %s", err)
+		}
+		validateResp, err := server.ValidateProviderConfig(context.Background(), &tfprotov6.ValidateProviderConfigRequest{Config: &config})
+		if err != nil {
+			t.Fatalf("failed to validate provider configuration: %s", err)
+		}
+		for _, diag := range validateResp.Diagnostics {
+			if diag.Severity == tfprotov6.DiagnosticSeverityError {
+				t.Errorf("unexpected validation error: %s: %s", diag.Summary, diag.Detail)
+			}
+		}
+
+		resp := &frameworkprovider.ConfigureResponse{}
+
+		p.Configure(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+		}
+		last := mock.GetLastRequest()
+		if last == nil {
+			t.Fatal("expected the project check to reach the mock server")
+		}
+		if got := last.Header.Get("X-Ovh-Application"); got != "env-app-key" {
+			t.Errorf("expected application key %q, got %q", "env-app-key", got)
+		}
+	})
+
+	t.Run("missing endpoint", func(t *testing.T) {
+		t.Setenv("OVH_ENDPOINT", "")
+
+		noEndpointFile := filepath.Join(t.TempDir(), "ovh.conf")
+		if err := os.WriteFile(noEndpointFile, []byte("[ovh-eu]\napplication_key=file-app-key\n"), 0o600); err != nil {
+			t.Fatalf("failed to write config file: %s", err)
+		}
+
+		p := New("test", "")()
+		req := testProviderConfigureRequest(t, p, map[string]string{
+			"config_file": noEndpointFile,
+		})
+		resp := &frameworkprovider.ConfigureResponse{}
+
+		p.Configure(context.Background(), req, resp)
+
+		found := false
+		for _, diag := range resp.Diagnostics.Errors() {
+			if diag.Summary() == "Missing OVH Endpoint Configuration" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected error %q, got %v", "Missing OVH Endpoint Configuration", resp.Diagnostics.Errors())
+		}
+	})
+
+	t.Run("missing config file", func(t *testing.T) {
+		p := New("test", "")()
+		req := testProviderConfigureRequest(t, p, map[string]string{
+			"ovh_endpoint": "ovh-eu",
+			"config_file":  filepath.Join(t.TempDir(), "missing.conf"),
+		})
+		resp := &frameworkprovider.ConfigureResponse{}
+
+		p.Configure(context.Background(), req, resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an error")
+		}
+		if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Invalid OVH Config File" {
+			t.Errorf("expected error %q, got %q", "Invalid OVH Config File", summary)
+		}
+	})
+}
+
+// TestProviderConfigureUserAgent tests that requests to the OVH API identify
+// the provider version and commit
+func TestProviderConfigureUserAgent(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+
+	cases := map[string]struct {
+		commit string
+		expect string
+	}{
+		"with commit": {
+			commit: "abc1234",
+			expect: "terraform-provider-hashicorp-ovh/1.2.3 (commit abc1234)",
+		},
+		"without commit": {
+			expect: "terraform-provider-hashicorp-ovh/1.2.3",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `{"project_id": "project-123", "status": "ok"}`, nil)
+
+			p := New("1.2.3", tc.commit)()
+			req := testProviderConfigureRequest(t, p, map[string]string{
+				"ovh_endpoint":           "ovh-eu",
+				"ovh_application_key":    "test-app-key",
+				"ovh_application_secret": "test-app-secret",
+				"ovh_consumer_key":       "test-consumer-key",
+				"api_base_url":           mock.URL,
+				"ovh_project_id":         "project-123",
+			})
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			last := mock.GetLastRequest()
+			if last == nil {
+				t.Fatal("expected the project check to reach the mock server")
+			}
+			if got := last.Header.Get("User-Agent"); !strings.HasSuffix(got, "("+tc.expect+")") {
+				t.Errorf("expected User-Agent to end with %q, got %q", "("+tc.expect+")", got)
+			}
+		})
+	}
+}
+
+// TestProviderConfigureCircuitBreakerCooldown tests that circuit_breaker_cooldown must be a positive duration
+func TestProviderConfigureCircuitBreakerCooldown(t *testing.T) {
+	cases := map[string]bool{
+		"30s":    true,
+		"2m":     true,
+		"0s":     false,
+		"-1m":    false,
+		"1 hour": false,
+	}
+
+	for cooldown, valid := range cases {
+		t.Run(cooldown, func(t *testing.T) {
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, map[string]string{
+				"ovh_endpoint":               "ovh-eu",
+				"ovh_application_key":        "test-app-key",
+				"ovh_application_secret":     "test-app-secret",
+				"ovh_consumer_key":           "test-consumer-key",
+				"circuit_breaker_cooldown":   cooldown,
+				"skip_credential_validation": "true",
+			})
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if valid && resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			if !valid {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an Invalid Circuit Breaker Cooldown error")
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Invalid Circuit Breaker Cooldown" {
+					t.Errorf("unexpected error summary: %s", summary)
+				}
+			}
+		})
+	}
+}
+
+// TestProviderConfigurePollInterval tests that poll_interval must be a duration between 5s and 5m
+func TestProviderConfigurePollInterval(t *testing.T) {
+	cases := map[string]bool{
+		"5s":  true,
+		"45s": true,
+		"5m":  true,
+		"1s":  false,
+		"10m": false,
+		"30":  false,
+	}
+
+	for interval, valid := range cases {
+		t.Run(interval, func(t *testing.T) {
+			p := New("test", "")()
+			req := testProviderConfigureRequest(t, p, map[string]string{
+				"ovh_endpoint":               "ovh-eu",
+				"ovh_application_key":        "test-app-key",
+				"ovh_application_secret":     "test-app-secret",
+				"ovh_consumer_key":           "test-consumer-key",
+				"poll_interval":              interval,
+				"skip_credential_validation": "true",
+			})
+			resp := &frameworkprovider.ConfigureResponse{}
+
+			p.Configure(context.Background(), req, resp)
+
+			if valid && resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+			if !valid {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an Invalid Poll Interval error")
+				}
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Invalid Poll Interval" {
+					t.Errorf("unexpected error summary: %s", summary)
+				}
+			}
+		})
+	}
+}
+
+// TestProviderConfigureProxy checks that the proxy attributes route OVH API
+// requests through the proxy, override the environment, and are validated
+func TestProviderConfigureProxy(t *testing.T) {
+	for _, envVar := range []string{
+		"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY",
+		"OVH_ACCESS_TOKEN", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_PROJECT_ID",
+		"OVH_API_BASE_URL",
+	} {
+		t.Setenv(envVar, "")
+	}
+	// Unreachable, so that a request following the environment fails.
+	t.Setenv("HTTP_PROXY", "http://127.0.0.1:1")
+	t.Setenv("NO_PROXY", "")
+
+	proxy := NewMockHTTPServer()
+	defer proxy.Close()
+
+	configure := func(values map[string]string) *frameworkprovider.ConfigureResponse {
+		t.Helper()
+		settings := map[string]string{
+			"ovh_endpoint":               "ovh-eu",
+			"ovh_application_key":        "test-app-key",
+			"ovh_application_secret":     "test-app-secret",
+			"ovh_consumer_key":           "test-consumer-key",
+			"api_base_url":               "http://api.ovh.invalid/1.0",
+			"skip_credential_validation": "true",
+		}
+		for key, value := range values {
+			settings[key] = value
+		}
+		p := New("test", "")()
+		resp := &frameworkprovider.ConfigureResponse{}
+		p.Configure(context.Background(), testProviderConfigureRequest(t, p, settings), resp)
+		return resp
+	}
+
+	resp := configure(map[string]string{"http_proxy": proxy.URL})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+	}
+	config := resp.ResourceData.(*Config)
+	var cluster map[string]interface{}
+	if err := config.OVHClient.Get("/cloud/project/vault/cluster/vault-123", &cluster); err != nil {
+		t.Fatalf("expected the request to go through the proxy, got %v", err)
+	}
+	if r := proxy.GetLastRequest(); r.Host != "api.ovh.invalid" || r.URL.Path != "/1.0/cloud/project/vault/cluster/vault-123" {
+		t.Errorf("expected the proxy to be asked for the OVH API, got %s %s", r.Host, r.URL.Path)
+	}
+
+	resp = configure(map[string]string{"http_proxy": proxy.URL, "no_proxy": "api.ovh.invalid"})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+	}
+	requests := proxy.GetRequestCount()
+	config = resp.ResourceData.(*Config)
+	if err := config.OVHClient.Get("/cloud/project/vault/cluster/vault-123", &cluster); err == nil {
+		t.Error("expected a request bypassing the proxy to fail to reach the invalid host")
+	}
+	if got := proxy.GetRequestCount(); got != requests {
+		t.Errorf("expected no_proxy to bypass the proxy, got %d requests", got-requests)
+	}
+
+	for name, value := range map[string]string{
+		"no scheme":   "proxy.example.com:3128",
+		"bad scheme":  "ftp://proxy.example.com",
+		"no host":     "http://",
+		"unparseable": "http://proxy example.com",
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := configure(map[string]string{"https_proxy": value})
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected an Invalid Proxy URL error")
+			}
+			if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Invalid Proxy URL" {
+				t.Errorf("unexpected error summary: %s", summary)
+			}
+		})
+	}
+
+	// OAuth2 tokens would not go through the proxy of the attributes.
+	p := New("test", "")()
+	resp = &frameworkprovider.ConfigureResponse{}
+	p.Configure(context.Background(), testProviderConfigureRequest(t, p, map[string]string{
+		"ovh_endpoint":               "ovh-eu",
+		"ovh_client_id":              "test-client-id",
+		"ovh_client_secret":          "test-client-secret",
+		"https_proxy":                proxy.URL,
+		"skip_credential_validation": "true",
+	}), resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an Unsupported Proxy Configuration error")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Unsupported Proxy Configuration" {
+		t.Errorf("unexpected error summary: %s", summary)
+	}
+}
+
+func TestConfigPollInterval(t *testing.T) {
+	if got := (&Config{}).pollInterval(30 * time.Second); got != 30*time.Second {
+		t.Errorf("expected the default interval when poll_interval is unset, got %s", got)
+	}
+	if got := (&Config{PollInterval: 10 * time.Second}).pollInterval(30 * time.Second); got != 10*time.Second {
+		t.Errorf("expected the configured interval, got %s", got)
+	}
+}
+
+// TestConfigInstanceType tests the fallback to default_instance_type
+func TestConfigInstanceType(t *testing.T) {
+	config := &Config{DefaultInstanceType: "b2-7"}
+	if got, err := config.instanceType("c2-15"); err != nil || got != "c2-15" {
+		t.Errorf("expected the resource instance type to win, got %q, %v", got, err)
+	}
+	if got, err := config.instanceType(""); err != nil || got != "b2-7" {
+		t.Errorf("expected the default instance type, got %q, %v", got, err)
+	}
+	if _, err := (&Config{}).instanceType(""); err == nil {
+		t.Error("expected an error without instance_type or default_instance_type")
+	}
+}
+
+// TestConfigProjectID tests the fallback to ovh_project_id and validation of other projects
+func TestConfigProjectID(t *testing.T) {
+	config := &Config{ProjectID: "abc123"}
+	if got, err := config.projectID(""); err != nil || got != "abc123" {
+		t.Errorf("expected the provider project, got %q, %v", got, err)
+	}
+	if got, err := config.projectID("abc123"); err != nil || got != "abc123" {
+		t.Errorf("expected the provider project without a check, got %q, %v", got, err)
+	}
+
+	cases := map[string]struct {
+		statusCode int
+		body       string
+		target     error
+	}{
+		"existing project":  {statusCode: 200, body: `{"project_id": "def456", "status": "ok"}`},
+		"unknown project":   {statusCode: 404, body: `{"class": "Client::NotFound", "message": "This service does not exist"}`, target: errUnknownProject},
+		"suspended project": {statusCode: 200, body: `{"project_id": "def456", "status": "suspended"}`, target: errProjectSuspended},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.statusCode, tc.body, nil)
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "abc123"
+
+			got, err := config.projectID("def456")
+			if tc.target == nil {
+				if err != nil || got != "def456" {
+					t.Errorf("expected the resource project, got %q, %v", got, err)
+				}
+			} else if !errors.Is(err, tc.target) {
+				t.Errorf("expected %v, got %v", tc.target, err)
+			}
+			if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/def456" {
+				t.Errorf("unexpected request path %s", got)
+			}
+		})
+	}
+}
+
+// TestConfigCheckFlavor tests validation of default_instance_type against the project flavors
+func TestConfigCheckFlavor(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		body       string
+		unknown    bool
+		expectErr  bool
+	}{
+		"offered flavor":  {statusCode: 200, body: `[{"name": "b2-7", "region": "GRA11"}, {"name": "c2-15", "region": "GRA11"}]`},
+		"unknown flavor":  {statusCode: 200, body: `[{"name": "b2-7", "region": "GRA11"}]`, unknown: true, expectErr: true},
+		"unlisted flavor": {statusCode: 403, body: `{"class": "Client::Forbidden", "message": "This call has not been granted"}`, expectErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.statusCode, tc.body, nil)
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "abc123"
+
+			err := config.checkFlavor("c2-15")
+			if tc.expectErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := errors.Is(err, errUnknownFlavor); got != tc.unknown {
+				t.Errorf("expected unknown=%t, got error: %v", tc.unknown, err)
+			}
+			if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/abc123/flavor" {
+				t.Errorf("unexpected request path %s", got)
+			}
+		})
+	}
+}
+
+// TestConfigCheckProject tests detection of suspended projects and caching of a successful check
+func TestConfigCheckProject(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		body       string
+		suspended  bool
+		expectErr  bool
+	}{
+		"active project":     {statusCode: 200, body: `{"project_id": "abc123", "status": "ok"}`},
+		"suspended project":  {statusCode: 200, body: `{"project_id": "abc123", "status": "suspended"}`, suspended: true, expectErr: true},
+		"expired service":    {statusCode: 460, body: `{"class": "Client::ServiceExpired", "message": "This service is expired"}`, suspended: true, expectErr: true},
+		"unreadable project": {statusCode: 403, body: `{"class": "Client::Forbidden", "message": "This call has not been granted"}`, expectErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.statusCode, tc.body, nil)
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "abc123"
+
+			err := config.checkProject()
+			if tc.expectErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := errors.Is(err, errProjectSuspended); got != tc.suspended {
+				t.Errorf("expected suspended=%t, got error: %v", tc.suspended, err)
+			}
+		})
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		mock := NewMockHTTPServer()
+		defer mock.Close()
+
+		mock.AddResponse(200, `{"project_id": "abc123", "status": "ok"}`, nil)
+
+		config := mock.NewConfig(t)
+		config.ProjectID = "abc123"
+
+		for i := 0; i < 3; i++ {
+			if err := config.checkProject(); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+		if got := mock.GetRequestCount(); got != 1 {
+			t.Errorf("expected the project to be checked once, got %d requests", got)
+		}
+	})
+}
+
+// TestConfigConcurrentUse runs several resource operations in parallel through
+// one Config, as Terraform does during apply. Run with -race to detect data races.
+func TestConfigConcurrentUse(t *testing.T) {
+	const workers = 8
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	for i := 0; i < workers*8; i++ {
+		mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "ok", "value": "v"}`, nil)
+	}
+
+	config := mock.NewConfig(t)
+	config.ProjectID = "abc123"
+
+	var wg sync.WaitGroup
+	errs := make(chan string, workers*3)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if err := config.checkProject(); err != nil {
+				errs <- err.Error()
+			}
+
+			cluster := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, testVaultClusterRawConfig())
+			if diags := resourceVaultClusterCreate(context.Background(), cluster, config); diags.HasError() {
+				errs <- fmt.Sprintf("vault cluster %d: %v", i, diags)
+			}
+
+			key := schema.TestResourceDataRaw(t, resourceConsulKV().Schema, map[string]interface{}{
+				"cluster_id": "consul-123",
+				"path":       fmt.Sprintf("config/key-%d", i),
+				"value":      "v",
+			})
+			if diags := resourceConsulKVCreate(context.Background(), key, config); diags.HasError() {
+				errs <- fmt.Sprintf("consul key %d: %v", i, diags)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestConfigConcurrentRequests checks that API calls made in parallel
+// through one Config are in flight together, so that a slow call does not
+// hold back the others
+func TestConfigConcurrentRequests(t *testing.T) {
+	const calls = 4
+	const delay = 300 * time.Millisecond
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	for i := 0; i < calls; i++ {
+		mock.AddDelayedResponse(200, `{"id": "vault-123", "status": "READY"}`, delay)
+	}
+
+	config := mock.NewConfig(t)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var cluster map[string]interface{}
+			if err := config.OVHClient.Get("/cloud/project/vault/cluster/vault-123", &cluster); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed >= calls*delay {
+		t.Errorf("expected the calls to run concurrently, took %s", elapsed)
+	}
+}
+
 // TestProviderResources tests that resources are properly registered
 func TestProviderResources(t *testing.T) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	resources := provider.Resources(context.Background())
 	
@@ -189,7 +1549,7 @@ func TestProviderResources(t *testing.T) {
 
 // TestProviderDataSources tests that data sources are properly registered
 func TestProviderDataSources(t *testing.T) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	dataSources := provider.DataSources(context.Background())
 	
@@ -203,7 +1563,7 @@ func TestProviderDataSources(t *testing.T) {
 
 // TestProviderConcurrentAccess tests thread safety
 func TestProviderConcurrentAccess(t *testing.T) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	// Test concurrent metadata requests
 	done := make(chan bool, 10)
@@ -239,7 +1599,7 @@ func TestProviderConcurrentAccess(t *testing.T) {
 // TestProviderEnvironmentVariables tests environment variable handling
 func TestProviderEnvironmentVariables(t *testing.T) {
 	// Test environment variable reading capability
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	if provider == nil {
 		t.Error("Provider should initialize regardless of environment variables")
@@ -315,7 +1675,7 @@ provider "hashicorp-ovh" {
 // BenchmarkProviderInitialization benchmarks provider creation
 func BenchmarkProviderInitialization(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		provider := New("test")()
+		provider := New("test", "")()
 		if provider == nil {
 			b.Fatal("Provider initialization failed")
 		}
@@ -324,7 +1684,7 @@ func BenchmarkProviderInitialization(b *testing.B) {
 
 // BenchmarkProviderMetadata benchmarks metadata retrieval
 func BenchmarkProviderMetadata(b *testing.B) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	for i := 0; i < b.N; i++ {
 		req := frameworkprovider.MetadataRequest{}
@@ -340,7 +1700,7 @@ func BenchmarkProviderMetadata(b *testing.B) {
 
 // BenchmarkProviderSchema benchmarks schema retrieval
 func BenchmarkProviderSchema(b *testing.B) {
-	provider := New("test")()
+	provider := New("test", "")()
 	
 	for i := 0; i < b.N; i++ {
 		req := frameworkprovider.SchemaRequest{}
@@ -359,7 +1719,7 @@ func BenchmarkProviderConfigure(b *testing.B) {
 	// Skip benchmarking Configure method as it requires complex setup
 	// Benchmark provider initialization instead
 	for i := 0; i < b.N; i++ {
-		provider := New("test")()
+		provider := New("test", "")()
 		
 		if provider == nil {
 			b.Fatal("Provider initialization failed")
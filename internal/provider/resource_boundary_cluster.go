@@ -3,31 +3,49 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// boundaryClusterUpdateKeys are the attributes resourceBoundaryClusterUpdate
+// sends to the cluster besides tags, which it can update on their own.
+var boundaryClusterUpdateKeys = []string{
+	"controller_count", "worker_count", "session_recording_config",
+	"security_groups", "ui_allowed_cidrs", "custom_domain", "maintenance_window",
+	"web3", "web3_targets", "instance_tags",
+}
+
 func resourceBoundaryCluster() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages a Boundary cluster on OVH infrastructure for secure access management",
 
 		CreateContext: resourceBoundaryClusterCreate,
 		ReadContext:   resourceBoundaryClusterRead,
-		UpdateContext: resourceBoundaryClusterUpdate,
+		UpdateContext: keepStateOnDryRun(resourceBoundaryClusterUpdate),
 		DeleteContext: resourceBoundaryClusterDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: resourceBoundaryClusterCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("/cloud/project/boundary/cluster", "Boundary cluster"),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the Boundary cluster",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Boundary cluster",
+				ValidateFunc: validateClusterName,
 			},
 			"region": {
 				Type:        schema.TypeString,
@@ -35,28 +53,31 @@ func resourceBoundaryCluster() *schema.Resource {
 				ForceNew:    true,
 				Description: "OVH region for the cluster",
 			},
+			"project_id": projectIdSchema(),
 			"controller_count": {
 				Type:         schema.TypeInt,
 				Required:     true,
 				Description:  "Number of Boundary controller nodes",
-				ValidateFunc: validation.IntBetween(1, 5),
+				ValidateFunc: validateIntBetween(1, 5),
 			},
 			"worker_count": {
 				Type:         schema.TypeInt,
 				Required:     true,
 				Description:  "Number of Boundary worker nodes",
-				ValidateFunc: validation.IntBetween(1, 20),
+				ValidateFunc: validateIntBetween(1, 20),
 			},
 			"instance_type": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "OVH instance type for Boundary nodes",
+				Optional:    true,
+				Computed:    true,
+				Description: "OVH instance type for Boundary nodes, defaults to the provider default_instance_type",
 			},
 			"database_type": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "postgresql",
-				Description: "Database backend type",
+				Description: "Database backend type. Changing it replaces the cluster, losing its data unless a snapshot is restored",
 				ValidateFunc: validation.StringInSlice([]string{
 					"postgresql", "mysql",
 				}, false),
@@ -85,6 +106,35 @@ func resourceBoundaryCluster() *schema.Resource {
 				Default:     true,
 				Description: "Enable session recording",
 			},
+			"session_recording_config": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Object storage for session recordings, requires session_recording",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_bucket": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of the OVH Object Storage bucket recordings are written to",
+							ValidateFunc: validation.StringMatch(bucketNamePattern, "must be 3 to 63 lowercase letters, digits, dots or hyphens, starting and ending with a letter or digit"),
+						},
+						"region": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "OVH region of the bucket",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"retention_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							Description:  "Number of days recordings are kept before being deleted",
+							ValidateFunc: validateIntBetween(1, 3650),
+						},
+					},
+				},
+			},
 			"multi_hop_sessions": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -92,19 +142,46 @@ func resourceBoundaryCluster() *schema.Resource {
 				Description: "Enable multi-hop sessions",
 			},
 			"web3_targets": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Enable Web3 target management",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable Web3 target management",
+				Deprecated:    "Use the web3 block instead",
+				ConflictsWith: []string{"web3"},
 			},
-			"tags": {
-				Type:        schema.TypeMap,
+			"web3": web3Schema("web3_targets"),
+			"security_groups": {
+				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "Tags to apply to cluster resources",
+				Description: "Security group IDs or names to attach to cluster nodes",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"ui_allowed_cidrs":      uiAllowedCidrsSchema(),
+			"custom_domain":         customDomainSchema(),
+			"custom_endpoint":       customEndpointSchema(),
+			"connection_info":       connectionInfoSchema(),
+			"user_data":             userDataSchema(),
+			"additional_volumes":    additionalVolumesSchema(),
+			"maintenance_window":    maintenanceWindowSchema(),
+			"planned_action":        plannedActionSchema(),
+			"recreate_if_unhealthy": recreateIfUnhealthySchema(),
+			"error_on_existing":     errorOnExistingSchema(),
+			"replacement_strategy":  replacementStrategySchema(),
+			"previous_cluster_id":   previousClusterIdSchema(),
+			"previous_endpoint":     previousEndpointSchema(),
+			"ready":                 readySchema(),
+			"ready_at":              readyAtSchema(),
+			"wait_for_ready":        waitForReadyOnCreateSchema(),
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable deletion protection and detach dependent resources before deleting the cluster",
+			},
+			"tags":          tagsSchema(),
+			"instance_tags": instanceTagsSchema(),
 			"controller_endpoints": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -123,6 +200,24 @@ func resourceBoundaryCluster() *schema.Resource {
 				Computed:    true,
 				Description: "Default auth method ID",
 			},
+			"recording_storage_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Boundary storage bucket holding session recordings, set when session_recording_config is",
+			},
+			"default_security_group_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the security group created by OVH for the cluster",
+			},
+			"nodes":                           clusterNodesSchema(),
+			"user_data_hash":                  userDataHashSchema(),
+			"volume_ids":                      volumeIdsSchema(),
+			"last_operation_id":               lastOperationIdSchema(),
+			"next_maintenance_at":             nextMaintenanceAtSchema(),
+			"estimated_monthly_cost":          estimatedMonthlyCostSchema(),
+			"estimated_monthly_cost_currency": estimatedMonthlyCostCurrencySchema(),
+			"config_json":                     configJsonSchema(),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -136,32 +231,79 @@ func resourceBoundaryClusterCreate(ctx context.Context, d *schema.ResourceData,
 	config := meta.(*Config)
 	_ = diag.Diagnostics{}
 
+	instanceType, err := config.instanceType(d.Get("instance_type").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := config.projectID(d.Get("project_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	clusterConfig := map[string]interface{}{
-		"name":             d.Get("name").(string),
-		"region":           d.Get("region").(string),
-		"controllerCount":  d.Get("controller_count").(int),
-		"workerCount":      d.Get("worker_count").(int),
-		"instanceType":     d.Get("instance_type").(string),
-		"databaseType":     d.Get("database_type").(string),
-		"vaultIntegration": d.Get("vault_integration").(bool),
-		"ldapAuth":         d.Get("ldap_auth").(bool),
-		"oidcAuth":         d.Get("oidc_auth").(bool),
-		"sessionRecording": d.Get("session_recording").(bool),
-		"multiHopSessions": d.Get("multi_hop_sessions").(bool),
-		"web3Targets":      d.Get("web3_targets").(bool),
-		"tags":             d.Get("tags"),
-	}
-
-	var result map[string]interface{}
-	err := config.OVHClient.Post("/cloud/project/boundary/cluster", clusterConfig, &result)
+		"name":              d.Get("name").(string),
+		"region":            d.Get("region").(string),
+		"controllerCount":   d.Get("controller_count").(int),
+		"workerCount":       d.Get("worker_count").(int),
+		"instanceType":      instanceType,
+		"databaseType":      d.Get("database_type").(string),
+		"vaultIntegration":  d.Get("vault_integration").(bool),
+		"ldapAuth":          d.Get("ldap_auth").(bool),
+		"oidcAuth":          d.Get("oidc_auth").(bool),
+		"sessionRecording":  d.Get("session_recording").(bool),
+		"multiHopSessions":  d.Get("multi_hop_sessions").(bool),
+		"securityGroups":    d.Get("security_groups").(*schema.Set).List(),
+		"uiAllowedCidrs":    d.Get("ui_allowed_cidrs"),
+		"customDomain":      d.Get("custom_domain").(string),
+		"additionalVolumes": expandAdditionalVolumes(d.Get("additional_volumes").([]interface{})),
+		"tags":              d.Get("tags"),
+		"instanceTags":      d.Get("instance_tags"),
+	}
+	if projectId != "" {
+		clusterConfig["projectId"] = projectId
+	}
+
+	addLegacyBlock(clusterConfig, "web3", "web3Targets", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_targets").(bool)), false)
+
+	if recording := expandSessionRecordingConfig(d.Get("session_recording_config").([]interface{})); recording != nil {
+		clusterConfig["sessionRecordingConfig"] = recording
+	}
+
+	if window := expandMaintenanceWindow(d.Get("maintenance_window").([]interface{})); window != nil {
+		clusterConfig["maintenanceWindow"] = window
+	}
+
+	setUserData(d, clusterConfig)
+
+	openUI := len(d.Get("ui_allowed_cidrs").([]interface{})) == 0
+
+	setPreviousCluster(ctx, config, d, "boundary", projectId)
+	if diags := checkExistingCluster(ctx, config, d, "boundary", "Boundary", projectId); diags.HasError() {
+		return diags
+	}
+	if diags := checkInstanceQuota(ctx, config, d, "Boundary", projectId, "controller_count", "worker_count"); diags.HasError() {
+		return diags
+	}
+
+	result, err := createCluster(ctx, config, d, "boundary", projectId, clusterConfig)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create Boundary cluster: %w", err))
+		return createClusterError("Boundary", projectId, err)
 	}
 
-	clusterId := result["id"].(string)
+	clusterId, err := createdClusterId("Boundary", result)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId(clusterId)
+	setConfigJson(d, clusterConfig)
+	setLastOperationId(d, result)
 
-	return resourceBoundaryClusterRead(ctx, d, meta)
+	diags := waitForReadyAfterCreate(ctx, config, d, "Boundary", "boundary")
+	diags = append(diags, readAfterCreate(ctx, d, meta, "Boundary", resourceBoundaryClusterRead)...)
+	if openUI {
+		diags = append(diags, openUIWarning("Boundary"))
+	}
+	return diags
 }
 
 func resourceBoundaryClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -176,27 +318,47 @@ func resourceBoundaryClusterRead(ctx context.Context, d *schema.ResourceData, me
 		d.SetId("")
 		return diag.FromErr(fmt.Errorf("failed to read Boundary cluster: %w", err))
 	}
+	if err := completeClusterNodes(config, "boundary", clusterId, cluster); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read nodes of Boundary cluster: %w", err))
+	}
 
-	d.Set("name", cluster["name"])
-	d.Set("region", cluster["region"])
-	d.Set("controller_count", cluster["controllerCount"])
-	d.Set("worker_count", cluster["workerCount"])
-	d.Set("instance_type", cluster["instanceType"])
-	d.Set("database_type", cluster["databaseType"])
-	d.Set("vault_integration", cluster["vaultIntegration"])
-	d.Set("ldap_auth", cluster["ldapAuth"])
-	d.Set("oidc_auth", cluster["oidcAuth"])
-	d.Set("session_recording", cluster["sessionRecording"])
-	d.Set("multi_hop_sessions", cluster["multiHopSessions"])
-	d.Set("web3_targets", cluster["web3Targets"])
-	d.Set("controller_endpoints", cluster["controllerEndpoints"])
-	d.Set("ui_url", cluster["uiUrl"])
-	d.Set("auth_method_id", cluster["authMethodId"])
-	d.Set("status", cluster["status"])
-
-	if tags, ok := cluster["tags"].(map[string]interface{}); ok {
-		d.Set("tags", tags)
+	d.Set("name", getString(cluster, "name"))
+	d.Set("region", getString(cluster, "region"))
+	d.Set("controller_count", getInt(cluster, "controllerCount"))
+	d.Set("worker_count", getInt(cluster, "workerCount"))
+	d.Set("instance_type", getString(cluster, "instanceType"))
+	setProjectID(d, cluster)
+	d.Set("database_type", getString(cluster, "databaseType"))
+	d.Set("vault_integration", getBool(cluster, "vaultIntegration"))
+	d.Set("ldap_auth", getBool(cluster, "ldapAuth"))
+	d.Set("oidc_auth", getBool(cluster, "oidcAuth"))
+	d.Set("session_recording", getBool(cluster, "sessionRecording"))
+	d.Set("multi_hop_sessions", getBool(cluster, "multiHopSessions"))
+	d.Set("web3_targets", getBool(cluster, "web3Targets"))
+	setLegacyBlock(d, "web3", "web3_targets", cluster, flattenWeb3)
+	d.Set("controller_endpoints", getStringList(cluster, "controllerEndpoints"))
+	d.Set("ui_url", getString(cluster, "uiUrl"))
+	d.Set("auth_method_id", getString(cluster, "authMethodId"))
+	d.Set("recording_storage_id", getString(cluster, "recordingStorageId"))
+	if recording, ok := cluster["sessionRecordingConfig"].(map[string]interface{}); ok {
+		d.Set("session_recording_config", flattenSessionRecordingConfig(recording))
 	}
+	d.Set("status", getString(cluster, "status"))
+	setClusterReadiness(d, "boundary", cluster)
+	d.Set("nodes", flattenClusterNodes(cluster))
+	setAdditionalVolumes(d, cluster)
+	setMaintenanceWindow(d, cluster)
+	setEstimatedMonthlyCost(d, config, "controller_count", "worker_count")
+	d.Set("security_groups", getStringList(cluster, "securityGroups"))
+	d.Set("ui_allowed_cidrs", getStringList(cluster, "uiAllowedCidrs"))
+	d.Set("custom_domain", getString(cluster, "customDomain"))
+	d.Set("custom_endpoint", getString(cluster, "customEndpoint"))
+	setConnectionInfo(d, "boundary", cluster)
+	refreshPreviousCluster(config, d, "boundary")
+	d.Set("default_security_group_id", getString(cluster, "defaultSecurityGroupId"))
+
+	d.Set("tags", flattenTags(cluster))
+	d.Set("instance_tags", flattenInstanceTags(cluster))
 
 	return nil
 }
@@ -207,7 +369,15 @@ func resourceBoundaryClusterUpdate(ctx context.Context, d *schema.ResourceData,
 
 	clusterId := d.Id()
 
-	if d.HasChanges("controller_count", "worker_count", "tags") {
+	if hasOnlyTagChanges(d, boundaryClusterUpdateKeys...) {
+		if err := updateClusterTags(ctx, config, "boundary", clusterId, d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Boundary cluster tags: %w", err))
+		}
+		mergeConfigJson(d, map[string]interface{}{"tags": d.Get("tags")})
+		return resourceBoundaryClusterRead(ctx, d, meta)
+	}
+
+	if d.HasChange("tags") || d.HasChanges(boundaryClusterUpdateKeys...) {
 		updateConfig := map[string]interface{}{}
 
 		if d.HasChange("controller_count") {
@@ -216,14 +386,51 @@ func resourceBoundaryClusterUpdate(ctx context.Context, d *schema.ResourceData,
 		if d.HasChange("worker_count") {
 			updateConfig["workerCount"] = d.Get("worker_count").(int)
 		}
+		if d.HasChange("session_recording_config") {
+			recording := expandSessionRecordingConfig(d.Get("session_recording_config").([]interface{}))
+			if recording == nil {
+				recording = map[string]interface{}{}
+			}
+			updateConfig["sessionRecordingConfig"] = recording
+		}
+		if d.HasChange("security_groups") {
+			updateConfig["securityGroups"] = d.Get("security_groups").(*schema.Set).List()
+		}
+		if d.HasChange("ui_allowed_cidrs") {
+			updateConfig["uiAllowedCidrs"] = d.Get("ui_allowed_cidrs")
+		}
+		if d.HasChange("custom_domain") {
+			updateConfig["customDomain"] = d.Get("custom_domain").(string)
+		}
+		if d.HasChange("maintenance_window") {
+			updateConfig["maintenanceWindow"] = expandMaintenanceWindow(d.Get("maintenance_window").([]interface{}))
+		}
+		if d.HasChanges("web3", "web3_targets") {
+			addLegacyBlock(updateConfig, "web3", "web3Targets", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_targets").(bool)), true)
+		}
 		if d.HasChange("tags") {
 			updateConfig["tags"] = d.Get("tags")
 		}
+		if d.HasChange("instance_tags") {
+			updateConfig["instanceTags"] = d.Get("instance_tags")
+		}
 
-		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/boundary/cluster/%s", clusterId), updateConfig, nil)
+		result, err := updateCluster(ctx, config, "boundary", clusterId, updateConfig, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("failed to update Boundary cluster: %w", err))
 		}
+		operationId := setLastOperationId(d, result)
+		mergeConfigJson(d, updateConfig)
+
+		if d.HasChange("tags") {
+			if err := deleteRemovedTags(config, fmt.Sprintf("/cloud/project/boundary/cluster/%s", clusterId), d); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update Boundary cluster: %w", err))
+			}
+		}
+
+		if err := waitForClusterOperation(ctx, config, "boundary", clusterId, operationId); err != nil {
+			return diag.FromErr(fmt.Errorf("cluster update failed: %w", err))
+		}
 	}
 
 	return resourceBoundaryClusterRead(ctx, d, meta)
@@ -235,7 +442,7 @@ func resourceBoundaryClusterDelete(ctx context.Context, d *schema.ResourceData,
 
 	clusterId := d.Id()
 
-	err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/boundary/cluster/%s", clusterId), nil)
+	err := deleteCluster(ctx, config, "boundary", clusterId, d.Get("force_destroy").(bool), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete Boundary cluster: %w", err))
 	}
@@ -243,3 +450,74 @@ func resourceBoundaryClusterDelete(ctx context.Context, d *schema.ResourceData,
 	d.SetId("")
 	return nil
 }
+
+func resourceBoundaryClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := planRecreateIfUnhealthy(d); err != nil {
+		return err
+	}
+
+	if err := planBlueGreenReplacement(d); err != nil {
+		return err
+	}
+
+	if err := planConfigJson(d, boundaryClusterUpdateKeys...); err != nil {
+		return err
+	}
+
+	if err := validateAdditionalVolumes(d); err != nil {
+		return err
+	}
+
+	if d.Get("multi_hop_sessions").(bool) && d.Get("worker_count").(int) < 2 {
+		return fmt.Errorf("multi_hop_sessions requires worker_count to be at least 2, got %d", d.Get("worker_count").(int))
+	}
+
+	if len(d.Get("session_recording_config").([]interface{})) > 0 && !d.Get("session_recording").(bool) {
+		return fmt.Errorf("session_recording_config block can only be set when session_recording is true")
+	}
+
+	if err := planNodeCountChange(ctx, d, "Boundary", "", "controller_count", "worker_count"); err != nil {
+		return err
+	}
+
+	if err := planDataLossOnReplace(d, "database_type"); err != nil {
+		return err
+	}
+
+	if err := planInstanceQuota(ctx, d, meta, "Boundary", "controller_count", "worker_count"); err != nil {
+		return err
+	}
+
+	return planEstimatedMonthlyCost(d, meta, "controller_count", "worker_count")
+}
+
+// bucketNamePattern matches OVH Object Storage S3 bucket names.
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func expandSessionRecordingConfig(l []interface{}) map[string]interface{} {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	raw := l[0].(map[string]interface{})
+	return map[string]interface{}{
+		"storageBucket": raw["storage_bucket"].(string),
+		"region":        raw["region"].(string),
+		"retentionDays": raw["retention_days"].(int),
+	}
+}
+
+func flattenSessionRecordingConfig(recording map[string]interface{}) []interface{} {
+	bucket := getString(recording, "storageBucket")
+	if bucket == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"storage_bucket": bucket,
+			"region":         getString(recording, "region"),
+			"retention_days": getInt(recording, "retentionDays"),
+		},
+	}
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testBoundaryClusterRawConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":             "test-boundary",
+		"region":           "GRA",
+		"controller_count": 1,
+		"worker_count":     2,
+		"instance_type":    "c2-15",
+	}
+}
+
+// TestBoundaryCluster_multiHopWorkerCount checks that multi-hop sessions are
+// rejected at plan time without at least 2 workers
+func TestBoundaryCluster_multiHopWorkerCount(t *testing.T) {
+	cases := map[string]struct {
+		workerCount int
+		multiHop    bool
+		expectError bool
+	}{
+		"multi-hop with 2 workers":    {workerCount: 2, multiHop: true},
+		"multi-hop with 1 worker":     {workerCount: 1, multiHop: true, expectError: true},
+		"no multi-hop with 1 worker":  {workerCount: 1, multiHop: false},
+		"multi-hop with many workers": {workerCount: 10, multiHop: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testBoundaryClusterRawConfig()
+			raw["worker_count"] = tc.workerCount
+			raw["multi_hop_sessions"] = tc.multiHop
+
+			_, err := resourceBoundaryCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func testSessionRecordingConfig(retentionDays int) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"storage_bucket": "boundary-recordings",
+			"region":         "GRA",
+			"retention_days": retentionDays,
+		},
+	}
+}
+
+// TestBoundaryCluster_sessionRecordingConfig checks that the recording storage
+// is rejected at plan time unless session recording is enabled
+func TestBoundaryCluster_sessionRecordingConfig(t *testing.T) {
+	cases := map[string]struct {
+		sessionRecording bool
+		expectError      bool
+	}{
+		"recording enabled":  {sessionRecording: true},
+		"recording disabled": {sessionRecording: false, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testBoundaryClusterRawConfig()
+			raw["session_recording"] = tc.sessionRecording
+			raw["session_recording_config"] = testSessionRecordingConfig(90)
+
+			_, err := resourceBoundaryCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestBoundaryCluster_recordingRetentionBounds(t *testing.T) {
+	cases := map[string]struct {
+		retentionDays int
+		expectError   bool
+	}{
+		"one day":   {retentionDays: 1},
+		"ten years": {retentionDays: 3650},
+		"zero":      {retentionDays: 0, expectError: true},
+		"too long":  {retentionDays: 3651, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testBoundaryClusterRawConfig()
+			raw["session_recording_config"] = testSessionRecordingConfig(tc.retentionDays)
+
+			diags := resourceBoundaryCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if tc.expectError {
+				if !diags.HasError() {
+					t.Fatal("expected an error")
+				}
+				if !regexp.MustCompile("retention_days must be between 1 and 3650").MatchString(diags[0].Summary) {
+					t.Errorf("unexpected error: %s", diags[0].Summary)
+				}
+			}
+			if !tc.expectError && diags.HasError() {
+				t.Errorf("unexpected error: %v", diags)
+			}
+		})
+	}
+}
+
+func TestBoundaryClusterCreate_sessionRecordingConfig(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "boundary-123"}`, nil)
+	mock.AddResponse(200, `{
+  "id": "boundary-123",
+  "status": "READY",
+  "sessionRecording": true,
+  "sessionRecordingConfig": {"storageBucket": "boundary-recordings", "region": "GRA", "retentionDays": 90},
+  "recordingStorageId": "sb_1234567890"
+}`, nil)
+
+	raw := testBoundaryClusterRawConfig()
+	raw["session_recording_config"] = testSessionRecordingConfig(90)
+	raw["ui_allowed_cidrs"] = []interface{}{"203.0.113.0/24"}
+	d := schema.TestResourceDataRaw(t, resourceBoundaryCluster().Schema, raw)
+
+	if diags := resourceBoundaryClusterCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[1]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	expected := map[string]interface{}{"storageBucket": "boundary-recordings", "region": "GRA", "retentionDays": float64(90)}
+	if !reflect.DeepEqual(body["sessionRecordingConfig"], expected) {
+		t.Errorf("expected sessionRecordingConfig %v, got %v", expected, body["sessionRecordingConfig"])
+	}
+
+	if got := d.Get("recording_storage_id").(string); got != "sb_1234567890" {
+		t.Errorf("expected recording_storage_id sb_1234567890, got %q", got)
+	}
+	if got := d.Get("session_recording_config.0.retention_days").(int); got != 90 {
+		t.Errorf("expected retention_days 90, got %d", got)
+	}
+}
+
+// TestBoundaryCluster_databaseTypeForceNew checks that changing the database
+// backend replaces the cluster, as Boundary cannot migrate it in place, and
+// shows in planned_action that its data is lost
+func TestBoundaryCluster_databaseTypeForceNew(t *testing.T) {
+	state := &sdkterraform.InstanceState{
+		ID: "boundary-123",
+		Attributes: map[string]string{
+			"id":               "boundary-123",
+			"name":             "test-boundary",
+			"region":           "GRA",
+			"controller_count": "1",
+			"worker_count":     "2",
+			"instance_type":    "c2-15",
+			"database_type":    "postgresql",
+		},
+	}
+	raw := testBoundaryClusterRawConfig()
+	raw["database_type"] = "mysql"
+
+	// Terraform sends the prior state along, as the replacement is planned
+	// without it.
+	rawState, err := state.AttrsAsObjectValue(resourceBoundaryCluster().CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("unexpected state error: %s", err)
+	}
+	state.RawState = rawState
+
+	diff, err := resourceBoundaryCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected a database_type change to replace the cluster")
+	}
+	if planned := diff.Attributes["planned_action"]; planned == nil || !strings.Contains(planned.New, "database_type postgresql -> mysql: replaces the cluster, which loses its data") {
+		t.Errorf("expected planned_action to show the data loss, got %v", planned)
+	}
+}
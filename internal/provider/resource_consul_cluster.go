@@ -3,60 +3,89 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// consulClusterUpdateKeys are the attributes resourceConsulClusterUpdate sends
+// to the cluster besides tags, which it can update on their own.
+var consulClusterUpdateKeys = []string{
+	"server_count", "client_count", "region_distribution", "monitoring",
+	"security_groups", "ui_allowed_cidrs", "custom_domain", "maintenance_window",
+	"auto_encrypt", "web3", "web3_services", "instance_tags",
+}
+
 func resourceConsulCluster() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages a Consul cluster on OVH infrastructure with service mesh capabilities",
 
 		CreateContext: resourceConsulClusterCreate,
 		ReadContext:   resourceConsulClusterRead,
-		UpdateContext: resourceConsulClusterUpdate,
+		UpdateContext: keepStateOnDryRun(resourceConsulClusterUpdate),
 		DeleteContext: resourceConsulClusterDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: resourceConsulClusterCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("/cloud/project/consul/cluster", "Consul cluster"),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the Consul cluster",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Consul cluster",
+				ValidateFunc: validateClusterName,
 			},
 			"region": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "OVH region for the cluster",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "OVH region for the cluster, the primary region when region_distribution is set",
+				ExactlyOneOf: []string{"region", "region_distribution"},
 			},
+			"project_id": projectIdSchema(),
 			"server_count": {
 				Type:         schema.TypeInt,
-				Required:     true,
-				Description:  "Number of Consul server nodes",
-				ValidateFunc: validation.IntBetween(1, 7),
+				Optional:     true,
+				Computed:     true,
+				Description:  "Number of Consul server nodes. The total over all regions when region_distribution is set",
+				ValidateFunc: validateIntBetween(1, maxRaftServers),
+				ExactlyOneOf: []string{"server_count", "region_distribution"},
 			},
 			"client_count": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Default:      3,
-				Description:  "Number of Consul client nodes",
-				ValidateFunc: validation.IntBetween(0, 100),
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Number of Consul client nodes, defaults to 0 as the servers can serve small workloads. The total over all regions when region_distribution is set",
+				ValidateFunc:  validateIntBetween(0, maxClusterClients),
+				ConflictsWith: []string{"region_distribution"},
 			},
+			"region_distribution": regionDistributionSchema(),
+			"drain_on_scale_in":   drainOnScaleInSchema(),
+			"drain_timeout":       drainTimeoutSchema(),
 			"instance_type": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "OVH instance type for Consul nodes",
+				Optional:    true,
+				Computed:    true,
+				Description: "OVH instance type for Consul nodes, defaults to the provider default_instance_type",
 			},
 			"datacenter": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Consul datacenter name",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Consul datacenter name, defaults to the lowercased region with characters other than letters, numbers, hyphens and underscores replaced by hyphens",
+				ValidateFunc: validateDatacenter,
 			},
 			"connect_enabled": {
 				Type:        schema.TypeBool,
@@ -82,6 +111,12 @@ func resourceConsulCluster() *schema.Resource {
 				Default:     true,
 				Description: "Enable TLS encryption",
 			},
+			"auto_encrypt": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Have the servers distribute TLS certificates to client agents automatically, requires tls_enabled",
+			},
 			"ui_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -100,20 +135,87 @@ func resourceConsulCluster() *schema.Resource {
 				Default:     true,
 				Description: "Enable automated backups",
 			},
-			"web3_services": {
-				Type:        schema.TypeBool,
+			"monitoring": {
+				Type:        schema.TypeList,
 				Optional:    true,
-				Default:     false,
-				Description: "Enable Web3 service discovery",
+				MaxItems:    1,
+				Description: "External monitoring configuration, requires monitoring_enabled",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prometheus_remote_write_url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Prometheus remote write URL the cluster metrics are pushed to",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+					},
+				},
+			},
+			"web3_services": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable Web3 service discovery",
+				Deprecated:    "Use the web3 block instead",
+				ConflictsWith: []string{"web3"},
 			},
-			"tags": {
-				Type:        schema.TypeMap,
+			"web3": web3Schema("web3_services"),
+			"security_groups": {
+				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "Tags to apply to cluster resources",
+				Description: "Security group IDs or names to attach to cluster nodes",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"ui_allowed_cidrs":   uiAllowedCidrsSchema(),
+			"custom_domain":      customDomainSchema(),
+			"custom_endpoint":    customEndpointSchema(),
+			"connection_info":    connectionInfoSchema(),
+			"ca_certificate":     caCertificateSchema(),
+			"client_certificate": clientCertificateSchema(),
+			"client_key":         clientKeySchema(),
+			"user_data":          userDataSchema(),
+			"additional_volumes": additionalVolumesSchema(),
+			"maintenance_window": maintenanceWindowSchema(),
+			"rotate_gossip_key": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Change this value, for example by incrementing it, to rotate the gossip encryption key without recreating the cluster",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"rotate_acl_tokens": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Change this value, for example by incrementing it, to rotate the ACL master token without recreating the cluster",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"rotate_ca": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Change this value, for example by incrementing it, to rotate the Connect CA without downtime, requires connect_enabled",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"planned_action":        plannedActionSchema(),
+			"recreate_if_unhealthy": recreateIfUnhealthySchema(),
+			"error_on_existing":     errorOnExistingSchema(),
+			"replacement_strategy":  replacementStrategySchema(),
+			"previous_cluster_id":   previousClusterIdSchema(),
+			"previous_endpoint":     previousEndpointSchema(),
+			"ready":                 readySchema(),
+			"ready_at":              readyAtSchema(),
+			"wait_for_ready":        waitForReadyOnCreateSchema(),
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable deletion protection and detach dependent resources before deleting the cluster",
+			},
+			"tags":          tagsSchema(),
+			"instance_tags": instanceTagsSchema(),
 			"server_endpoints": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -127,6 +229,16 @@ func resourceConsulCluster() *schema.Resource {
 				Computed:    true,
 				Description: "Consul UI URL",
 			},
+			"metrics_endpoint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Prometheus metrics endpoint, set when monitoring is enabled",
+			},
+			"grafana_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Grafana dashboard URL, set when monitoring is enabled",
+			},
 			"gossip_key": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -139,6 +251,25 @@ func resourceConsulCluster() *schema.Resource {
 				Sensitive:   true,
 				Description: "ACL master token",
 			},
+			"ca_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry time of the Connect CA root certificate, in RFC 3339 format",
+			},
+			"default_security_group_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the security group created by OVH for the cluster",
+			},
+			"nodes":                           clusterNodesSchema(),
+			"region_nodes":                    regionNodesSchema(),
+			"user_data_hash":                  userDataHashSchema(),
+			"volume_ids":                      volumeIdsSchema(),
+			"last_operation_id":               lastOperationIdSchema(),
+			"next_maintenance_at":             nextMaintenanceAtSchema(),
+			"estimated_monthly_cost":          estimatedMonthlyCostSchema(),
+			"estimated_monthly_cost_currency": estimatedMonthlyCostCurrencySchema(),
+			"config_json":                     configJsonSchema(),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -152,34 +283,80 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 	config := meta.(*Config)
 	_ = diag.Diagnostics{}
 
+	instanceType, err := config.instanceType(d.Get("instance_type").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := config.projectID(d.Get("project_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	clusterConfig := map[string]interface{}{
 		"name":              d.Get("name").(string),
 		"region":            d.Get("region").(string),
 		"serverCount":       d.Get("server_count").(int),
 		"clientCount":       d.Get("client_count").(int),
-		"instanceType":      d.Get("instance_type").(string),
+		"instanceType":      instanceType,
 		"datacenter":        d.Get("datacenter").(string),
 		"connectEnabled":    d.Get("connect_enabled").(bool),
 		"aclEnabled":        d.Get("acl_enabled").(bool),
 		"encryptionEnabled": d.Get("encryption_enabled").(bool),
 		"tlsEnabled":        d.Get("tls_enabled").(bool),
+		"autoEncrypt":       d.Get("auto_encrypt").(bool),
 		"uiEnabled":         d.Get("ui_enabled").(bool),
 		"monitoringEnabled": d.Get("monitoring_enabled").(bool),
 		"backupEnabled":     d.Get("backup_enabled").(bool),
-		"web3Services":      d.Get("web3_services").(bool),
+		"securityGroups":    d.Get("security_groups").(*schema.Set).List(),
+		"uiAllowedCidrs":    d.Get("ui_allowed_cidrs"),
+		"customDomain":      d.Get("custom_domain").(string),
+		"additionalVolumes": expandAdditionalVolumes(d.Get("additional_volumes").([]interface{})),
 		"tags":              d.Get("tags"),
+		"instanceTags":      d.Get("instance_tags"),
+	}
+	if projectId != "" {
+		clusterConfig["projectId"] = projectId
 	}
 
-	var result map[string]interface{}
-	err := config.OVHClient.Post("/cloud/project/consul/cluster", clusterConfig, &result)
+	addLegacyBlock(clusterConfig, "web3", "web3Services", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_services").(bool)), false)
+
+	if monitoring := expandConsulMonitoring(d.Get("monitoring").([]interface{})); monitoring != nil {
+		clusterConfig["monitoring"] = monitoring
+	}
+
+	if window := expandMaintenanceWindow(d.Get("maintenance_window").([]interface{})); window != nil {
+		clusterConfig["maintenanceWindow"] = window
+	}
+
+	if distribution := expandRegionDistribution(d.Get("region_distribution").([]interface{})); distribution != nil {
+		clusterConfig["regionDistribution"] = distribution
+	}
+
+	setUserData(d, clusterConfig)
+
+	setPreviousCluster(ctx, config, d, "consul", projectId)
+	if diags := checkExistingCluster(ctx, config, d, "consul", "Consul", projectId); diags.HasError() {
+		return diags
+	}
+	if diags := checkInstanceQuota(ctx, config, d, "Consul", projectId, "server_count", "client_count"); diags.HasError() {
+		return diags
+	}
+
+	result, err := createCluster(ctx, config, d, "consul", projectId, clusterConfig)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create Consul cluster: %w", err))
+		return createClusterError("Consul", projectId, err)
 	}
 
-	clusterId := result["id"].(string)
+	clusterId, err := createdClusterId("Consul", result)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId(clusterId)
+	setConfigJson(d, clusterConfig)
+	setLastOperationId(d, result)
 
-	return resourceConsulClusterRead(ctx, d, meta)
+	diags := waitForReadyAfterCreate(ctx, config, d, "Consul", "consul")
+	return append(diags, readAfterCreate(ctx, d, meta, "Consul", resourceConsulClusterRead)...)
 }
 
 func resourceConsulClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -194,36 +371,64 @@ func resourceConsulClusterRead(ctx context.Context, d *schema.ResourceData, meta
 		d.SetId("")
 		return diag.FromErr(fmt.Errorf("failed to read Consul cluster: %w", err))
 	}
+	if err := completeClusterNodes(config, "consul", clusterId, cluster); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read nodes of Consul cluster: %w", err))
+	}
 
-	d.Set("name", cluster["name"])
-	d.Set("region", cluster["region"])
-	d.Set("server_count", cluster["serverCount"])
-	d.Set("client_count", cluster["clientCount"])
-	d.Set("instance_type", cluster["instanceType"])
-	d.Set("datacenter", cluster["datacenter"])
-	d.Set("connect_enabled", cluster["connectEnabled"])
-	d.Set("acl_enabled", cluster["aclEnabled"])
-	d.Set("encryption_enabled", cluster["encryptionEnabled"])
-	d.Set("tls_enabled", cluster["tlsEnabled"])
-	d.Set("ui_enabled", cluster["uiEnabled"])
-	d.Set("monitoring_enabled", cluster["monitoringEnabled"])
-	d.Set("backup_enabled", cluster["backupEnabled"])
-	d.Set("web3_services", cluster["web3Services"])
-	d.Set("server_endpoints", cluster["serverEndpoints"])
-	d.Set("ui_url", cluster["uiUrl"])
-	d.Set("status", cluster["status"])
+	d.Set("name", getString(cluster, "name"))
+	d.Set("region", getString(cluster, "region"))
+	d.Set("server_count", getInt(cluster, "serverCount"))
+	d.Set("client_count", getInt(cluster, "clientCount"))
+	d.Set("instance_type", getString(cluster, "instanceType"))
+	setProjectID(d, cluster)
+	d.Set("datacenter", getString(cluster, "datacenter"))
+	d.Set("connect_enabled", getBool(cluster, "connectEnabled"))
+	d.Set("acl_enabled", getBool(cluster, "aclEnabled"))
+	d.Set("encryption_enabled", getBool(cluster, "encryptionEnabled"))
+	d.Set("tls_enabled", getBool(cluster, "tlsEnabled"))
+	d.Set("auto_encrypt", getBool(cluster, "autoEncrypt"))
+	d.Set("ui_enabled", getBool(cluster, "uiEnabled"))
+	d.Set("monitoring_enabled", getBool(cluster, "monitoringEnabled"))
+	d.Set("backup_enabled", getBool(cluster, "backupEnabled"))
+	d.Set("web3_services", getBool(cluster, "web3Services"))
+	setLegacyBlock(d, "web3", "web3_services", cluster, flattenWeb3)
+	d.Set("server_endpoints", getStringList(cluster, "serverEndpoints"))
+	d.Set("ui_url", getString(cluster, "uiUrl"))
+	d.Set("status", getString(cluster, "status"))
+	setClusterReadiness(d, "consul", cluster)
+	d.Set("nodes", flattenClusterNodes(cluster))
+	d.Set("region_nodes", flattenRegionNodes(cluster))
+	setRegionDistribution(d, cluster)
+	setAdditionalVolumes(d, cluster)
+	setMaintenanceWindow(d, cluster)
+	setEstimatedMonthlyCost(d, config, "server_count", "client_count")
+	d.Set("security_groups", getStringList(cluster, "securityGroups"))
+	d.Set("ui_allowed_cidrs", getStringList(cluster, "uiAllowedCidrs"))
+	d.Set("custom_domain", getString(cluster, "customDomain"))
+	d.Set("custom_endpoint", getString(cluster, "customEndpoint"))
+	setConnectionInfo(d, "consul", cluster)
+	refreshPreviousCluster(config, d, "consul")
+	setTLSCertificates(config, d, "consul", clusterId)
+	d.Set("default_security_group_id", getString(cluster, "defaultSecurityGroupId"))
 
-	if gossipKey, ok := cluster["gossipKey"].(string); ok {
-		d.Set("gossip_key", gossipKey)
+	if getBool(cluster, "monitoringEnabled") {
+		d.Set("metrics_endpoint", getString(cluster, "metricsEndpoint"))
+		d.Set("grafana_url", getString(cluster, "grafanaUrl"))
+	} else {
+		d.Set("metrics_endpoint", "")
+		d.Set("grafana_url", "")
 	}
 
-	if masterToken, ok := cluster["masterToken"].(string); ok {
-		d.Set("master_token", masterToken)
+	if monitoring, ok := cluster["monitoring"].(map[string]interface{}); ok {
+		d.Set("monitoring", flattenConsulMonitoring(monitoring))
 	}
 
-	if tags, ok := cluster["tags"].(map[string]interface{}); ok {
-		d.Set("tags", tags)
-	}
+	setWriteOnceString(d, "gossip_key", cluster, "gossipKey")
+	setWriteOnceString(d, "master_token", cluster, "masterToken")
+	d.Set("ca_expires_at", getString(cluster, "caExpiresAt"))
+
+	d.Set("tags", flattenTags(cluster))
+	d.Set("instance_tags", flattenInstanceTags(cluster))
 
 	return nil
 }
@@ -234,7 +439,15 @@ func resourceConsulClusterUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	clusterId := d.Id()
 
-	if d.HasChanges("server_count", "client_count", "tags") {
+	if hasOnlyTagChanges(d, consulClusterUpdateKeys...) && !d.HasChanges("rotate_gossip_key", "rotate_acl_tokens", "rotate_ca") {
+		if err := updateClusterTags(ctx, config, "consul", clusterId, d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Consul cluster tags: %w", err))
+		}
+		mergeConfigJson(d, map[string]interface{}{"tags": d.Get("tags")})
+		return resourceConsulClusterRead(ctx, d, meta)
+	}
+
+	if d.HasChange("tags") || d.HasChanges(consulClusterUpdateKeys...) {
 		updateConfig := map[string]interface{}{}
 
 		if d.HasChange("server_count") {
@@ -242,13 +455,100 @@ func resourceConsulClusterUpdate(ctx context.Context, d *schema.ResourceData, me
 		}
 		if d.HasChange("client_count") {
 			updateConfig["clientCount"] = d.Get("client_count").(int)
+
+			// When the drain or the update fails, the previous client_count
+			// is kept so that the next apply scales in again.
+			drainedNodes, err := drainClientsOnScaleIn(ctx, config, d, "consul")
+			if err != nil {
+				d.Partial(true)
+				return diag.FromErr(fmt.Errorf("failed to update Consul cluster: %w", err))
+			}
+			if len(drainedNodes) > 0 {
+				updateConfig["removedNodes"] = drainedNodes
+			}
+		}
+		if d.HasChange("region_distribution") {
+			updateConfig["regionDistribution"] = expandRegionDistribution(d.Get("region_distribution").([]interface{}))
+		}
+		if d.HasChange("monitoring") {
+			monitoring := expandConsulMonitoring(d.Get("monitoring").([]interface{}))
+			if monitoring == nil {
+				monitoring = map[string]interface{}{}
+			}
+			updateConfig["monitoring"] = monitoring
+		}
+		if d.HasChange("security_groups") {
+			updateConfig["securityGroups"] = d.Get("security_groups").(*schema.Set).List()
+		}
+		if d.HasChange("ui_allowed_cidrs") {
+			updateConfig["uiAllowedCidrs"] = d.Get("ui_allowed_cidrs")
+		}
+		if d.HasChange("custom_domain") {
+			updateConfig["customDomain"] = d.Get("custom_domain").(string)
+		}
+		if d.HasChange("maintenance_window") {
+			updateConfig["maintenanceWindow"] = expandMaintenanceWindow(d.Get("maintenance_window").([]interface{}))
+		}
+		if d.HasChange("auto_encrypt") {
+			updateConfig["autoEncrypt"] = d.Get("auto_encrypt").(bool)
+		}
+		if d.HasChanges("web3", "web3_services") {
+			addLegacyBlock(updateConfig, "web3", "web3Services", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_services").(bool)), true)
 		}
 		if d.HasChange("tags") {
 			updateConfig["tags"] = d.Get("tags")
 		}
+		if d.HasChange("instance_tags") {
+			updateConfig["instanceTags"] = d.Get("instance_tags")
+		}
 
-		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/consul/cluster/%s", clusterId), updateConfig, nil)
+		result, err := updateCluster(ctx, config, "consul", clusterId, updateConfig, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
+			d.Partial(true)
+			return diag.FromErr(fmt.Errorf("failed to update Consul cluster: %w", err))
+		}
+		operationId := setLastOperationId(d, result)
+		mergeConfigJson(d, updateConfig)
+
+		if d.HasChange("tags") {
+			if err := deleteRemovedTags(config, fmt.Sprintf("/cloud/project/consul/cluster/%s", clusterId), d); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update Consul cluster: %w", err))
+			}
+		}
+
+		if err := waitForClusterOperation(ctx, config, "consul", clusterId, operationId); err != nil {
+			return diag.FromErr(fmt.Errorf("cluster update failed: %w", err))
+		}
+	}
+
+	// Rotations are the only time new secrets are returned in full, so they
+	// are stored here rather than left to Read. A failed rotation keeps the
+	// previous state, so that its trigger is not consumed and the next apply
+	// runs it again.
+	if d.HasChange("rotate_gossip_key") {
+		oldKey, _ := d.GetChange("gossip_key")
+		newKey, err := rotateConsulGossipKey(ctx, config, clusterId, oldKey.(string))
+		if err != nil {
+			d.Partial(true)
+			return diag.FromErr(fmt.Errorf("failed to update Consul cluster: %w", err))
+		}
+		d.Set("gossip_key", newKey)
+	}
+
+	if d.HasChange("rotate_acl_tokens") {
+		masterToken, err := rotateConsulACLTokens(ctx, config, clusterId)
+		if err != nil {
+			d.Partial(true)
+			return diag.FromErr(fmt.Errorf("failed to update Consul cluster: %w", err))
+		}
+		if !isMaskedSecret(masterToken) {
+			d.Set("master_token", masterToken)
+		}
+	}
+
+	if d.HasChange("rotate_ca") {
+		if err := rotateConsulConnectCA(ctx, config, clusterId); err != nil {
+			d.Partial(true)
 			return diag.FromErr(fmt.Errorf("failed to update Consul cluster: %w", err))
 		}
 	}
@@ -262,7 +562,7 @@ func resourceConsulClusterDelete(ctx context.Context, d *schema.ResourceData, me
 
 	clusterId := d.Id()
 
-	err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/consul/cluster/%s", clusterId), nil)
+	err := deleteCluster(ctx, config, "consul", clusterId, d.Get("force_destroy").(bool), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete Consul cluster: %w", err))
 	}
@@ -270,3 +570,103 @@ func resourceConsulClusterDelete(ctx context.Context, d *schema.ResourceData, me
 	d.SetId("")
 	return nil
 }
+
+func resourceConsulClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := planRecreateIfUnhealthy(d); err != nil {
+		return err
+	}
+
+	if err := planBlueGreenReplacement(d); err != nil {
+		return err
+	}
+
+	if err := planConfigJson(d, consulClusterUpdateKeys...); err != nil {
+		return err
+	}
+
+	if err := planRegionDistribution(d, 0); err != nil {
+		return err
+	}
+
+	if err := validateAdditionalVolumes(d); err != nil {
+		return err
+	}
+
+	if err := setDefaultDatacenter(d); err != nil {
+		return err
+	}
+
+	if len(d.Get("monitoring").([]interface{})) > 0 && !d.Get("monitoring_enabled").(bool) {
+		return fmt.Errorf("monitoring block can only be set when monitoring_enabled is true")
+	}
+
+	if d.Get("connect_enabled").(bool) && !d.Get("tls_enabled").(bool) {
+		return fmt.Errorf("connect_enabled requires tls_enabled to be true")
+	}
+
+	if d.Get("auto_encrypt").(bool) && !d.Get("tls_enabled").(bool) {
+		return fmt.Errorf("auto_encrypt requires tls_enabled to be true")
+	}
+
+	// A new cluster gets fresh secrets, so rotations only apply to existing ones.
+	if d.Id() != "" && d.HasChange("rotate_gossip_key") {
+		if !d.Get("encryption_enabled").(bool) {
+			return fmt.Errorf("rotate_gossip_key requires encryption_enabled to be true")
+		}
+		if err := d.SetNewComputed("gossip_key"); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() != "" && d.HasChange("rotate_acl_tokens") {
+		if !d.Get("acl_enabled").(bool) {
+			return fmt.Errorf("rotate_acl_tokens requires acl_enabled to be true")
+		}
+		if err := d.SetNewComputed("master_token"); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() != "" && d.HasChange("rotate_ca") {
+		if !d.Get("connect_enabled").(bool) {
+			return fmt.Errorf("rotate_ca requires connect_enabled to be true")
+		}
+		if err := d.SetNewComputed("ca_expires_at"); err != nil {
+			return err
+		}
+	}
+
+	if err := planNodeCountChange(ctx, d, "Consul", "server_count", "server_count", "client_count"); err != nil {
+		return err
+	}
+
+	if err := planInstanceQuota(ctx, d, meta, "Consul", "server_count", "client_count"); err != nil {
+		return err
+	}
+
+	return planEstimatedMonthlyCost(d, meta, "server_count", "client_count")
+}
+
+func expandConsulMonitoring(l []interface{}) map[string]interface{} {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	raw := l[0].(map[string]interface{})
+	return map[string]interface{}{
+		"prometheusRemoteWriteUrl": raw["prometheus_remote_write_url"].(string),
+	}
+}
+
+func flattenConsulMonitoring(monitoring map[string]interface{}) []interface{} {
+	url := getString(monitoring, "prometheusRemoteWriteUrl")
+	if url == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"prometheus_remote_write_url": url,
+		},
+	}
+}
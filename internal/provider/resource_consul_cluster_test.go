@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testConsulClusterRawConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":          "test-consul",
+		"region":        "GRA",
+		"server_count":  3,
+		"instance_type": "c2-15",
+		"datacenter":    "dc1",
+	}
+}
+
+// TestConsulCluster_monitoringRequiresEnabled checks that the monitoring block
+// is rejected when monitoring_enabled is false
+func TestConsulCluster_monitoringRequiresEnabled(t *testing.T) {
+	raw := testConsulClusterRawConfig()
+	raw["monitoring_enabled"] = false
+	raw["monitoring"] = []interface{}{
+		map[string]interface{}{
+			"prometheus_remote_write_url": "https://prometheus.example.com/api/v1/write",
+		},
+	}
+
+	_, err := resourceConsulCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err == nil {
+		t.Fatal("expected an error when monitoring is set without monitoring_enabled")
+	}
+	if !regexp.MustCompile("monitoring_enabled is true").MatchString(err.Error()) {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+// TestConsulCluster_connectRequiresTLS checks that Consul Connect is rejected
+// at plan time when TLS is disabled
+func TestConsulCluster_connectRequiresTLS(t *testing.T) {
+	cases := map[string]struct {
+		connectEnabled bool
+		tlsEnabled     bool
+		expectError    bool
+	}{
+		"connect with tls":       {connectEnabled: true, tlsEnabled: true},
+		"connect without tls":    {connectEnabled: true, tlsEnabled: false, expectError: true},
+		"no connect without tls": {connectEnabled: false, tlsEnabled: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testConsulClusterRawConfig()
+			raw["connect_enabled"] = tc.connectEnabled
+			raw["tls_enabled"] = tc.tlsEnabled
+
+			_, err := resourceConsulCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestConsulCluster_autoEncryptRequiresTLS checks that auto-encrypt is
+// rejected at plan time when TLS is disabled
+func TestConsulCluster_autoEncryptRequiresTLS(t *testing.T) {
+	cases := map[string]struct {
+		autoEncrypt bool
+		tlsEnabled  bool
+		expectError bool
+	}{
+		"auto-encrypt with tls":       {autoEncrypt: true, tlsEnabled: true},
+		"auto-encrypt without tls":    {autoEncrypt: true, tlsEnabled: false, expectError: true},
+		"no auto-encrypt without tls": {autoEncrypt: false, tlsEnabled: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testConsulClusterRawConfig()
+			raw["auto_encrypt"] = tc.autoEncrypt
+			raw["tls_enabled"] = tc.tlsEnabled
+			raw["connect_enabled"] = false
+
+			_, err := resourceConsulCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestConsulCluster_monitoringURLValidation checks the remote write URL format
+func TestConsulCluster_monitoringURLValidation(t *testing.T) {
+	raw := testConsulClusterRawConfig()
+	raw["monitoring"] = []interface{}{
+		map[string]interface{}{
+			"prometheus_remote_write_url": "not-a-url",
+		},
+	}
+
+	diags := resourceConsulCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() {
+		t.Fatal("expected an error for an invalid prometheus_remote_write_url")
+	}
+}
+
+// TestConsulClusterRead_monitoringOutputs checks that the monitoring endpoints
+// are populated from the API response
+func TestConsulClusterRead_monitoringOutputs(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "id": "consul-123",
+  "name": "test-consul",
+  "monitoringEnabled": true,
+  "metricsEndpoint": "https://consul-123.metrics.example.com/metrics",
+  "grafanaUrl": "https://grafana.example.com/d/consul-123",
+  "monitoring": {"prometheusRemoteWriteUrl": "https://prometheus.example.com/api/v1/write"}
+}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.SetId("consul-123")
+
+	if diags := resourceConsulClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("metrics_endpoint").(string); got != "https://consul-123.metrics.example.com/metrics" {
+		t.Errorf("unexpected metrics_endpoint: %q", got)
+	}
+	if got := d.Get("grafana_url").(string); got != "https://grafana.example.com/d/consul-123" {
+		t.Errorf("unexpected grafana_url: %q", got)
+	}
+	if got := d.Get("monitoring.0.prometheus_remote_write_url").(string); got != "https://prometheus.example.com/api/v1/write" {
+		t.Errorf("unexpected prometheus_remote_write_url: %q", got)
+	}
+}
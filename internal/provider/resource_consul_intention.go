@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceConsulIntention() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Consul Connect intention allowing or denying traffic between two services",
+
+		CreateContext: resourceConsulIntentionCreate,
+		ReadContext:   resourceConsulIntentionRead,
+		UpdateContext: keepStateOnDryRun(resourceConsulIntentionUpdate),
+		DeleteContext: resourceConsulIntentionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceConsulIntentionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Consul cluster",
+			},
+			"source_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the source service, or * for any service",
+				ValidateFunc: validation.All(validation.StringIsNotEmpty, validation.StringDoesNotContainAny("/")),
+			},
+			"destination_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the destination service, or * for any service",
+				ValidateFunc: validation.All(validation.StringIsNotEmpty, validation.StringDoesNotContainAny("/")),
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Whether connections from the source to the destination are allowed or denied",
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the intention",
+			},
+		},
+	}
+}
+
+func resourceConsulIntentionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId := d.Get("cluster_id").(string)
+	source := d.Get("source_name").(string)
+	destination := d.Get("destination_name").(string)
+
+	intentionConfig := map[string]interface{}{
+		"sourceName":      source,
+		"destinationName": destination,
+		"action":          d.Get("action").(string),
+		"description":     d.Get("description").(string),
+	}
+
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/consul/cluster/%s/intention", clusterId), intentionConfig, nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create Consul intention: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", clusterId, source, destination))
+
+	return resourceConsulIntentionRead(ctx, d, meta)
+}
+
+func resourceConsulIntentionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, source, destination, err := parseThreePartID(d.Id(), "cluster_id", "source", "destination")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var intention map[string]interface{}
+	err = config.OVHClient.Get(fmt.Sprintf("/cloud/project/consul/cluster/%s/intention/%s/%s", clusterId, source, destination), &intention)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read Consul intention: %w", err))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("source_name", getString(intention, "sourceName"))
+	d.Set("destination_name", getString(intention, "destinationName"))
+	d.Set("action", getString(intention, "action"))
+	d.Set("description", getString(intention, "description"))
+
+	return nil
+}
+
+func resourceConsulIntentionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, source, destination, err := parseThreePartID(d.Id(), "cluster_id", "source", "destination")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("action", "description") {
+		updateConfig := map[string]interface{}{
+			"action":      d.Get("action").(string),
+			"description": d.Get("description").(string),
+		}
+
+		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/consul/cluster/%s/intention/%s/%s", clusterId, source, destination), updateConfig, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Consul intention: %w", err))
+		}
+	}
+
+	return resourceConsulIntentionRead(ctx, d, meta)
+}
+
+func resourceConsulIntentionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, source, destination, err := parseThreePartID(d.Id(), "cluster_id", "source", "destination")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(fmt.Sprintf("/cloud/project/consul/cluster/%s/intention/%s/%s", clusterId, source, destination), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete Consul intention: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceConsulIntentionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	clusterId, source, destination, err := parseThreePartID(d.Id(), "cluster_id", "source", "destination")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("source_name", source)
+	d.Set("destination_name", destination)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConsulIntention_validation(t *testing.T) {
+	cases := map[string]struct {
+		source      string
+		destination string
+		action      string
+		valid       bool
+	}{
+		"allow":             {source: "web", destination: "db", action: "allow", valid: true},
+		"deny wildcard":     {source: "*", destination: "db", action: "deny", valid: true},
+		"unknown action":    {source: "web", destination: "db", action: "permit"},
+		"empty source":      {source: "", destination: "db", action: "allow"},
+		"empty destination": {source: "web", destination: "", action: "allow"},
+		"slash in name":     {source: "web/v2", destination: "db", action: "allow"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cluster_id":       "consul-123",
+				"source_name":      tc.source,
+				"destination_name": tc.destination,
+				"action":           tc.action,
+			}
+
+			diags := resourceConsulIntention().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if diags.HasError() == tc.valid {
+				t.Errorf("expected valid=%t, got diagnostics %v", tc.valid, diags)
+			}
+		})
+	}
+}
+
+func TestConsulIntention_import(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConsulIntention().Schema, map[string]interface{}{})
+	d.SetId("consul-123/web/db")
+
+	results, err := resourceConsulIntentionImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if got := results[0].Get("source_name").(string); got != "web" {
+		t.Errorf("expected source_name web, got %q", got)
+	}
+	if got := results[0].Get("destination_name").(string); got != "db" {
+		t.Errorf("expected destination_name db, got %q", got)
+	}
+
+	d.SetId("consul-123/web")
+	if _, err := resourceConsulIntentionImport(context.Background(), d, nil); err == nil {
+		t.Error("expected an error for an ID without a destination")
+	}
+}
+
+func TestConsulIntentionRead(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"sourceName": "web", "destinationName": "db", "action": "deny", "description": "block web to db"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulIntention().Schema, map[string]interface{}{})
+	d.SetId("consul-123/web/db")
+
+	if diags := resourceConsulIntentionRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/consul/cluster/consul-123/intention/web/db" {
+		t.Errorf("unexpected request path %q", got)
+	}
+	if got := d.Get("action").(string); got != "deny" {
+		t.Errorf("expected action deny, got %q", got)
+	}
+	if got := d.Get("cluster_id").(string); got != "consul-123" {
+		t.Errorf("expected cluster_id consul-123, got %q", got)
+	}
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceConsulKV() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a key in the KV store of a Consul cluster running on OVH infrastructure",
+
+		CreateContext: resourceConsulKVCreate,
+		ReadContext:   resourceConsulKVRead,
+		UpdateContext: keepStateOnDryRun(resourceConsulKVUpdate),
+		DeleteContext: resourceConsulKVDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceConsulKVImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Consul cluster",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the key, such as config/app/db_host",
+				ValidateFunc: validation.All(
+					validation.StringIsNotEmpty,
+					validation.StringDoesNotMatch(regexp.MustCompile(`^/`), "path must not start with a slash"),
+				),
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Value stored under the key",
+			},
+			"flags": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Opaque unsigned integer stored alongside the value",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"delete_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Delete the key from Consul when the resource is destroyed",
+			},
+		},
+	}
+}
+
+func resourceConsulKVCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId := d.Get("cluster_id").(string)
+	path := d.Get("path").(string)
+
+	if err := putConsulKV(config, clusterId, path, d); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create Consul key: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, path))
+
+	return resourceConsulKVRead(ctx, d, meta)
+}
+
+func resourceConsulKVRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, path, err := parseTwoPartID(d.Id(), "cluster_id", "path")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var key map[string]interface{}
+	err = config.OVHClient.Get(fmt.Sprintf("/cloud/project/consul/cluster/%s/kv/%s", clusterId, path), &key)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read Consul key: %w", err))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("path", path)
+	d.Set("value", getString(key, "value"))
+	d.Set("flags", getInt(key, "flags"))
+
+	return nil
+}
+
+func resourceConsulKVUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, path, err := parseTwoPartID(d.Id(), "cluster_id", "path")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("value", "flags") {
+		if err := putConsulKV(config, clusterId, path, d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Consul key: %w", err))
+		}
+	}
+
+	return resourceConsulKVRead(ctx, d, meta)
+}
+
+func resourceConsulKVDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, path, err := parseTwoPartID(d.Id(), "cluster_id", "path")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("delete_on_destroy").(bool) {
+		err = config.OVHClient.Delete(fmt.Sprintf("/cloud/project/consul/cluster/%s/kv/%s", clusterId, path), nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to delete Consul key: %w", err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceConsulKVImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	clusterId, path, err := parseTwoPartID(d.Id(), "cluster_id", "path")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("path", path)
+	d.Set("delete_on_destroy", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// putConsulKV writes the key, Consul KV writes being upserts for both create and update.
+func putConsulKV(config *Config, clusterId, path string, d *schema.ResourceData) error {
+	keyConfig := map[string]interface{}{
+		"value": d.Get("value").(string),
+		"flags": d.Get("flags").(int),
+	}
+
+	return config.OVHClient.Put(fmt.Sprintf("/cloud/project/consul/cluster/%s/kv/%s", clusterId, path), keyConfig, nil)
+}
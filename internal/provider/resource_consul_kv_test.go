@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestConsulKV_import(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConsulKV().Schema, map[string]interface{}{})
+	d.SetId("consul-123/config/app/db_host")
+
+	results, err := resourceConsulKVImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := results[0].Get("cluster_id").(string); got != "consul-123" {
+		t.Errorf("expected cluster_id consul-123, got %q", got)
+	}
+	if got := results[0].Get("path").(string); got != "config/app/db_host" {
+		t.Errorf("expected path config/app/db_host, got %q", got)
+	}
+}
+
+// TestConsulKVUpdate_inPlace checks that a value change is written with a PUT
+// to the existing key rather than recreating it
+func TestConsulKVUpdate_inPlace(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"key": "config/app/db_host", "value": "db.internal", "flags": 2}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulKV().Schema, map[string]interface{}{
+		"cluster_id": "consul-123",
+		"path":       "config/app/db_host",
+		"value":      "db.internal",
+		"flags":      2,
+	})
+	d.SetId("consul-123/config/app/db_host")
+
+	if diags := resourceConsulKVUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+
+	put := mock.Requests[0]
+	if put.Method != http.MethodPut || put.URL.Path != "/cloud/project/consul/cluster/consul-123/kv/config/app/db_host" {
+		t.Errorf("unexpected request %s %s", put.Method, put.URL.Path)
+	}
+	if body["value"] != "db.internal" || body["flags"] != 2.0 {
+		t.Errorf("unexpected request body %v", body)
+	}
+	if d.Id() != "consul-123/config/app/db_host" {
+		t.Errorf("expected the ID to be unchanged, got %q", d.Id())
+	}
+}
+
+func TestConsulKVDelete_keepOnDestroy(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceConsulKV().Schema, map[string]interface{}{
+		"cluster_id":        "consul-123",
+		"path":              "config/app/db_host",
+		"value":             "db.internal",
+		"delete_on_destroy": false,
+	})
+	d.SetId("consul-123/config/app/db_host")
+
+	if diags := resourceConsulKVDelete(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := mock.GetRequestCount(); got != 0 {
+		t.Errorf("expected the key to be left in Consul, got %d requests", got)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the ID to be cleared, got %q", d.Id())
+	}
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceConsulService() *schema.Resource {
+	return &schema.Resource{
+		Description: "Registers an external service in the catalog of a Consul cluster, so that workloads not running on Nomad join the service mesh",
+
+		CreateContext: resourceConsulServiceCreate,
+		ReadContext:   resourceConsulServiceRead,
+		UpdateContext: keepStateOnDryRun(resourceConsulServiceUpdate),
+		DeleteContext: resourceConsulServiceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceConsulServiceImport,
+		},
+
+		CustomizeDiff: resourceConsulServiceCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Consul cluster",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the service",
+				ValidateFunc: validation.All(validation.StringIsNotWhiteSpace, validation.StringDoesNotContainAny("/")),
+			},
+			"service_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "ID of the service instance, defaults to name. Set it to register several instances of a service",
+				ValidateFunc: validation.All(validation.StringIsNotWhiteSpace, validation.StringDoesNotContainAny("/")),
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "IP address or hostname the service is reachable at",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Port the service listens on",
+				ValidateFunc: validateIntBetween(1, 65535),
+			},
+			"tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Tags of the service, to filter it in discovery queries",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"check": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Health check of the service, either an HTTP or a TCP check",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"http": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "URL the check sends GET requests to, healthy on a 2xx response",
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						},
+						"tcp": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "host:port the check opens TCP connections to, healthy when the connection succeeds",
+							ValidateFunc: validateHostPort,
+						},
+						"interval": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "10s",
+							Description:  "How often the check runs, as a duration such as 10s",
+							ValidateFunc: validateDuration,
+						},
+						"timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "5s",
+							Description:  "How long the check waits for a response, as a duration shorter than interval",
+							ValidateFunc: validateDuration,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceConsulServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId := d.Get("cluster_id").(string)
+	serviceId := d.Get("service_id").(string)
+	if serviceId == "" {
+		serviceId = d.Get("name").(string)
+	}
+
+	serviceConfig := expandConsulService(d)
+	serviceConfig["id"] = serviceId
+
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/consul/cluster/%s/service", clusterId), serviceConfig, nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to register Consul service: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, serviceId))
+
+	return resourceConsulServiceRead(ctx, d, meta)
+}
+
+func resourceConsulServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, serviceId, err := parseTwoPartID(d.Id(), "cluster_id", "service_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var service map[string]interface{}
+	err = config.OVHClient.Get(fmt.Sprintf("/cloud/project/consul/cluster/%s/service/%s", clusterId, serviceId), &service)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read Consul service: %w", err))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("service_id", serviceId)
+	d.Set("name", getString(service, "name"))
+	d.Set("address", getString(service, "address"))
+	d.Set("port", getInt(service, "port"))
+	d.Set("tags", getStringList(service, "tags"))
+	d.Set("check", flattenConsulServiceCheck(service))
+
+	return nil
+}
+
+func resourceConsulServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, serviceId, err := parseTwoPartID(d.Id(), "cluster_id", "service_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("address", "port", "tags", "check") {
+		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/consul/cluster/%s/service/%s", clusterId, serviceId), expandConsulService(d), nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Consul service: %w", err))
+		}
+	}
+
+	return resourceConsulServiceRead(ctx, d, meta)
+}
+
+func resourceConsulServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, serviceId, err := parseTwoPartID(d.Id(), "cluster_id", "service_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(fmt.Sprintf("/cloud/project/consul/cluster/%s/service/%s", clusterId, serviceId), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to deregister Consul service: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceConsulServiceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	clusterId, serviceId, err := parseTwoPartID(d.Id(), "cluster_id", "service_id")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("service_id", serviceId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceConsulServiceCustomizeDiff checks that a check block sets exactly
+// one of http and tcp, and times out before its next run.
+func resourceConsulServiceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("check") {
+		return nil
+	}
+	checks := d.Get("check").([]interface{})
+	if len(checks) == 0 || checks[0] == nil {
+		return nil
+	}
+	check := checks[0].(map[string]interface{})
+
+	if (check["http"].(string) == "") == (check["tcp"].(string) == "") {
+		return fmt.Errorf("check must set exactly one of http and tcp")
+	}
+
+	interval, err := time.ParseDuration(check["interval"].(string))
+	if err != nil {
+		return nil
+	}
+	timeout, err := time.ParseDuration(check["timeout"].(string))
+	if err != nil {
+		return nil
+	}
+	if timeout >= interval {
+		return fmt.Errorf("check timeout (%s) must be shorter than its interval (%s)", timeout, interval)
+	}
+	return nil
+}
+
+// validateHostPort checks that a string attribute is a host:port address
+// with a port between 1 and 65535.
+func validateHostPort(v interface{}, k string) (ws []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	host, port, err := net.SplitHostPort(value)
+	if err == nil && host != "" {
+		var n int
+		n, err = strconv.Atoi(port)
+		if err == nil && (n < 1 || n > 65535) {
+			err = fmt.Errorf("port out of range")
+		}
+	}
+	if err != nil || host == "" {
+		errors = append(errors, fmt.Errorf("%s must be a host:port address with a port between 1 and 65535, got %q", k, value))
+	}
+	return
+}
+
+func expandConsulService(d *schema.ResourceData) map[string]interface{} {
+	service := map[string]interface{}{
+		"name":    d.Get("name").(string),
+		"address": d.Get("address").(string),
+		"port":    d.Get("port").(int),
+		"tags":    d.Get("tags").([]interface{}),
+	}
+
+	if checks := d.Get("check").([]interface{}); len(checks) > 0 && checks[0] != nil {
+		raw := checks[0].(map[string]interface{})
+		check := map[string]interface{}{
+			"interval": raw["interval"].(string),
+			"timeout":  raw["timeout"].(string),
+		}
+		if http := raw["http"].(string); http != "" {
+			check["http"] = http
+		}
+		if tcp := raw["tcp"].(string); tcp != "" {
+			check["tcp"] = tcp
+		}
+		service["check"] = check
+	}
+	return service
+}
+
+func flattenConsulServiceCheck(service map[string]interface{}) []interface{} {
+	check, ok := service["check"].(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"http":     getString(check, "http"),
+			"tcp":      getString(check, "tcp"),
+			"interval": getString(check, "interval"),
+			"timeout":  getString(check, "timeout"),
+		},
+	}
+}
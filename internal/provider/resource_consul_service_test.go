@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConsulService_validation(t *testing.T) {
+	cases := map[string]struct {
+		port  int
+		check map[string]interface{}
+		valid bool
+	}{
+		"no check":         {port: 8080, valid: true},
+		"http check":       {port: 8080, check: map[string]interface{}{"http": "http://10.0.0.5:8080/health"}, valid: true},
+		"tcp check":        {port: 5432, check: map[string]interface{}{"tcp": "10.0.0.5:5432", "interval": "30s", "timeout": "2s"}, valid: true},
+		"port zero":        {port: 0},
+		"port too high":    {port: 65536},
+		"invalid http":     {port: 8080, check: map[string]interface{}{"http": "10.0.0.5/health"}},
+		"tcp without port": {port: 5432, check: map[string]interface{}{"tcp": "10.0.0.5"}},
+		"tcp bad port":     {port: 5432, check: map[string]interface{}{"tcp": "10.0.0.5:70000"}},
+		"invalid interval": {port: 8080, check: map[string]interface{}{"http": "http://10.0.0.5/", "interval": "often"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cluster_id": "consul-123",
+				"name":       "billing",
+				"address":    "10.0.0.5",
+				"port":       tc.port,
+			}
+			if tc.check != nil {
+				raw["check"] = []interface{}{tc.check}
+			}
+
+			diags := resourceConsulService().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if diags.HasError() == tc.valid {
+				t.Errorf("expected valid=%t, got diagnostics %v", tc.valid, diags)
+			}
+		})
+	}
+}
+
+// TestConsulService_checkDiff checks the check parameters validated at plan
+// time
+func TestConsulService_checkDiff(t *testing.T) {
+	cases := map[string]struct {
+		check     map[string]interface{}
+		expectErr bool
+	}{
+		"http":             {check: map[string]interface{}{"http": "http://10.0.0.5/health"}},
+		"neither":          {check: map[string]interface{}{"interval": "10s"}, expectErr: true},
+		"both":             {check: map[string]interface{}{"http": "http://10.0.0.5/health", "tcp": "10.0.0.5:8080"}, expectErr: true},
+		"timeout too long": {check: map[string]interface{}{"tcp": "10.0.0.5:8080", "interval": "5s", "timeout": "5s"}, expectErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cluster_id": "consul-123",
+				"name":       "billing",
+				"address":    "10.0.0.5",
+				"port":       8080,
+				"check":      []interface{}{tc.check},
+			}
+
+			_, err := resourceConsulService().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("expected error %t, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestConsulServiceCreate(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "billing", "name": "billing", "address": "10.0.0.5", "port": 8080, "tags": ["v1"], "check": {"http": "http://10.0.0.5:8080/health", "interval": "10s", "timeout": "5s"}}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceConsulService().Schema, map[string]interface{}{
+		"cluster_id": "consul-123",
+		"name":       "billing",
+		"address":    "10.0.0.5",
+		"port":       8080,
+		"tags":       []interface{}{"v1"},
+		"check":      []interface{}{map[string]interface{}{"http": "http://10.0.0.5:8080/health"}},
+	})
+
+	if diags := resourceConsulServiceCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "consul-123/billing" {
+		t.Errorf("expected ID consul-123/billing, got %q", d.Id())
+	}
+	if got := d.Get("service_id").(string); got != "billing" {
+		t.Errorf("expected service_id to default to the name, got %q", got)
+	}
+
+	if path := mock.Requests[0].URL.Path; path != "/cloud/project/consul/cluster/consul-123/service" {
+		t.Errorf("expected the service to be registered with the cluster, got %s", path)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	check, _ := body["check"].(map[string]interface{})
+	if check["http"] != "http://10.0.0.5:8080/health" || check["interval"] != "10s" {
+		t.Errorf("expected the HTTP check with its default interval, got %v", body["check"])
+	}
+	if _, ok := check["tcp"]; ok {
+		t.Errorf("expected no tcp check, got %v", check["tcp"])
+	}
+}
+
+func TestConsulService_import(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConsulService().Schema, map[string]interface{}{})
+	d.SetId("consul-123/billing-2")
+
+	results, err := resourceConsulServiceImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := results[0].Get("cluster_id").(string); got != "consul-123" {
+		t.Errorf("expected cluster_id consul-123, got %q", got)
+	}
+	if got := results[0].Get("service_id").(string); got != "billing-2" {
+		t.Errorf("expected service_id billing-2, got %q", got)
+	}
+
+	d.SetId("billing")
+	if _, err := resourceConsulServiceImport(context.Background(), d, nil); err == nil {
+		t.Error("expected an error for an ID without a cluster ID")
+	}
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceKMSKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an OVH KMS key, such as the key Vault clusters auto-unseal with",
+
+		CreateContext: resourceKMSKeyCreate,
+		ReadContext:   resourceKMSKeyRead,
+		UpdateContext: keepStateOnDryRun(resourceKMSKeyUpdate),
+		DeleteContext: resourceKMSKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateByName("/cloud/project/kms/key", "KMS key"),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the KMS key",
+				ValidateFunc: validateClusterName,
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "OVH region of the key, which must be the region of the clusters using it",
+			},
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "AES-256",
+				Description:  "Key algorithm (AES-256, RSA-2048, RSA-4096)",
+				ValidateFunc: validation.StringInSlice([]string{"AES-256", "RSA-2048", "RSA-4096"}, false),
+			},
+			"rotation_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Number of days after which a new version of the key is generated, or 0 to never rotate it",
+				ValidateFunc: validateIntBetween(0, 3650),
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the key, to set as auto_unseal_key_id on Vault clusters",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the key was created, in RFC 3339 format",
+			},
+		},
+	}
+}
+
+func resourceKMSKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	keyConfig := map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"region":       d.Get("region").(string),
+		"algorithm":    d.Get("algorithm").(string),
+		"rotationDays": d.Get("rotation_days").(int),
+	}
+
+	var result map[string]interface{}
+	err := config.OVHClient.Post("/cloud/project/kms/key", keyConfig, &result)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create KMS key: %w", err))
+	}
+
+	keyId := getString(result, "id")
+	if keyId == "" {
+		return diag.Errorf("failed to create KMS key: no key ID returned")
+	}
+	d.SetId(keyId)
+
+	return resourceKMSKeyRead(ctx, d, meta)
+}
+
+func resourceKMSKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	var key map[string]interface{}
+	err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/kms/key/%s", d.Id()), &key)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read KMS key: %w", err))
+	}
+
+	d.Set("name", getString(key, "name"))
+	d.Set("region", getString(key, "region"))
+	d.Set("algorithm", getString(key, "algorithm"))
+	d.Set("rotation_days", getInt(key, "rotationDays"))
+	d.Set("key_id", d.Id())
+	d.Set("created_at", getString(key, "createdAt"))
+
+	return nil
+}
+
+func resourceKMSKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	if d.HasChange("rotation_days") {
+		updateConfig := map[string]interface{}{
+			"rotationDays": d.Get("rotation_days").(int),
+		}
+
+		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/kms/key/%s", d.Id()), updateConfig, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update KMS key: %w", err))
+		}
+	}
+
+	return resourceKMSKeyRead(ctx, d, meta)
+}
+
+func resourceKMSKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/kms/key/%s", d.Id()), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete KMS key: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// checkKMSKeyRegion verifies that the KMS key keyId is in region, as a Vault
+// cluster can only auto-unseal with a key of its own region.
+func checkKMSKeyRegion(config *Config, keyId, region string) error {
+	var key map[string]interface{}
+	if err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/kms/key/%s", keyId), &key); err != nil {
+		return fmt.Errorf("failed to read KMS key %s: %w", keyId, err)
+	}
+
+	if keyRegion := getString(key, "region"); keyRegion != region {
+		return fmt.Errorf("KMS key %s is in region %s, but the cluster is in region %s", keyId, keyRegion, region)
+	}
+	return nil
+}
+
+// validateAutoUnsealKey checks at plan time that auto_unseal_key_id is only
+// set with auto_unseal, and that the key is in the region of the cluster. A
+// key created in the same apply is unknown here and checked on create.
+func validateAutoUnsealKey(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("auto_unseal_key_id") || !d.NewValueKnown("region") {
+		return nil
+	}
+	keyId := d.Get("auto_unseal_key_id").(string)
+	if keyId == "" || (d.Id() != "" && !d.HasChange("auto_unseal_key_id")) {
+		return nil
+	}
+
+	if !d.Get("auto_unseal").(bool) {
+		return fmt.Errorf("auto_unseal_key_id can only be set when auto_unseal is true")
+	}
+
+	config, ok := meta.(*Config)
+	if !ok {
+		return nil
+	}
+	return checkKMSKeyRegion(config, keyId, d.Get("region").(string))
+}
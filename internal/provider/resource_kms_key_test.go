@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestKMSKey_internalValidate(t *testing.T) {
+	if err := resourceKMSKey().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid schema: %s", err)
+	}
+}
+
+// TestKMSKeyCreate checks that a key is created with its algorithm and
+// rotation period and that its ID and creation time are read back
+func TestKMSKeyCreate(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "kms-123"}`, nil)
+	mock.AddResponse(200, `{"id": "kms-123", "name": "vault-unseal", "region": "GRA", "algorithm": "AES-256", "rotationDays": 90, "createdAt": "2026-10-01T10:00:00Z"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceKMSKey().Schema, map[string]interface{}{
+		"name":          "vault-unseal",
+		"region":        "GRA",
+		"rotation_days": 90,
+	})
+
+	if diags := resourceKMSKeyCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if body["algorithm"] != "AES-256" || body["rotationDays"] != float64(90) {
+		t.Errorf("expected an AES-256 key rotated every 90 days, got %v", body)
+	}
+	if got := d.Get("key_id").(string); got != "kms-123" {
+		t.Errorf("expected key_id kms-123, got %q", got)
+	}
+	if got := d.Get("created_at").(string); got != "2026-10-01T10:00:00Z" {
+		t.Errorf("unexpected created_at %q", got)
+	}
+}
+
+// TestKMSKeyUpdate_rotationDays checks that the rotation period is updated
+// in place
+func TestKMSKeyUpdate_rotationDays(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "kms-123", "name": "vault-unseal", "region": "GRA", "algorithm": "AES-256", "rotationDays": 30}`, nil)
+
+	r := resourceKMSKey()
+	state := &sdkterraform.InstanceState{
+		ID: "kms-123",
+		Attributes: map[string]string{
+			"id":            "kms-123",
+			"name":          "vault-unseal",
+			"region":        "GRA",
+			"algorithm":     "AES-256",
+			"rotation_days": "90",
+		},
+	}
+	raw := map[string]interface{}{
+		"name":          "vault-unseal",
+		"region":        "GRA",
+		"rotation_days": 30,
+	}
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("expected a rotation_days change to update the key in place")
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	if diags := resourceKMSKeyUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.Requests[0]; got.Method != http.MethodPut || got.URL.Path != "/cloud/project/kms/key/kms-123" {
+		t.Errorf("expected the key to be updated, got %s %s", got.Method, got.URL.Path)
+	}
+	if body := mock.RequestBodies[0]; body != `{"rotationDays":30}` {
+		t.Errorf("unexpected update body %s", body)
+	}
+}
@@ -10,60 +10,93 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const defaultNomadGPUType = "nvidia-t4"
+
+var nomadGPUTypes = []string{
+	"nvidia-t4", "nvidia-l4", "nvidia-l40s", "nvidia-v100", "nvidia-v100s", "nvidia-a10", "nvidia-a100", "nvidia-h100",
+}
+
+// nomadClusterUpdateKeys are the attributes resourceNomadClusterUpdate sends
+// to the cluster besides tags, which it can update on their own.
+var nomadClusterUpdateKeys = []string{
+	"server_count", "client_count", "region_distribution", "autoscaling",
+	"security_groups", "ui_allowed_cidrs", "custom_domain", "maintenance_window",
+	"vault_token", "consul_token", "kata", "kata_containers", "gpu",
+	"gpu_support", "web3", "web3_enabled", "instance_tags",
+}
+
 func resourceNomadCluster() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages a Nomad cluster on OVH infrastructure with enterprise features",
 
 		CreateContext: resourceNomadClusterCreate,
 		ReadContext:   resourceNomadClusterRead,
-		UpdateContext: resourceNomadClusterUpdate,
+		UpdateContext: keepStateOnDryRun(resourceNomadClusterUpdate),
 		DeleteContext: resourceNomadClusterDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: resourceNomadClusterCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("/cloud/project/nomad/cluster", "Nomad cluster"),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the Nomad cluster",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Nomad cluster",
+				ValidateFunc: validateClusterName,
 			},
 			"region": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "OVH region for the cluster",
-				ValidateFunc: validation.StringInSlice([]string{
-					"GRA", "SBG", "RBX", "BHS", "WAW", "DE", "UK", "SGP", "SYD", "US-EAST", "US-WEST",
-				}, false),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "OVH region for the cluster, the primary region when region_distribution is set",
+				ExactlyOneOf: []string{"region", "region_distribution"},
 			},
+			"project_id": projectIdSchema(),
 			"server_count": {
 				Type:         schema.TypeInt,
-				Required:     true,
-				Description:  "Number of Nomad server nodes",
-				ValidateFunc: validation.IntBetween(1, 5),
+				Optional:     true,
+				Computed:     true,
+				Description:  "Number of Nomad server nodes, an odd number is recommended for Raft quorum. The total over all regions when region_distribution is set",
+				ValidateFunc: validateIntBetween(1, maxRaftServers),
+				ExactlyOneOf: []string{"server_count", "region_distribution"},
 			},
 			"client_count": {
-				Type:         schema.TypeInt,
-				Required:     true,
-				Description:  "Number of Nomad client nodes",
-				ValidateFunc: validation.IntBetween(0, 100),
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Number of Nomad client nodes, defaults to 0. The total over all regions when region_distribution is set",
+				ValidateFunc:  validateIntBetween(0, maxClusterClients),
+				ConflictsWith: []string{"region_distribution"},
 			},
+			"region_distribution": regionDistributionSchema(),
+			"drain_on_scale_in":   drainOnScaleInSchema(),
+			"drain_timeout":       drainTimeoutSchema(),
 			"instance_type": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "OVH instance type for cluster nodes",
+				Optional:    true,
+				Computed:    true,
+				Description: "OVH instance type for cluster nodes, defaults to the provider default_instance_type",
 				ValidateFunc: validation.StringInSlice([]string{
 					"s1-2", "s1-4", "s1-8", "c2-7", "c2-15", "c2-30", "c2-60", "c2-120",
 					"r2-15", "r2-30", "r2-60", "r2-120", "t1-45", "t1-90", "t1-180",
 				}, false),
 			},
 			"datacenter": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Nomad datacenter name",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Nomad datacenter name, defaults to the lowercased region with characters other than letters, numbers, hyphens and underscores replaced by hyphens",
+				ValidateFunc: validateDatacenter,
 			},
 			"vault_integration": {
 				Type:        schema.TypeBool,
@@ -77,6 +110,44 @@ func resourceNomadCluster() *schema.Resource {
 				Default:     true,
 				Description: "Enable Consul integration for service discovery",
 			},
+			"vault_cluster_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of an existing Vault cluster to integrate with, requires vault_integration. When unset, a Vault cluster is provisioned or discovered",
+			},
+			"consul_cluster_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of an existing Consul cluster to integrate with, requires consul_integration. When unset, a Consul cluster is provisioned or discovered",
+			},
+			"vault_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Vault token Nomad uses for the Vault integration, requires vault_integration. When unset, a token is requested from OVH",
+			},
+			"consul_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Consul ACL token Nomad uses for the Consul integration, requires consul_integration. When unset, a token is requested from OVH",
+			},
+			"vault_integration_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the Vault integration as reported by OVH",
+			},
+			"consul_integration_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the Consul integration as reported by OVH",
+			},
 			"acl_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -90,31 +161,174 @@ func resourceNomadCluster() *schema.Resource {
 				Description: "Enable TLS encryption",
 			},
 			"web3_enabled": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Enable Web3 blockchain integration",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable Web3 blockchain integration",
+				Deprecated:    "Use the web3 block instead",
+				ConflictsWith: []string{"web3"},
 			},
+			"web3": web3Schema("web3_enabled"),
 			"kata_containers": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Enable Kata containers for secure workloads",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable Kata containers for secure workloads",
+				Deprecated:    "Use the kata block instead",
+				ConflictsWith: []string{"kata"},
+			},
+			"kata": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Kata Containers runtime configuration for secure workloads",
+				ConflictsWith: []string{"kata_containers"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Enable the Kata Containers runtime on client nodes",
+						},
+						"runtime_class": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "kata",
+							Description: "Runtime class name jobs use to select the Kata runtime",
+						},
+						"hypervisor": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Hypervisor backing Kata sandboxes, either qemu or firecracker",
+							ValidateFunc: validation.StringInSlice([]string{"qemu", "firecracker"}, false),
+						},
+					},
+				},
 			},
 			"gpu_support": {
-				Type:        schema.TypeBool,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable GPU support for ML workloads",
+				Deprecated:    "Use the gpu block instead",
+				ConflictsWith: []string{"gpu"},
+			},
+			"gpu": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Dedicated GPU client pool for ML workloads",
+				ConflictsWith: []string{"gpu_support"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Provision the GPU client pool",
+						},
+						"gpu_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      defaultNomadGPUType,
+							Description:  "GPU model attached to each node, such as nvidia-t4 or nvidia-a100",
+							ValidateFunc: validation.StringInSlice(nomadGPUTypes, false),
+						},
+						"gpus_per_node": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							Description:  "Number of GPUs attached to each node",
+							ValidateFunc: validateIntBetween(1, 8),
+						},
+						"node_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							Description:  "Number of nodes in the GPU client pool",
+							ValidateFunc: validateIntBetween(1, 100),
+						},
+					},
+				},
+			},
+			"autoscaling": {
+				Type:        schema.TypeList,
 				Optional:    true,
-				Default:     false,
-				Description: "Enable GPU support for ML workloads",
+				MaxItems:    1,
+				Description: "Autoscaling configuration for Nomad client nodes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Enable client autoscaling",
+						},
+						"min_count": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "Minimum number of Nomad client nodes",
+							ValidateFunc: validateIntBetween(0, 100),
+						},
+						"max_count": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "Maximum number of Nomad client nodes",
+							ValidateFunc: validateIntBetween(0, 100),
+						},
+						"target_cpu_percent": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      70,
+							Description:  "Average client CPU utilization the autoscaler aims for",
+							ValidateFunc: validateIntBetween(1, 100),
+						},
+						"scale_in_cooldown": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "5m",
+							Description:  "Minimum time between two scale-in operations, as a duration such as 5m",
+							ValidateFunc: validateDuration,
+						},
+					},
+				},
 			},
-			"tags": {
-				Type:        schema.TypeMap,
+			"security_groups": {
+				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "Tags to apply to cluster resources",
+				Description: "Security group IDs or names to attach to cluster nodes",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"ui_allowed_cidrs":      uiAllowedCidrsSchema(),
+			"custom_domain":         customDomainSchema(),
+			"custom_endpoint":       customEndpointSchema(),
+			"connection_info":       connectionInfoSchema(),
+			"ca_certificate":        caCertificateSchema(),
+			"client_certificate":    clientCertificateSchema(),
+			"client_key":            clientKeySchema(),
+			"user_data":             userDataSchema(),
+			"additional_volumes":    additionalVolumesSchema(),
+			"maintenance_window":    maintenanceWindowSchema(),
+			"pending_create_steps":  pendingCreateStepsSchema(),
+			"planned_action":        plannedActionSchema(),
+			"recreate_if_unhealthy": recreateIfUnhealthySchema(),
+			"error_on_existing":     errorOnExistingSchema(),
+			"replacement_strategy":  replacementStrategySchema(),
+			"previous_cluster_id":   previousClusterIdSchema(),
+			"previous_endpoint":     previousEndpointSchema(),
+			"ready":                 readySchema(),
+			"ready_at":              readyAtSchema(),
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable deletion protection and detach dependent resources before deleting the cluster",
+			},
+			"tags":          tagsSchema(),
+			"instance_tags": instanceTagsSchema(),
 			"server_endpoints": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -123,11 +337,38 @@ func resourceNomadCluster() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"gpu_node_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the nodes in the GPU client pool",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"current_client_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of Nomad client nodes currently running",
+			},
 			"ui_url": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Nomad UI URL",
 			},
+			"default_security_group_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the security group created by OVH for the cluster",
+			},
+			"nodes":                           clusterNodesSchema(),
+			"region_nodes":                    regionNodesSchema(),
+			"user_data_hash":                  userDataHashSchema(),
+			"volume_ids":                      volumeIdsSchema(),
+			"last_operation_id":               lastOperationIdSchema(),
+			"next_maintenance_at":             nextMaintenanceAtSchema(),
+			"estimated_monthly_cost":          estimatedMonthlyCostSchema(),
+			"estimated_monthly_cost_currency": estimatedMonthlyCostCurrencySchema(),
+			"config_json":                     configJsonSchema(),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -150,7 +391,14 @@ func resourceNomadClusterCreate(ctx context.Context, d *schema.ResourceData, met
 	region := d.Get("region").(string)
 	serverCount := d.Get("server_count").(int)
 	clientCount := d.Get("client_count").(int)
-	instanceType := d.Get("instance_type").(string)
+	instanceType, err := config.instanceType(d.Get("instance_type").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := config.projectID(d.Get("project_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	datacenter := d.Get("datacenter").(string)
 
 	clusterConfig := map[string]interface{}{
@@ -164,26 +412,97 @@ func resourceNomadClusterCreate(ctx context.Context, d *schema.ResourceData, met
 		"consulIntegration": d.Get("consul_integration").(bool),
 		"aclEnabled":        d.Get("acl_enabled").(bool),
 		"tlsEnabled":        d.Get("tls_enabled").(bool),
-		"web3Enabled":       d.Get("web3_enabled").(bool),
-		"kataContainers":    d.Get("kata_containers").(bool),
-		"gpuSupport":        d.Get("gpu_support").(bool),
+		"securityGroups":    d.Get("security_groups").(*schema.Set).List(),
+		"uiAllowedCidrs":    d.Get("ui_allowed_cidrs"),
+		"customDomain":      d.Get("custom_domain").(string),
+		"additionalVolumes": expandAdditionalVolumes(d.Get("additional_volumes").([]interface{})),
 		"tags":              d.Get("tags"),
+		"instanceTags":      d.Get("instance_tags"),
+	}
+	if projectId != "" {
+		clusterConfig["projectId"] = projectId
+	}
+
+	addLegacyBlock(clusterConfig, "web3", "web3Enabled", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_enabled").(bool)), false)
+
+	if autoscaling := expandNomadAutoscaling(d.Get("autoscaling").([]interface{})); autoscaling != nil {
+		clusterConfig["autoscaling"] = autoscaling
+	}
+
+	addLegacyBlock(clusterConfig, "kata", "kataContainers", expandNomadKata(d.Get("kata").([]interface{}), d.Get("kata_containers").(bool)), false)
+
+	addLegacyBlock(clusterConfig, "gpu", "gpuSupport", expandNomadGPU(d.Get("gpu").([]interface{}), d.Get("gpu_support").(bool)), false)
+
+	if vaultClusterId := d.Get("vault_cluster_id").(string); vaultClusterId != "" {
+		if err := checkClusterReady(config, "vault", vaultClusterId); err != nil {
+			return diag.FromErr(fmt.Errorf("invalid vault_cluster_id: %w", err))
+		}
+		clusterConfig["vaultClusterId"] = vaultClusterId
+	}
+
+	if consulClusterId := d.Get("consul_cluster_id").(string); consulClusterId != "" {
+		if err := checkClusterReady(config, "consul", consulClusterId); err != nil {
+			return diag.FromErr(fmt.Errorf("invalid consul_cluster_id: %w", err))
+		}
+		clusterConfig["consulClusterId"] = consulClusterId
+	}
+
+	if vaultToken := d.Get("vault_token").(string); vaultToken != "" {
+		clusterConfig["vaultToken"] = vaultToken
+	}
+	if consulToken := d.Get("consul_token").(string); consulToken != "" {
+		clusterConfig["consulToken"] = consulToken
+	}
+
+	if window := expandMaintenanceWindow(d.Get("maintenance_window").([]interface{})); window != nil {
+		clusterConfig["maintenanceWindow"] = window
 	}
 
-	var result map[string]interface{}
-	err := config.OVHClient.Post("/cloud/project/nomad/cluster", clusterConfig, &result)
+	if distribution := expandRegionDistribution(d.Get("region_distribution").([]interface{})); distribution != nil {
+		clusterConfig["regionDistribution"] = distribution
+	}
+
+	setUserData(d, clusterConfig)
+
+	setPreviousCluster(ctx, config, d, "nomad", projectId)
+	if diags := checkExistingCluster(ctx, config, d, "nomad", "Nomad", projectId); diags.HasError() {
+		return diags
+	}
+	if diags := checkInstanceQuota(ctx, config, d, "Nomad", projectId, "server_count", "client_count"); diags.HasError() {
+		return diags
+	}
+
+	result, err := createCluster(ctx, config, d, "nomad", projectId, clusterConfig)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create Nomad cluster: %w", err))
+		return createClusterError("Nomad", projectId, err)
 	}
 
-	clusterId := result["id"].(string)
+	clusterId, err := createdClusterId("Nomad", result)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId(clusterId)
+	setConfigJson(d, clusterConfig)
+	operationId := setLastOperationId(d, result)
+
+	// An error here would taint the cluster and the next apply would replace
+	// it, so keep it in state with a warning and let the next apply resume
+	// waiting through Update instead.
+	if err := waitForClusterOperation(ctx, config, "nomad", clusterId, operationId); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  "Nomad cluster is not ready yet",
+				Detail:   fmt.Sprintf("Nomad cluster %s was created but did not become READY: %s. It has been kept in state, run terraform apply again to resume waiting for it.", clusterId, err),
+			},
+		}
+	}
 
-	if err := waitForClusterReady(ctx, config, clusterId); err != nil {
-		return diag.FromErr(fmt.Errorf("cluster creation timeout: %w", err))
+	if err := requestNomadIntegrationTokens(config, d, clusterId); err != nil {
+		return resumeCreateLater(d, "Nomad", nomadStepIntegrationTokens, err)
 	}
 
-	return resourceNomadClusterRead(ctx, d, meta)
+	return readAfterCreate(ctx, d, meta, "Nomad", resourceNomadClusterRead)
 }
 
 func resourceNomadClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -198,29 +517,70 @@ func resourceNomadClusterRead(ctx context.Context, d *schema.ResourceData, meta
 		d.SetId("")
 		return diag.FromErr(fmt.Errorf("failed to read Nomad cluster: %w", err))
 	}
+	if err := completeClusterNodes(config, "nomad", clusterId, cluster); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read nodes of Nomad cluster: %w", err))
+	}
 
-	d.Set("name", cluster["name"])
-	d.Set("region", cluster["region"])
-	d.Set("server_count", cluster["serverCount"])
-	d.Set("client_count", cluster["clientCount"])
-	d.Set("instance_type", cluster["instanceType"])
-	d.Set("datacenter", cluster["datacenter"])
-	d.Set("vault_integration", cluster["vaultIntegration"])
-	d.Set("consul_integration", cluster["consulIntegration"])
-	d.Set("acl_enabled", cluster["aclEnabled"])
-	d.Set("tls_enabled", cluster["tlsEnabled"])
-	d.Set("web3_enabled", cluster["web3Enabled"])
-	d.Set("kata_containers", cluster["kataContainers"])
-	d.Set("gpu_support", cluster["gpuSupport"])
-	d.Set("server_endpoints", cluster["serverEndpoints"])
-	d.Set("ui_url", cluster["uiUrl"])
-	d.Set("status", cluster["status"])
-	d.Set("created_at", cluster["createdAt"])
-
-	if tags, ok := cluster["tags"].(map[string]interface{}); ok {
-		d.Set("tags", tags)
+	d.Set("name", getString(cluster, "name"))
+	d.Set("region", getString(cluster, "region"))
+	d.Set("server_count", getInt(cluster, "serverCount"))
+	d.Set("client_count", getInt(cluster, "clientCount"))
+	d.Set("instance_type", getString(cluster, "instanceType"))
+	setProjectID(d, cluster)
+	d.Set("datacenter", getString(cluster, "datacenter"))
+	d.Set("vault_integration", getBool(cluster, "vaultIntegration"))
+	d.Set("consul_integration", getBool(cluster, "consulIntegration"))
+	d.Set("vault_cluster_id", getString(cluster, "vaultClusterId"))
+	d.Set("consul_cluster_id", getString(cluster, "consulClusterId"))
+	setWriteOnceString(d, "vault_token", cluster, "vaultToken")
+	setWriteOnceString(d, "consul_token", cluster, "consulToken")
+	d.Set("vault_integration_status", getString(cluster, "vaultIntegrationStatus"))
+	d.Set("consul_integration_status", getString(cluster, "consulIntegrationStatus"))
+	d.Set("acl_enabled", getBool(cluster, "aclEnabled"))
+	d.Set("tls_enabled", getBool(cluster, "tlsEnabled"))
+	d.Set("web3_enabled", getBool(cluster, "web3Enabled"))
+	setLegacyBlock(d, "web3", "web3_enabled", cluster, flattenWeb3)
+	d.Set("kata_containers", getBool(cluster, "kataContainers"))
+	d.Set("gpu_support", getBool(cluster, "gpuSupport"))
+	d.Set("current_client_count", getInt(cluster, "currentClientCount"))
+	d.Set("server_endpoints", getStringList(cluster, "serverEndpoints"))
+	d.Set("ui_url", getString(cluster, "uiUrl"))
+	d.Set("status", getString(cluster, "status"))
+	setClusterReadiness(d, "nomad", cluster)
+	// Pending create steps are only known to the provider, so they are kept.
+	d.Set("pending_create_steps", d.Get("pending_create_steps"))
+	d.Set("nodes", flattenClusterNodes(cluster))
+	d.Set("region_nodes", flattenRegionNodes(cluster))
+	setRegionDistribution(d, cluster)
+	setAdditionalVolumes(d, cluster)
+	setMaintenanceWindow(d, cluster)
+	setEstimatedMonthlyCost(d, config, "server_count", "client_count")
+	d.Set("security_groups", getStringList(cluster, "securityGroups"))
+	d.Set("ui_allowed_cidrs", getStringList(cluster, "uiAllowedCidrs"))
+	d.Set("custom_domain", getString(cluster, "customDomain"))
+	d.Set("custom_endpoint", getString(cluster, "customEndpoint"))
+	setConnectionInfo(d, "nomad", cluster)
+	refreshPreviousCluster(config, d, "nomad")
+	setTLSCertificates(config, d, "nomad", clusterId)
+	d.Set("default_security_group_id", getString(cluster, "defaultSecurityGroupId"))
+	d.Set("created_at", getString(cluster, "createdAt"))
+
+	// A disabled autoscaler is only reflected back when the block is configured,
+	// otherwise clusters without the block would show a perpetual diff.
+	if autoscaling, ok := cluster["autoscaling"].(map[string]interface{}); ok {
+		if getBool(autoscaling, "enabled") || len(d.Get("autoscaling").([]interface{})) > 0 {
+			d.Set("autoscaling", flattenNomadAutoscaling(autoscaling))
+		}
 	}
 
+	setLegacyBlock(d, "kata", "kata_containers", cluster, flattenNomadKata)
+
+	setLegacyBlock(d, "gpu", "gpu_support", cluster, flattenNomadGPU)
+	d.Set("gpu_node_ids", getStringList(cluster, "gpuNodeIds"))
+
+	d.Set("tags", flattenTags(cluster))
+	d.Set("instance_tags", flattenInstanceTags(cluster))
+
 	return nil
 }
 
@@ -230,7 +590,24 @@ func resourceNomadClusterUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	clusterId := d.Id()
 
-	if d.HasChanges("server_count", "client_count", "tags") {
+	// Resume waiting for a cluster whose create timed out.
+	if old, _ := d.GetChange("status"); old.(string) != "READY" {
+		if err := waitForClusterReady(ctx, config, "nomad", clusterId); err != nil {
+			return diag.FromErr(fmt.Errorf("cluster creation timeout: %w", err))
+		}
+	}
+
+	// Pending create steps are resumed after a full update, so they rule out
+	// the tags endpoint.
+	if hasOnlyTagChanges(d, nomadClusterUpdateKeys...) && !d.HasChange("pending_create_steps") {
+		if err := updateClusterTags(ctx, config, "nomad", clusterId, d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Nomad cluster tags: %w", err))
+		}
+		mergeConfigJson(d, map[string]interface{}{"tags": d.Get("tags")})
+		return resourceNomadClusterRead(ctx, d, meta)
+	}
+
+	if d.HasChange("tags") || d.HasChanges(nomadClusterUpdateKeys...) {
 		updateConfig := map[string]interface{}{}
 
 		if d.HasChange("server_count") {
@@ -238,21 +615,86 @@ func resourceNomadClusterUpdate(ctx context.Context, d *schema.ResourceData, met
 		}
 		if d.HasChange("client_count") {
 			updateConfig["clientCount"] = d.Get("client_count").(int)
+
+			// When the drain or the update fails, the previous client_count
+			// is kept so that the next apply scales in again.
+			drainedNodes, err := drainClientsOnScaleIn(ctx, config, d, "nomad")
+			if err != nil {
+				d.Partial(true)
+				return diag.FromErr(fmt.Errorf("failed to update Nomad cluster: %w", err))
+			}
+			if len(drainedNodes) > 0 {
+				updateConfig["removedNodes"] = drainedNodes
+			}
+		}
+		if d.HasChange("region_distribution") {
+			updateConfig["regionDistribution"] = expandRegionDistribution(d.Get("region_distribution").([]interface{}))
+		}
+		if d.HasChange("autoscaling") {
+			autoscaling := expandNomadAutoscaling(d.Get("autoscaling").([]interface{}))
+			if autoscaling == nil {
+				autoscaling = map[string]interface{}{"enabled": false}
+			}
+			updateConfig["autoscaling"] = autoscaling
+		}
+		if d.HasChange("security_groups") {
+			updateConfig["securityGroups"] = d.Get("security_groups").(*schema.Set).List()
+		}
+		if d.HasChange("ui_allowed_cidrs") {
+			updateConfig["uiAllowedCidrs"] = d.Get("ui_allowed_cidrs")
+		}
+		if d.HasChange("custom_domain") {
+			updateConfig["customDomain"] = d.Get("custom_domain").(string)
+		}
+		if d.HasChange("maintenance_window") {
+			updateConfig["maintenanceWindow"] = expandMaintenanceWindow(d.Get("maintenance_window").([]interface{}))
+		}
+		if vaultToken := d.Get("vault_token").(string); d.HasChange("vault_token") && vaultToken != "" {
+			updateConfig["vaultToken"] = vaultToken
+		}
+		if consulToken := d.Get("consul_token").(string); d.HasChange("consul_token") && consulToken != "" {
+			updateConfig["consulToken"] = consulToken
+		}
+		if d.HasChanges("kata", "kata_containers") {
+			addLegacyBlock(updateConfig, "kata", "kataContainers", expandNomadKata(d.Get("kata").([]interface{}), d.Get("kata_containers").(bool)), true)
+		}
+		if d.HasChanges("gpu", "gpu_support") {
+			addLegacyBlock(updateConfig, "gpu", "gpuSupport", expandNomadGPU(d.Get("gpu").([]interface{}), d.Get("gpu_support").(bool)), true)
+		}
+		if d.HasChanges("web3", "web3_enabled") {
+			addLegacyBlock(updateConfig, "web3", "web3Enabled", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_enabled").(bool)), true)
 		}
 		if d.HasChange("tags") {
 			updateConfig["tags"] = d.Get("tags")
 		}
+		if d.HasChange("instance_tags") {
+			updateConfig["instanceTags"] = d.Get("instance_tags")
+		}
 
-		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/nomad/cluster/%s", clusterId), updateConfig, nil)
+		result, err := updateCluster(ctx, config, "nomad", clusterId, updateConfig, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
+			d.Partial(true)
 			return diag.FromErr(fmt.Errorf("failed to update Nomad cluster: %w", err))
 		}
+		operationId := setLastOperationId(d, result)
+		mergeConfigJson(d, updateConfig)
 
-		if err := waitForClusterReady(ctx, config, clusterId); err != nil {
-			return diag.FromErr(fmt.Errorf("cluster update timeout: %w", err))
+		if d.HasChange("tags") {
+			if err := deleteRemovedTags(config, fmt.Sprintf("/cloud/project/nomad/cluster/%s", clusterId), d); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update Nomad cluster: %w", err))
+			}
+		}
+
+		if err := waitForClusterOperation(ctx, config, "nomad", clusterId, operationId); err != nil {
+			return diag.FromErr(fmt.Errorf("cluster update failed: %w", err))
 		}
 	}
 
+	if err := requestNomadIntegrationTokens(config, d, clusterId); err != nil {
+		d.Set("pending_create_steps", []string{nomadStepIntegrationTokens})
+		return diag.FromErr(fmt.Errorf("failed to update Nomad cluster: %w", err))
+	}
+
 	return resourceNomadClusterRead(ctx, d, meta)
 }
 
@@ -262,7 +704,7 @@ func resourceNomadClusterDelete(ctx context.Context, d *schema.ResourceData, met
 
 	clusterId := d.Id()
 
-	err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/nomad/cluster/%s", clusterId), nil)
+	err := deleteCluster(ctx, config, "nomad", clusterId, d.Get("force_destroy").(bool), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete Nomad cluster: %w", err))
 	}
@@ -271,27 +713,200 @@ func resourceNomadClusterDelete(ctx context.Context, d *schema.ResourceData, met
 	return nil
 }
 
-func waitForClusterReady(ctx context.Context, config *Config, clusterId string) error {
-	timeout := time.After(30 * time.Minute)
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-timeout:
-			return fmt.Errorf("timeout waiting for cluster to be ready")
-		case <-ticker.C:
-			var cluster map[string]interface{}
-			err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/nomad/cluster/%s", clusterId), &cluster)
-			if err != nil {
-				continue
-			}
+func resourceNomadClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := planRegionDistribution(d, 0); err != nil {
+		return err
+	}
 
-			if status, ok := cluster["status"].(string); ok && status == "READY" {
-				return nil
-			}
-		case <-ctx.Done():
-			return ctx.Err()
+	if err := validateAdditionalVolumes(d); err != nil {
+		return err
+	}
+
+	if err := setDefaultDatacenter(d); err != nil {
+		return err
+	}
+
+	// A cluster left in state before becoming READY plans an update so the
+	// next apply resumes waiting for it.
+	if d.Id() != "" && d.Get("status").(string) != "READY" {
+		if err := d.SetNewComputed("status"); err != nil {
+			return err
+		}
+	}
+
+	if err := planPendingCreateSteps(d); err != nil {
+		return err
+	}
+
+	if err := planRecreateIfUnhealthy(d); err != nil {
+		return err
+	}
+
+	if err := planBlueGreenReplacement(d); err != nil {
+		return err
+	}
+
+	if err := planConfigJson(d, nomadClusterUpdateKeys...); err != nil {
+		return err
+	}
+
+	if err := validateNomadIntegrationClusters(d); err != nil {
+		return err
+	}
+
+	if err := validateNomadIntegrationTokens(d); err != nil {
+		return err
+	}
+
+	if err := planNodeCountChange(ctx, d, "Nomad", "server_count", "server_count", "client_count"); err != nil {
+		return err
+	}
+
+	if err := planInstanceQuota(ctx, d, meta, "Nomad", "server_count", "client_count"); err != nil {
+		return err
+	}
+
+	// The GPU pool is rebuilt when its settings change, so its nodes are
+	// only known after the update.
+	if d.Id() != "" && d.HasChanges("gpu", "gpu_support") {
+		if err := d.SetNewComputed("gpu_node_ids"); err != nil {
+			return err
+		}
+	}
+
+	if kata := d.Get("kata").([]interface{}); len(kata) > 0 && kata[0] != nil {
+		raw := kata[0].(map[string]interface{})
+		if !raw["enabled"].(bool) && raw["hypervisor"].(string) != "" {
+			return fmt.Errorf("kata hypervisor can only be set when kata enabled is true")
+		}
+	}
+
+	if err := validateNomadAutoscaling(d); err != nil {
+		return err
+	}
+
+	return planEstimatedMonthlyCost(d, meta, "server_count", "client_count")
+}
+
+// validateNomadAutoscaling checks that an enabled autoscaling block bounds
+// client_count, which the autoscaler owns once it is enabled.
+func validateNomadAutoscaling(d *schema.ResourceDiff) error {
+	autoscaling := d.Get("autoscaling").([]interface{})
+	if len(autoscaling) == 0 || autoscaling[0] == nil {
+		return nil
+	}
+
+	raw := autoscaling[0].(map[string]interface{})
+	if !raw["enabled"].(bool) {
+		return nil
+	}
+
+	clientCount := d.Get("client_count").(int)
+	minCount := raw["min_count"].(int)
+	maxCount := raw["max_count"].(int)
+
+	if minCount > maxCount {
+		return fmt.Errorf("autoscaling min_count (%d) must be less than or equal to max_count (%d)", minCount, maxCount)
+	}
+	if clientCount < minCount || clientCount > maxCount {
+		return fmt.Errorf("client_count (%d) must be between autoscaling min_count (%d) and max_count (%d)", clientCount, minCount, maxCount)
+	}
+
+	// Once the autoscaler owns the client pool, client_count is only the
+	// initial size; changing it afterwards would fight the autoscaler.
+	if d.Id() != "" && d.HasChange("client_count") {
+		old, _ := d.GetChange("autoscaling")
+		if oldList := old.([]interface{}); len(oldList) > 0 && oldList[0] != nil && oldList[0].(map[string]interface{})["enabled"].(bool) {
+			return fmt.Errorf("client_count cannot be changed while autoscaling is enabled, adjust min_count and max_count instead")
+		}
+	}
+
+	return nil
+}
+
+func expandNomadAutoscaling(l []interface{}) map[string]interface{} {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	raw := l[0].(map[string]interface{})
+	return map[string]interface{}{
+		"enabled":          raw["enabled"].(bool),
+		"minCount":         raw["min_count"].(int),
+		"maxCount":         raw["max_count"].(int),
+		"targetCpuPercent": raw["target_cpu_percent"].(int),
+		"scaleInCooldown":  raw["scale_in_cooldown"].(string),
+	}
+}
+
+func flattenNomadAutoscaling(autoscaling map[string]interface{}) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":            getBool(autoscaling, "enabled"),
+			"min_count":          getInt(autoscaling, "minCount"),
+			"max_count":          getInt(autoscaling, "maxCount"),
+			"target_cpu_percent": getInt(autoscaling, "targetCpuPercent"),
+			"scale_in_cooldown":  getString(autoscaling, "scaleInCooldown"),
+		},
+	}
+}
+
+// expandNomadKata builds the kata payload from the kata block, falling back to
+// the legacy kata_containers flag when the block is not set.
+func expandNomadKata(l []interface{}, legacyEnabled bool) map[string]interface{} {
+	return expandLegacyBlock(l, legacyEnabled, map[string]interface{}{"enabled": true}, func(raw map[string]interface{}) map[string]interface{} {
+		kata := map[string]interface{}{
+			"enabled":      raw["enabled"].(bool),
+			"runtimeClass": raw["runtime_class"].(string),
+		}
+		if hypervisor := raw["hypervisor"].(string); hypervisor != "" {
+			kata["hypervisor"] = hypervisor
 		}
+		return kata
+	})
+}
+
+func flattenNomadKata(kata map[string]interface{}) []interface{} {
+	runtimeClass := getString(kata, "runtimeClass")
+	if runtimeClass == "" {
+		runtimeClass = "kata"
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":       getBool(kata, "enabled"),
+			"runtime_class": runtimeClass,
+			"hypervisor":    getString(kata, "hypervisor"),
+		},
+	}
+}
+
+// expandNomadGPU builds the GPU pool payload from the gpu block. The legacy
+// gpu_support flag maps to a single node pool of the default GPU type.
+func expandNomadGPU(l []interface{}, legacyEnabled bool) map[string]interface{} {
+	legacy := map[string]interface{}{
+		"enabled":     true,
+		"gpuType":     defaultNomadGPUType,
+		"gpusPerNode": 1,
+		"nodeCount":   1,
+	}
+	return expandLegacyBlock(l, legacyEnabled, legacy, func(raw map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"enabled":     raw["enabled"].(bool),
+			"gpuType":     raw["gpu_type"].(string),
+			"gpusPerNode": raw["gpus_per_node"].(int),
+			"nodeCount":   raw["node_count"].(int),
+		}
+	})
+}
+
+func flattenNomadGPU(gpu map[string]interface{}) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":       getBool(gpu, "enabled"),
+			"gpu_type":      getString(gpu, "gpuType"),
+			"gpus_per_node": getInt(gpu, "gpusPerNode"),
+			"node_count":    getInt(gpu, "nodeCount"),
+		},
 	}
 }
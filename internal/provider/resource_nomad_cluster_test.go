@@ -1,28 +1,37 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 // TestAccNomadCluster_basic tests basic Nomad cluster creation
 func TestAccNomadCluster_basic(t *testing.T) {
+	config := testAccGetClient(t)
 	resourceName := "hashicorp_ovh_nomad_cluster.test"
 	clusterName := "test-nomad-cluster"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		CheckDestroy:             testAccCheckNomadClusterDestroy,
+		CheckDestroy:             testAccCheckNomadClusterDestroy(config),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNomadClusterConfig_basic(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
 					resource.TestCheckResourceAttr(resourceName, "region", "eu-west-1"),
 					resource.TestCheckResourceAttr(resourceName, "server_count", "3"),
@@ -40,18 +49,19 @@ func TestAccNomadCluster_basic(t *testing.T) {
 
 // TestAccNomadCluster_update tests Nomad cluster updates
 func TestAccNomadCluster_update(t *testing.T) {
+	config := testAccGetClient(t)
 	resourceName := "hashicorp_ovh_nomad_cluster.test"
 	clusterName := "test-nomad-cluster-update"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		CheckDestroy:             testAccCheckNomadClusterDestroy,
+		CheckDestroy:             testAccCheckNomadClusterDestroy(config),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNomadClusterConfig_basic(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
 					resource.TestCheckResourceAttr(resourceName, "server_count", "3"),
 					resource.TestCheckResourceAttr(resourceName, "client_count", "5"),
@@ -60,7 +70,7 @@ func TestAccNomadCluster_update(t *testing.T) {
 			{
 				Config: testAccNomadClusterConfig_updated(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
 					resource.TestCheckResourceAttr(resourceName, "server_count", "5"),
 					resource.TestCheckResourceAttr(resourceName, "client_count", "8"),
@@ -73,18 +83,19 @@ func TestAccNomadCluster_update(t *testing.T) {
 
 // TestAccNomadCluster_withTags tests Nomad cluster with tags
 func TestAccNomadCluster_withTags(t *testing.T) {
+	config := testAccGetClient(t)
 	resourceName := "hashicorp_ovh_nomad_cluster.test"
 	clusterName := "test-nomad-cluster-tags"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		CheckDestroy:             testAccCheckNomadClusterDestroy,
+		CheckDestroy:             testAccCheckNomadClusterDestroy(config),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNomadClusterConfig_withTags(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "test"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Team", "platform"),
@@ -94,7 +105,7 @@ func TestAccNomadCluster_withTags(t *testing.T) {
 			{
 				Config: testAccNomadClusterConfig_withUpdatedTags(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "staging"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Team", "devops"),
 					resource.TestCheckResourceAttr(resourceName, "tags.Owner", "john.doe"),
@@ -107,18 +118,19 @@ func TestAccNomadCluster_withTags(t *testing.T) {
 
 // TestAccNomadCluster_minimalConfig tests minimal configuration
 func TestAccNomadCluster_minimalConfig(t *testing.T) {
+	config := testAccGetClient(t)
 	resourceName := "hashicorp_ovh_nomad_cluster.test"
 	clusterName := "test-nomad-minimal"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		CheckDestroy:             testAccCheckNomadClusterDestroy,
+		CheckDestroy:             testAccCheckNomadClusterDestroy(config),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNomadClusterConfig_minimal(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", clusterName),
 					resource.TestCheckResourceAttr(resourceName, "region", "eu-west-1"),
 					// Check default values
@@ -140,15 +152,15 @@ func TestAccNomadCluster_invalidConfiguration(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccNomadClusterConfig_invalidServerCount(),
-				ExpectError: regexp.MustCompile("server_count must be between 1 and 10"),
+				ExpectError: regexp.MustCompile("server_count must be between 1 and 7"),
 			},
 			{
 				Config:      testAccNomadClusterConfig_invalidClientCount(),
 				ExpectError: regexp.MustCompile("client_count must be between 0 and 100"),
 			},
 			{
-				Config:      testAccNomadClusterConfig_invalidRegion(),
-				ExpectError: regexp.MustCompile("invalid region"),
+				Config:      testAccNomadClusterConfig_invalidDatacenter(),
+				ExpectError: regexp.MustCompile("datacenter must be 1 to 64 lowercase letters, numbers, hyphens or underscores"),
 			},
 			{
 				Config:      testAccNomadClusterConfig_invalidName(),
@@ -160,19 +172,20 @@ func TestAccNomadCluster_invalidConfiguration(t *testing.T) {
 
 // TestAccNomadCluster_disappears tests resource disappears scenario
 func TestAccNomadCluster_disappears(t *testing.T) {
+	config := testAccGetClient(t)
 	resourceName := "hashicorp_ovh_nomad_cluster.test"
 	clusterName := "test-nomad-disappears"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		CheckDestroy:             testAccCheckNomadClusterDestroy,
+		CheckDestroy:             testAccCheckNomadClusterDestroy(config),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNomadClusterConfig_basic(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
-					testAccCheckNomadClusterDisappears(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
+					testAccCheckNomadClusterDisappears(config, resourceName),
 				),
 				ExpectNonEmptyPlan: true,
 			},
@@ -182,18 +195,19 @@ func TestAccNomadCluster_disappears(t *testing.T) {
 
 // TestAccNomadCluster_import tests resource import functionality
 func TestAccNomadCluster_import(t *testing.T) {
+	config := testAccGetClient(t)
 	resourceName := "hashicorp_ovh_nomad_cluster.test"
 	clusterName := "test-nomad-import"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		CheckDestroy:             testAccCheckNomadClusterDestroy,
+		CheckDestroy:             testAccCheckNomadClusterDestroy(config),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNomadClusterConfig_basic(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 				),
 			},
 			{
@@ -211,21 +225,23 @@ func TestAccNomadCluster_import(t *testing.T) {
 
 // TestAccNomadCluster_withSecurityGroups tests security group configuration
 func TestAccNomadCluster_withSecurityGroups(t *testing.T) {
+	config := testAccGetClient(t)
 	resourceName := "hashicorp_ovh_nomad_cluster.test"
 	clusterName := "test-nomad-sg"
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-		CheckDestroy:             testAccCheckNomadClusterDestroy,
+		CheckDestroy:             testAccCheckNomadClusterDestroy(config),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccNomadClusterConfig_withSecurityGroups(clusterName),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckNomadClusterExists(resourceName),
+					testAccCheckNomadClusterExists(config, resourceName),
 					resource.TestCheckResourceAttr(resourceName, "security_groups.#", "2"),
 					resource.TestCheckTypeSetElemAttr(resourceName, "security_groups.*", "sg-nomad-servers"),
 					resource.TestCheckTypeSetElemAttr(resourceName, "security_groups.*", "sg-nomad-clients"),
+					resource.TestCheckResourceAttrSet(resourceName, "default_security_group_id"),
 				),
 			},
 		},
@@ -234,8 +250,8 @@ func TestAccNomadCluster_withSecurityGroups(t *testing.T) {
 
 // Helper functions for test checks
 
-func testAccCheckNomadClusterExists(resourceName string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
+func testAccCheckNomadClusterExists(config *Config, resourceName string) resource.TestCheckFunc {
+	return RetryCheck(func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
 		if !ok {
 			return fmt.Errorf("resource not found: %s", resourceName)
@@ -245,43 +261,24 @@ func testAccCheckNomadClusterExists(resourceName string) resource.TestCheckFunc
 			return fmt.Errorf("no Nomad cluster ID is set")
 		}
 
-		// Here you would typically make an API call to verify the resource exists
-		// For now, we'll assume the resource exists if it has an ID
-		return nil
-	}
-}
-
-func testAccCheckNomadClusterDestroy(s *terraform.State) error {
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "hashicorp_ovh_nomad_cluster" {
-			continue
+		var cluster map[string]interface{}
+		if err := config.OVHClient.Get(fmt.Sprintf("/cloud/project/nomad/cluster/%s", rs.Primary.ID), &cluster); err != nil {
+			return fmt.Errorf("failed to read Nomad cluster %s: %w", rs.Primary.ID, err)
 		}
-
-		// Here you would typically make an API call to verify the resource is destroyed
-		// For now, we'll check that the ID is cleared
-		if rs.Primary.ID != "" {
-			return fmt.Errorf("Nomad cluster %s still exists", rs.Primary.ID)
+		if status := getString(cluster, "status"); status != "READY" {
+			return fmt.Errorf("Nomad cluster %s is %s, expected READY", rs.Primary.ID, status)
 		}
-	}
 
-	return nil
+		return nil
+	}, DefaultCheckAttempts, DefaultCheckDelay)
 }
 
-func testAccCheckNomadClusterDisappears(resourceName string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[resourceName]
-		if !ok {
-			return fmt.Errorf("resource not found: %s", resourceName)
-		}
-
-		if rs.Primary.ID == "" {
-			return fmt.Errorf("no Nomad cluster ID is set")
-		}
+func testAccCheckNomadClusterDestroy(config *Config) resource.TestCheckFunc {
+	return TestAccCheckResourceDestroy(config, "hashicorp_ovh_nomad_cluster")
+}
 
-		// Here you would typically make an API call to delete the resource
-		// simulating it disappearing outside of Terraform
-		return nil
-	}
+func testAccCheckNomadClusterDisappears(config *Config, resourceName string) resource.TestCheckFunc {
+	return TestAccCheckResourceDisappears(config, resourceName)
 }
 
 // Test configuration functions
@@ -360,10 +357,12 @@ resource "hashicorp_ovh_nomad_cluster" "test" {
 func testAccNomadClusterConfig_withSecurityGroups(name string) string {
 	return fmt.Sprintf(`
 resource "hashicorp_ovh_nomad_cluster" "test" {
-  name         = "%s"
-  region       = "eu-west-1"
-  server_count = 3
-  client_count = 5
+  name          = "%s"
+  region        = "GRA"
+  server_count  = 3
+  client_count  = 5
+  instance_type = "c2-15"
+  datacenter    = "dc1"
 
   security_groups = [
     "sg-nomad-servers",
@@ -395,13 +394,14 @@ resource "hashicorp_ovh_nomad_cluster" "test" {
 `
 }
 
-func testAccNomadClusterConfig_invalidRegion() string {
+func testAccNomadClusterConfig_invalidDatacenter() string {
 	return `
 resource "hashicorp_ovh_nomad_cluster" "test" {
   name         = "test-invalid"
-  region       = "invalid-region"
+  region       = "eu-west-1"
   server_count = 3
   client_count = 5
+  datacenter   = "DC 1"  # Invalid: uppercase and space
 }
 `
 }
@@ -417,8 +417,812 @@ resource "hashicorp_ovh_nomad_cluster" "test" {
 `
 }
 
+// TestNomadClusterRead_numericFields checks that JSON numbers decoded as
+// float64 are stored as ints and do not produce a diff against the config
+func TestNomadClusterRead_numericFields(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "id": "nomad-123",
+  "name": "test-nomad",
+  "region": "GRA",
+  "serverCount": 3,
+  "clientCount": 5,
+  "instanceType": "c2-15",
+  "datacenter": "dc1",
+  "vaultIntegration": true,
+  "consulIntegration": true,
+  "aclEnabled": true,
+  "tlsEnabled": true,
+  "web3Enabled": false,
+  "kataContainers": false,
+  "gpuSupport": false,
+  "serverEndpoints": ["10.0.0.1:4646"],
+  "securityGroups": ["sg-nomad-servers", "sg-nomad-clients"],
+  "defaultSecurityGroupId": "sg-default-123",
+  "status": "READY"
+}`, nil)
+
+	raw := map[string]interface{}{
+		"name":          "test-nomad",
+		"region":        "GRA",
+		"server_count":  3,
+		"client_count":  5,
+		"instance_type": "c2-15",
+		"datacenter":    "dc1",
+		"security_groups": []interface{}{
+			"sg-nomad-servers",
+			"sg-nomad-clients",
+		},
+	}
+
+	r := resourceNomadCluster()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId("nomad-123")
+
+	if diags := resourceNomadClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	serverCount, ok := d.Get("server_count").(int)
+	if !ok || serverCount != 3 {
+		t.Errorf("expected server_count to be int 3, got %#v", d.Get("server_count"))
+	}
+	if got := d.Get("security_groups").(*schema.Set).Len(); got != 2 {
+		t.Errorf("expected 2 security groups, got %d", got)
+	}
+	if got := d.Get("default_security_group_id").(string); got != "sg-default-123" {
+		t.Errorf("expected default_security_group_id sg-default-123, got %q", got)
+	}
+	if got := d.State().Attributes["server_count"]; got != "3" {
+		t.Errorf("expected server_count state value 3, got %q", got)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff after read, got %#v", diff.Attributes)
+	}
+}
+
+// TestNomadClusterRead_stringBooleans checks that booleans returned as
+// strings or 0/1 numbers are coerced rather than read as false
+func TestNomadClusterRead_stringBooleans(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "id": "nomad-123",
+  "name": "test-nomad",
+  "region": "GRA",
+  "serverCount": 3,
+  "vaultIntegration": "true",
+  "consulIntegration": "false",
+  "aclEnabled": 1,
+  "tlsEnabled": "TRUE",
+  "web3Enabled": 0,
+  "kataContainers": true,
+  "gpuSupport": "false",
+  "status": "READY"
+}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, map[string]interface{}{
+		"name":         "test-nomad",
+		"region":       "GRA",
+		"server_count": 3,
+	})
+	d.SetId("nomad-123")
+
+	if diags := resourceNomadClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := map[string]bool{
+		"vault_integration":  true,
+		"consul_integration": false,
+		"acl_enabled":        true,
+		"tls_enabled":        true,
+		"web3_enabled":       false,
+		"kata_containers":    true,
+		"gpu_support":        false,
+	}
+	for key, want := range expected {
+		if got := d.Get(key).(bool); got != want {
+			t.Errorf("expected %s to be %t, got %t", key, want, got)
+		}
+	}
+}
+
+// TestNomadCluster_autoscalingBounds checks that client_count must fall
+// within the autoscaling bounds
+func TestNomadCluster_autoscalingBounds(t *testing.T) {
+	cases := map[string]struct {
+		clientCount int
+		minCount    int
+		maxCount    int
+		expectError bool
+	}{
+		"within bounds":     {clientCount: 3, minCount: 2, maxCount: 10},
+		"below min_count":   {clientCount: 1, minCount: 2, maxCount: 10, expectError: true},
+		"above max_count":   {clientCount: 12, minCount: 2, maxCount: 10, expectError: true},
+		"min above max":     {clientCount: 5, minCount: 8, maxCount: 4, expectError: true},
+		"equal to boundary": {clientCount: 10, minCount: 2, maxCount: 10},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":          "test-nomad",
+				"region":        "GRA",
+				"server_count":  3,
+				"client_count":  tc.clientCount,
+				"instance_type": "c2-15",
+				"datacenter":    "dc1",
+				"autoscaling": []interface{}{
+					map[string]interface{}{
+						"min_count": tc.minCount,
+						"max_count": tc.maxCount,
+					},
+				},
+			}
+
+			_, err := resourceNomadCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestNomadCluster_serverCountValidation checks the server_count bounds and
+// the error message asserted by the acceptance tests
+func TestNomadCluster_serverCountValidation(t *testing.T) {
+	for serverCount, valid := range map[int]bool{1: true, 3: true, 7: true, 0: false, 8: false, 15: false} {
+		raw := map[string]interface{}{
+			"name":          "test-nomad",
+			"region":        "GRA",
+			"server_count":  serverCount,
+			"client_count":  3,
+			"instance_type": "c2-15",
+			"datacenter":    "dc1",
+		}
+
+		diags := resourceNomadCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+		if valid && diags.HasError() {
+			t.Errorf("server_count %d: unexpected errors: %v", serverCount, diags)
+		}
+		if !valid {
+			if !diags.HasError() {
+				t.Errorf("server_count %d: expected an error", serverCount)
+				continue
+			}
+			if !regexp.MustCompile("server_count must be between 1 and 7").MatchString(diags[0].Summary) {
+				t.Errorf("server_count %d: unexpected error: %s", serverCount, diags[0].Summary)
+			}
+		}
+	}
+}
+
 // Unit tests for resource logic will be added when resources are implemented
 
 // TODO: Add resource schema tests when nomadClusterResource is implemented
-// TODO: Add validation tests when resource validation is implemented  
+// TODO: Add validation tests when resource validation is implemented
 // TODO: Add benchmark tests when resource operations are implemented
+
+// TestNomadCluster_datacenterDefault checks that datacenter defaults to a
+// name derived from the region that validateDatacenter accepts, and that an
+// explicit value is preserved
+func TestNomadCluster_datacenterDefault(t *testing.T) {
+	cases := map[string]struct {
+		region     string
+		datacenter string
+		expected   string
+	}{
+		"omitted":         {region: "GRA", expected: "gra"},
+		"explicit":        {region: "GRA", datacenter: "dc1", expected: "dc1"},
+		"hyphenated":      {region: "eu-west-1", expected: "eu-west-1"},
+		"other character": {region: "US.EAST 1", expected: "us-east-1"},
+		"leading hyphen":  {region: "-GRA", expected: "gra"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":          "test-nomad",
+				"region":        tc.region,
+				"server_count":  3,
+				"client_count":  3,
+				"instance_type": "c2-15",
+			}
+			if tc.datacenter != "" {
+				raw["datacenter"] = tc.datacenter
+			}
+
+			diff, err := resourceNomadCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := diff.Attributes["datacenter"].New
+			if got != tc.expected {
+				t.Errorf("expected datacenter %q, got %q", tc.expected, got)
+			}
+			if _, errs := validateDatacenter(got, "datacenter"); len(errs) > 0 {
+				t.Errorf("expected the default datacenter to be valid, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestNomadCluster_datacenterValidation(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":          "test-nomad",
+		"region":        "GRA",
+		"server_count":  3,
+		"client_count":  3,
+		"instance_type": "c2-15",
+		"datacenter":    "DC 1",
+	}
+
+	diags := resourceNomadCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() {
+		t.Fatal("expected an error for an invalid datacenter")
+	}
+}
+
+func TestExpandNomadKata(t *testing.T) {
+	if got := expandNomadKata(nil, false); got != nil {
+		t.Errorf("expected no kata payload, got %v", got)
+	}
+
+	if got := expandNomadKata(nil, true); !reflect.DeepEqual(got, map[string]interface{}{"enabled": true}) {
+		t.Errorf("expected the legacy flag to map to enabled kata, got %v", got)
+	}
+
+	block := []interface{}{
+		map[string]interface{}{
+			"enabled":       true,
+			"runtime_class": "kata-fc",
+			"hypervisor":    "firecracker",
+		},
+	}
+	expected := map[string]interface{}{
+		"enabled":      true,
+		"runtimeClass": "kata-fc",
+		"hypervisor":   "firecracker",
+	}
+	if got := expandNomadKata(block, false); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+// TestNomadCluster_kataValidation checks the hypervisor values and that a
+// hypervisor is only accepted when kata is enabled
+func TestNomadCluster_kataValidation(t *testing.T) {
+	cases := map[string]struct {
+		kata        map[string]interface{}
+		expectError bool
+	}{
+		"qemu":                   {kata: map[string]interface{}{"hypervisor": "qemu"}},
+		"unknown hypervisor":     {kata: map[string]interface{}{"hypervisor": "xen"}, expectError: true},
+		"hypervisor disabled":    {kata: map[string]interface{}{"enabled": false, "hypervisor": "qemu"}, expectError: true},
+		"disabled no hypervisor": {kata: map[string]interface{}{"enabled": false}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":          "test-nomad",
+				"region":        "GRA",
+				"server_count":  3,
+				"client_count":  3,
+				"instance_type": "c2-15",
+				"kata":          []interface{}{tc.kata},
+			}
+			config := sdkterraform.NewResourceConfigRaw(raw)
+
+			if diags := resourceNomadCluster().Validate(config); diags.HasError() {
+				if !tc.expectError {
+					t.Errorf("unexpected validation error: %v", diags)
+				}
+				return
+			}
+
+			_, err := resourceNomadCluster().Diff(context.Background(), nil, config, nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestExpandNomadGPU(t *testing.T) {
+	expected := map[string]interface{}{
+		"enabled":     true,
+		"gpuType":     "nvidia-t4",
+		"gpusPerNode": 1,
+		"nodeCount":   1,
+	}
+	if got := expandNomadGPU(nil, true); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the legacy flag to map to %v, got %v", expected, got)
+	}
+	if got := expandNomadGPU(nil, false); got != nil {
+		t.Errorf("expected no GPU payload, got %v", got)
+	}
+}
+
+func TestNomadCluster_gpuValidation(t *testing.T) {
+	cases := map[string]struct {
+		gpu         map[string]interface{}
+		expectError bool
+	}{
+		"a100 pool":        {gpu: map[string]interface{}{"gpu_type": "nvidia-a100", "gpus_per_node": 4, "node_count": 2}},
+		"unknown gpu_type": {gpu: map[string]interface{}{"gpu_type": "nvidia-gtx1080"}, expectError: true},
+		"too many gpus":    {gpu: map[string]interface{}{"gpus_per_node": 9}, expectError: true},
+		"no gpus":          {gpu: map[string]interface{}{"gpus_per_node": 0}, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":          "test-nomad",
+				"region":        "GRA",
+				"server_count":  3,
+				"client_count":  3,
+				"instance_type": "c2-15",
+				"gpu":           []interface{}{tc.gpu},
+			}
+
+			diags := resourceNomadCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if tc.expectError && !diags.HasError() {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && diags.HasError() {
+				t.Errorf("unexpected error: %v", diags)
+			}
+		})
+	}
+}
+
+// TestNomadCluster_resumeAfterCreateTimeout checks that a cluster whose create
+// wait timed out stays in state and that the next apply waits for it to be READY
+func TestNomadCluster_resumeAfterCreateTimeout(t *testing.T) {
+	interval := clusterReadyPollInterval
+	clusterReadyPollInterval = 10 * time.Millisecond
+	defer func() { clusterReadyPollInterval = interval }()
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+	config := mock.NewConfig(t)
+
+	raw := map[string]interface{}{
+		"name":          "test-nomad",
+		"region":        "GRA",
+		"server_count":  3,
+		"client_count":  3,
+		"instance_type": "c2-15",
+	}
+
+	mock.AddResponse(200, `{"id": "nomad-123"}`, nil)
+	for i := 0; i < 100; i++ {
+		mock.AddResponse(200, `{"id": "nomad-123", "status": "PROVISIONING"}`, nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, raw)
+	diags := resourceNomadClusterCreate(ctx, d, config)
+	if diags.HasError() {
+		t.Fatalf("expected a warning rather than an error, got: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got: %v", diags)
+	}
+	if d.Id() != "nomad-123" {
+		t.Fatalf("expected the cluster to be kept in state, got ID %q", d.Id())
+	}
+
+	// The resumed apply waits, then pushes the planned fields, waits again and
+	// requests the integration tokens the create did not get to.
+	mock.Responses = nil
+	mock.AddResponse(200, `{"id": "nomad-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"token": "vault-token"}`, nil)
+	mock.AddResponse(200, `{"token": "consul-token"}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "name": "test-nomad", "status": "READY"}`, nil)
+
+	d = schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, raw)
+	d.SetId("nomad-123")
+	if diags := resourceNomadClusterUpdate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("status").(string); got != "READY" {
+		t.Errorf("expected status READY after resuming, got %q", got)
+	}
+	if got := d.Get("vault_token").(string); got != "vault-token" {
+		t.Errorf("expected the Vault integration token to be requested, got %q", got)
+	}
+}
+
+// TestNomadCluster_integrationClusterIds checks that an integration cluster ID
+// requires the matching integration to be enabled
+func TestNomadCluster_integrationClusterIds(t *testing.T) {
+	cases := map[string]struct {
+		values      map[string]interface{}
+		expectError bool
+	}{
+		"vault cluster":                 {values: map[string]interface{}{"vault_cluster_id": "vault-123"}},
+		"consul cluster":                {values: map[string]interface{}{"consul_cluster_id": "consul-123"}},
+		"vault cluster without vault":   {values: map[string]interface{}{"vault_cluster_id": "vault-123", "vault_integration": false}, expectError: true},
+		"consul cluster without consul": {values: map[string]interface{}{"consul_cluster_id": "consul-123", "consul_integration": false}, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":          "test-nomad",
+				"region":        "GRA",
+				"server_count":  3,
+				"client_count":  3,
+				"instance_type": "c2-15",
+			}
+			for k, v := range tc.values {
+				raw[k] = v
+			}
+
+			_, err := resourceNomadCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestNomadCluster_discoveredIntegrationClusterIds checks that the
+// integration clusters OVH discovered do not plan a replacement when the
+// configuration does not set them
+func TestNomadCluster_discoveredIntegrationClusterIds(t *testing.T) {
+	state := &sdkterraform.InstanceState{
+		ID: "nomad-123",
+		Attributes: map[string]string{
+			"id":                "nomad-123",
+			"name":              "test-nomad",
+			"region":            "GRA",
+			"server_count":      "3",
+			"client_count":      "3",
+			"instance_type":     "c2-15",
+			"status":            "READY",
+			"vault_integration": "true",
+			"vault_token":       "vault-token",
+			"vault_cluster_id":  "vault-123",
+			"consul_cluster_id": "consul-123",
+		},
+	}
+	raw := testNomadClusterUpdateRaw()
+	raw["vault_integration"] = true
+
+	diff, err := resourceNomadCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("expected no replacement, got %v", diff)
+	}
+}
+
+// TestNomadCluster_integrationTokens checks that an integration token
+// requires the matching integration to be enabled
+func TestNomadCluster_integrationTokens(t *testing.T) {
+	cases := map[string]struct {
+		values      map[string]interface{}
+		expectError bool
+	}{
+		"vault token":                 {values: map[string]interface{}{"vault_token": "hvs.token"}},
+		"consul token":                {values: map[string]interface{}{"consul_token": "consul-token"}},
+		"vault token without vault":   {values: map[string]interface{}{"vault_token": "hvs.token", "vault_integration": false}, expectError: true},
+		"consul token without consul": {values: map[string]interface{}{"consul_token": "consul-token", "consul_integration": false}, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"name":          "test-nomad",
+				"region":        "GRA",
+				"server_count":  3,
+				"client_count":  3,
+				"instance_type": "c2-15",
+			}
+			for k, v := range tc.values {
+				raw[k] = v
+			}
+
+			_, err := resourceNomadCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestNomadClusterCreate_integrationTokens checks that a supplied token is
+// sent with the cluster while a missing one is requested from OVH
+func TestNomadClusterCreate_integrationTokens(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "operationId": "op-1"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"token": "consul-token"}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "name": "test-nomad", "status": "READY", "vaultIntegrationStatus": "CONNECTED", "consulToken": "****"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, map[string]interface{}{
+		"name":          "test-nomad",
+		"region":        "GRA",
+		"server_count":  3,
+		"client_count":  3,
+		"instance_type": "c2-15",
+		"vault_token":   "hvs.token",
+	})
+
+	if diags := resourceNomadClusterCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[1]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if body["vaultToken"] != "hvs.token" {
+		t.Errorf("expected the supplied Vault token to be sent, got %v", body["vaultToken"])
+	}
+	if _, ok := body["consulToken"]; ok {
+		t.Error("expected no Consul token to be sent")
+	}
+
+	if got := mock.Requests[3].URL.Path; got != "/cloud/project/nomad/cluster/nomad-123/integration/consul/token" {
+		t.Errorf("expected a Consul integration token to be requested, got %s", got)
+	}
+	if got := d.Get("consul_token").(string); got != "consul-token" {
+		t.Errorf("expected consul_token consul-token, got %q", got)
+	}
+	if got := d.Get("vault_integration_status").(string); got != "CONNECTED" {
+		t.Errorf("expected vault_integration_status CONNECTED, got %q", got)
+	}
+}
+
+// TestNomadClusterCreate_vaultClusterNotReady checks that creation stops
+// before the POST when the referenced Vault cluster is not READY
+func TestNomadClusterCreate_vaultClusterNotReady(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "status": "PROVISIONING"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadCluster().Schema, map[string]interface{}{
+		"name":             "test-nomad",
+		"region":           "GRA",
+		"server_count":     3,
+		"client_count":     3,
+		"instance_type":    "c2-15",
+		"vault_cluster_id": "vault-123",
+	})
+
+	diags := resourceNomadClusterCreate(context.Background(), d, mock.NewConfig(t))
+	if !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	if count := mock.GetRequestCount(); count != 1 {
+		t.Errorf("expected only the Vault cluster lookup, got %d requests", count)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no ID, got %q", d.Id())
+	}
+}
+
+// testNomadClusterUpdateData returns the resource data of an update of a READY
+// Nomad cluster without integrations, whose state holds attributes, to raw.
+func testNomadClusterUpdateData(t *testing.T, attributes map[string]string, raw map[string]interface{}) *schema.ResourceData {
+	r := resourceNomadCluster()
+	state := &sdkterraform.InstanceState{
+		ID: "nomad-123",
+		Attributes: map[string]string{
+			"id":                 "nomad-123",
+			"name":               "test-nomad",
+			"region":             "GRA",
+			"server_count":       "3",
+			"client_count":       "3",
+			"instance_type":      "c2-15",
+			"vault_integration":  "false",
+			"consul_integration": "false",
+			"status":             "READY",
+		},
+	}
+	for key, value := range attributes {
+		state.Attributes[key] = value
+	}
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+	return d
+}
+
+// testNomadClusterUpdateRaw is the configuration of the cluster of
+// testNomadClusterUpdateData.
+func testNomadClusterUpdateRaw() map[string]interface{} {
+	return map[string]interface{}{
+		"name":               "test-nomad",
+		"region":             "GRA",
+		"server_count":       3,
+		"client_count":       3,
+		"instance_type":      "c2-15",
+		"vault_integration":  false,
+		"consul_integration": false,
+	}
+}
+
+// testNomadClusterUpdateBody runs an update of d and returns the body of the
+// PUT it sent to the cluster.
+func testNomadClusterUpdateBody(t *testing.T, d *schema.ResourceData) map[string]interface{} {
+	t.Helper()
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "nomad-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-1"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "name": "test-nomad", "status": "READY"}`, nil)
+
+	if diags := resourceNomadClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for i, r := range mock.Requests {
+		if r.Method == http.MethodPut && r.URL.Path == "/cloud/project/nomad/cluster/nomad-123" {
+			var body map[string]interface{}
+			if err := json.Unmarshal([]byte(mock.RequestBodies[i]), &body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+			return body
+		}
+	}
+	t.Fatal("expected the cluster to be updated")
+	return nil
+}
+
+// TestNomadClusterUpdate_kata checks that changing the kata block, or
+// removing it, is sent to the cluster
+func TestNomadClusterUpdate_kata(t *testing.T) {
+	raw := testNomadClusterUpdateRaw()
+	raw["kata"] = []interface{}{map[string]interface{}{"hypervisor": "firecracker"}}
+	d := testNomadClusterUpdateData(t, nil, raw)
+
+	body := testNomadClusterUpdateBody(t, d)
+	kata, _ := body["kata"].(map[string]interface{})
+	if kata["enabled"] != true || kata["hypervisor"] != "firecracker" || body["kataContainers"] != true {
+		t.Errorf("expected the kata block to be sent, got %v", body)
+	}
+
+	d = testNomadClusterUpdateData(t, map[string]string{"kata_containers": "true"}, testNomadClusterUpdateRaw())
+	body = testNomadClusterUpdateBody(t, d)
+	kata, _ = body["kata"].(map[string]interface{})
+	if kata["enabled"] != false || body["kataContainers"] != false {
+		t.Errorf("expected removing kata_containers to disable kata, got %v", body)
+	}
+}
+
+// TestNomadClusterUpdate_gpu checks that changing the gpu block is sent to
+// the cluster and that the nodes of the pool are planned unknown
+func TestNomadClusterUpdate_gpu(t *testing.T) {
+	raw := testNomadClusterUpdateRaw()
+	raw["gpu"] = []interface{}{map[string]interface{}{"gpu_type": "nvidia-a100", "node_count": 2}}
+	d := testNomadClusterUpdateData(t, map[string]string{
+		"gpu_support":    "true",
+		"gpu_node_ids.#": "1",
+		"gpu_node_ids.0": "node-1",
+	}, raw)
+
+	body := testNomadClusterUpdateBody(t, d)
+	gpu, _ := body["gpu"].(map[string]interface{})
+	if gpu["enabled"] != true || gpu["gpuType"] != "nvidia-a100" || gpu["nodeCount"] != float64(2) || body["gpuSupport"] != true {
+		t.Errorf("expected the gpu block to be sent, got %v", body)
+	}
+
+	diff, err := resourceNomadCluster().Diff(context.Background(), &sdkterraform.InstanceState{
+		ID: "nomad-123",
+		Attributes: map[string]string{
+			"id":             "nomad-123",
+			"name":           "test-nomad",
+			"region":         "GRA",
+			"server_count":   "3",
+			"client_count":   "3",
+			"instance_type":  "c2-15",
+			"status":         "READY",
+			"gpu_support":    "true",
+			"gpu_node_ids.#": "1",
+			"gpu_node_ids.0": "node-1",
+		},
+	}, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if attr := diff.Attributes["gpu_node_ids.#"]; attr == nil || !attr.NewComputed {
+		t.Errorf("expected gpu_node_ids to be unknown after a gpu change, got %v", attr)
+	}
+}
+
+// TestNomadClusterUpdate_web3 checks that moving from web3_enabled to the
+// web3 block is sent to the cluster
+func TestNomadClusterUpdate_web3(t *testing.T) {
+	raw := testNomadClusterUpdateRaw()
+	raw["web3"] = []interface{}{map[string]interface{}{"chain_id": 137, "wallet_integration": true}}
+	d := testNomadClusterUpdateData(t, map[string]string{"web3_enabled": "true"}, raw)
+
+	body := testNomadClusterUpdateBody(t, d)
+	web3, _ := body["web3"].(map[string]interface{})
+	if web3["enabled"] != true || web3["chainId"] != float64(137) || web3["walletIntegration"] != true || body["web3Enabled"] != true {
+		t.Errorf("expected the web3 block to be sent, got %v", body)
+	}
+}
+
+// TestNomadClusterUpdate_tagsWithPendingCreateSteps checks that a tag change
+// does not skip the create steps pending on the cluster
+func TestNomadClusterUpdate_tagsWithPendingCreateSteps(t *testing.T) {
+	raw := testNomadClusterUpdateRaw()
+	raw["vault_integration"] = true
+	raw["tags"] = map[string]interface{}{"env": "prod"}
+	d := testNomadClusterUpdateData(t, map[string]string{
+		"vault_integration":      "true",
+		"pending_create_steps.#": "1",
+		"pending_create_steps.0": nomadStepIntegrationTokens,
+	}, raw)
+
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "nomad-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-1"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"token": "vault-token"}`, nil)
+	mock.AddResponse(200, `{"id": "nomad-123", "name": "test-nomad", "status": "READY"}`, nil)
+
+	if diags := resourceNomadClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	requested := false
+	for _, r := range mock.Requests {
+		requested = requested || (r.Method == http.MethodPost && r.URL.Path == "/cloud/project/nomad/cluster/nomad-123/integration/vault/token")
+	}
+	if !requested {
+		t.Error("expected the pending integration token to be requested")
+	}
+	if got := d.Get("vault_token"); got != "vault-token" {
+		t.Errorf("expected vault_token to be set, got %v", got)
+	}
+	if got := d.Get("pending_create_steps").([]interface{}); len(got) != 0 {
+		t.Errorf("expected no pending create steps, got %v", got)
+	}
+}
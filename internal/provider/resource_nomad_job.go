@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// nomadJobPollInterval is how often a job is polled while waiting for its
+// allocations to run, unless poll_interval is set on the provider.
+var nomadJobPollInterval = 10 * time.Second
+
+func resourceNomadJob() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a job on a Nomad cluster running on OVH infrastructure",
+
+		CreateContext: resourceNomadJobCreate,
+		ReadContext:   resourceNomadJobRead,
+		UpdateContext: keepStateOnDryRun(resourceNomadJobUpdate),
+		DeleteContext: resourceNomadJobDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Nomad cluster",
+			},
+			"jobspec": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Job specification in HCL or JSON. Changes are submitted to the running job",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"vars": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Values of the HCL2 variables declared in the jobspec",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"job_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the job, as declared in the jobspec",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the job, such as pending, running or dead",
+			},
+			"allocation_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of running allocations of the job",
+			},
+		},
+	}
+}
+
+func resourceNomadJobCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId := d.Get("cluster_id").(string)
+
+	jobId, err := registerNomadJob(config, d, clusterId)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to register Nomad job: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, jobId))
+
+	if err := waitForNomadJob(ctx, config, clusterId, jobId, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNomadJobRead(ctx, d, meta)
+}
+
+func resourceNomadJobRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, jobId, err := parseTwoPartID(d.Id(), "cluster_id", "job_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var job map[string]interface{}
+	err = config.OVHClient.Get(fmt.Sprintf("/cloud/project/nomad/cluster/%s/job/%s", clusterId, jobId), &job)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read Nomad job: %w", err))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("job_id", getString(job, "jobId"))
+	d.Set("status", getString(job, "status"))
+	d.Set("allocation_count", getInt(job, "allocationCount"))
+
+	return nil
+}
+
+// resourceNomadJobUpdate submits the changed jobspec, which Nomad rolls out
+// to the running job. A jobspec declaring another job ID registers a new job,
+// so the previous one is deregistered once the new one is running.
+func resourceNomadJobUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, jobId, err := parseTwoPartID(d.Id(), "cluster_id", "job_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("jobspec", "vars") {
+		newJobId, err := registerNomadJob(config, d, clusterId)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Nomad job: %w", err))
+		}
+
+		if newJobId != jobId {
+			d.SetId(fmt.Sprintf("%s/%s", clusterId, newJobId))
+		}
+		if err := waitForNomadJob(ctx, config, clusterId, newJobId, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if newJobId != jobId {
+			err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/nomad/cluster/%s/job/%s", clusterId, jobId), nil)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to deregister previous Nomad job %s: %w", jobId, err))
+			}
+		}
+	}
+
+	return resourceNomadJobRead(ctx, d, meta)
+}
+
+func resourceNomadJobDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, jobId, err := parseTwoPartID(d.Id(), "cluster_id", "job_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(fmt.Sprintf("/cloud/project/nomad/cluster/%s/job/%s", clusterId, jobId), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to deregister Nomad job: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// registerNomadJob submits the jobspec and vars of d to a Nomad cluster, and
+// returns the ID of the job it declares.
+func registerNomadJob(config *Config, d *schema.ResourceData, clusterId string) (string, error) {
+	jobConfig := map[string]interface{}{
+		"jobspec":   d.Get("jobspec").(string),
+		"variables": d.Get("vars").(map[string]interface{}),
+	}
+
+	var result map[string]interface{}
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/nomad/cluster/%s/job", clusterId), jobConfig, &result)
+	if err != nil {
+		return "", err
+	}
+
+	jobId := getString(result, "jobId")
+	if jobId == "" {
+		return "", fmt.Errorf("no job ID returned")
+	}
+	return jobId, nil
+}
+
+// waitForNomadJob polls a job until its latest deployment is successful, or
+// until it is running when it has no deployment, such as batch jobs. A failed
+// or cancelled deployment is returned as an error.
+func waitForNomadJob(ctx context.Context, config *Config, clusterId, jobId string, timeout time.Duration) error {
+	path := fmt.Sprintf("/cloud/project/nomad/cluster/%s/job/%s", clusterId, jobId)
+	last := "status was unknown"
+	return poll(ctx, fmt.Sprintf("Nomad job %s to run", jobId), timeout, config.pollInterval(nomadJobPollInterval), func() (string, error) {
+		var job map[string]interface{}
+		if err := config.OVHClient.Get(path, &job); err != nil {
+			return last, nil
+		}
+
+		status := getString(job, "status")
+		deployment, hasDeployment := job["deployment"].(map[string]interface{})
+		switch {
+		case !hasDeployment && status == "running":
+			return "", nil
+		case hasDeployment && getString(deployment, "status") == "successful":
+			return "", nil
+		case hasDeployment && (getString(deployment, "status") == "failed" || getString(deployment, "status") == "cancelled"):
+			return "", fmt.Errorf("deployment of Nomad job %s %s: %s", jobId, getString(deployment, "status"), getString(deployment, "statusDescription"))
+		}
+		last = fmt.Sprintf("status was %s", status)
+		return last, nil
+	})
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testNomadJobspec = `job "web" {
+  group "web" {
+    task "server" {
+      driver = "docker"
+    }
+  }
+}`
+
+// TestNomadJobCreate_waitsForDeployment checks that a job is registered with
+// its vars and polled until its deployment is successful
+func TestNomadJobCreate_waitsForDeployment(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"jobId": "web", "evaluationId": "eval-1"}`, nil)
+	mock.AddResponse(200, `{"jobId": "web", "status": "pending", "deployment": {"status": "running"}}`, nil)
+	mock.AddResponse(200, `{"jobId": "web", "status": "running", "deployment": {"status": "successful"}}`, nil)
+	mock.AddResponse(200, `{"jobId": "web", "status": "running", "allocationCount": 3}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadJob().Schema, map[string]interface{}{
+		"cluster_id": "nomad-123",
+		"jobspec":    testNomadJobspec,
+		"vars":       map[string]interface{}{"image": "nginx:1.27"},
+	})
+	config := mock.NewConfig(t)
+	config.PollInterval = time.Millisecond
+
+	if diags := resourceNomadJobCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "nomad-123/web" {
+		t.Errorf("expected ID nomad-123/web, got %q", d.Id())
+	}
+	if got := d.Get("status").(string); got != "running" {
+		t.Errorf("expected status running, got %q", got)
+	}
+	if got := d.Get("allocation_count").(int); got != 3 {
+		t.Errorf("expected 3 allocations, got %d", got)
+	}
+	if mock.GetRequestCount() != 4 {
+		t.Errorf("expected 4 requests, got %d", mock.GetRequestCount())
+	}
+	if body := mock.RequestBodies[0]; !strings.Contains(body, `"image":"nginx:1.27"`) {
+		t.Errorf("expected vars to be submitted, got %s", body)
+	}
+}
+
+// TestNomadJobCreate_failedDeployment checks that a failed deployment fails
+// the create and keeps the registered job in state
+func TestNomadJobCreate_failedDeployment(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"jobId": "web"}`, nil)
+	mock.AddResponse(200, `{"jobId": "web", "status": "running", "deployment": {"status": "failed", "statusDescription": "Failed due to progress deadline"}}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadJob().Schema, map[string]interface{}{
+		"cluster_id": "nomad-123",
+		"jobspec":    testNomadJobspec,
+	})
+	config := mock.NewConfig(t)
+	config.PollInterval = time.Millisecond
+
+	diags := resourceNomadJobCreate(context.Background(), d, config)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a failed deployment")
+	}
+	if summary := diags[0].Summary; !strings.Contains(summary, "progress deadline") {
+		t.Errorf("expected the deployment status description, got %q", summary)
+	}
+	if d.Id() != "nomad-123/web" {
+		t.Errorf("expected the registered job to stay in state, got ID %q", d.Id())
+	}
+}
+
+// TestNomadJobUpdate_renamedJob checks that a jobspec declaring another job
+// ID registers the new job before deregistering the previous one
+func TestNomadJobUpdate_renamedJob(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"jobId": "api"}`, nil)
+	mock.AddResponse(200, `{"jobId": "api", "status": "running"}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"jobId": "api", "status": "running", "allocationCount": 1}`, nil)
+
+	r := resourceNomadJob()
+	state := &sdkterraform.InstanceState{
+		ID: "nomad-123/web",
+		Attributes: map[string]string{
+			"id":         "nomad-123/web",
+			"cluster_id": "nomad-123",
+			"jobspec":    testNomadJobspec,
+			"job_id":     "web",
+		},
+	}
+	raw := map[string]interface{}{
+		"cluster_id": "nomad-123",
+		"jobspec":    strings.Replace(testNomadJobspec, `job "web"`, `job "api"`, 1),
+	}
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("expected a jobspec change to update the job in place")
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	config := mock.NewConfig(t)
+	config.PollInterval = time.Millisecond
+	if diags := resourceNomadJobUpdate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []struct{ method, path string }{
+		{http.MethodPost, "/cloud/project/nomad/cluster/nomad-123/job"},
+		{http.MethodGet, "/cloud/project/nomad/cluster/nomad-123/job/api"},
+		{http.MethodDelete, "/cloud/project/nomad/cluster/nomad-123/job/web"},
+		{http.MethodGet, "/cloud/project/nomad/cluster/nomad-123/job/api"},
+	}
+	if len(mock.Requests) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, want := range expected {
+		if got := mock.Requests[i]; got.Method != want.method || got.URL.Path != want.path {
+			t.Errorf("request %d: expected %s %s, got %s %s", i, want.method, want.path, got.Method, got.URL.Path)
+		}
+	}
+	if d.Id() != "nomad-123/api" {
+		t.Errorf("expected ID nomad-123/api, got %q", d.Id())
+	}
+}
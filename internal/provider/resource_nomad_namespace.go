@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNomadNamespace() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a namespace on a Nomad cluster running on OVH infrastructure",
+
+		CreateContext: resourceNomadNamespaceCreate,
+		ReadContext:   resourceNomadNamespaceRead,
+		UpdateContext: keepStateOnDryRun(resourceNomadNamespaceUpdate),
+		DeleteContext: resourceNomadNamespaceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNomadNamespaceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Nomad cluster",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the namespace",
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[a-zA-Z0-9-]{1,128}$`),
+					"namespace name must be 1-128 characters and contain only letters, numbers, and hyphens",
+				),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the namespace",
+			},
+			"quota": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the resource quota attached to the namespace",
+			},
+		},
+	}
+}
+
+func resourceNomadNamespaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+
+	namespaceConfig := map[string]interface{}{
+		"name":        name,
+		"description": d.Get("description").(string),
+		"quota":       d.Get("quota").(string),
+	}
+
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/nomad/cluster/%s/namespace", clusterId), namespaceConfig, nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create Nomad namespace: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, name))
+
+	return resourceNomadNamespaceRead(ctx, d, meta)
+}
+
+func resourceNomadNamespaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "namespace")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var namespace map[string]interface{}
+	err = config.OVHClient.Get(fmt.Sprintf("/cloud/project/nomad/cluster/%s/namespace/%s", clusterId, name), &namespace)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read Nomad namespace: %w", err))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("name", getString(namespace, "name"))
+	d.Set("description", getString(namespace, "description"))
+	d.Set("quota", getString(namespace, "quota"))
+
+	return nil
+}
+
+func resourceNomadNamespaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "namespace")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("description", "quota") {
+		updateConfig := map[string]interface{}{
+			"description": d.Get("description").(string),
+			"quota":       d.Get("quota").(string),
+		}
+
+		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/nomad/cluster/%s/namespace/%s", clusterId, name), updateConfig, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Nomad namespace: %w", err))
+		}
+	}
+
+	return resourceNomadNamespaceRead(ctx, d, meta)
+}
+
+func resourceNomadNamespaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "namespace")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(fmt.Sprintf("/cloud/project/nomad/cluster/%s/namespace/%s", clusterId, name), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete Nomad namespace: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceNomadNamespaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
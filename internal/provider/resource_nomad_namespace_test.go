@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestNomadNamespace_nameValidation(t *testing.T) {
+	cases := map[string]bool{
+		"team-a":         true,
+		"Prod01":         true,
+		"team_a":         false,
+		"team a":         false,
+		"":               false,
+		"ns.with.dots":   false,
+		"namespace-1234": true,
+	}
+
+	for name, valid := range cases {
+		raw := map[string]interface{}{
+			"cluster_id": "nomad-123",
+			"name":       name,
+		}
+
+		diags := resourceNomadNamespace().Validate(sdkterraform.NewResourceConfigRaw(raw))
+		if diags.HasError() == valid {
+			t.Errorf("name %q: expected valid=%t, got diagnostics %v", name, valid, diags)
+		}
+	}
+}
+
+func TestNomadNamespace_import(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNomadNamespace().Schema, map[string]interface{}{})
+	d.SetId("nomad-123/team-a")
+
+	results, err := resourceNomadNamespaceImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if got := results[0].Get("cluster_id").(string); got != "nomad-123" {
+		t.Errorf("expected cluster_id nomad-123, got %q", got)
+	}
+	if got := results[0].Get("name").(string); got != "team-a" {
+		t.Errorf("expected name team-a, got %q", got)
+	}
+
+	d.SetId("team-a")
+	if _, err := resourceNomadNamespaceImport(context.Background(), d, nil); err == nil {
+		t.Error("expected an error for an ID without a cluster_id")
+	}
+}
@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNomadQuota() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a resource quota specification on a Nomad cluster running on OVH infrastructure",
+
+		CreateContext: resourceNomadQuotaCreate,
+		ReadContext:   resourceNomadQuotaRead,
+		UpdateContext: keepStateOnDryRun(resourceNomadQuotaUpdate),
+		DeleteContext: resourceNomadQuotaDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNomadQuotaImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Nomad cluster",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the quota specification",
+			},
+			"limits": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Resource limits per region",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Nomad region the limit applies to",
+						},
+						"cpu": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "CPU limit in MHz",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"memory_mb": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "Memory limit in MB",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"used_cpu": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "CPU currently consumed by jobs under this quota, in MHz",
+			},
+			"used_memory_mb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Memory currently consumed by jobs under this quota, in MB",
+			},
+		},
+	}
+}
+
+func resourceNomadQuotaCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+
+	quotaConfig := map[string]interface{}{
+		"name":   name,
+		"limits": expandNomadQuotaLimits(d.Get("limits").([]interface{})),
+	}
+
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/nomad/cluster/%s/quota", clusterId), quotaConfig, nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create Nomad quota: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, name))
+
+	return resourceNomadQuotaRead(ctx, d, meta)
+}
+
+func resourceNomadQuotaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "quota_name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var quota map[string]interface{}
+	err = config.OVHClient.Get(fmt.Sprintf("/cloud/project/nomad/cluster/%s/quota/%s", clusterId, name), &quota)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read Nomad quota: %w", err))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("name", getString(quota, "name"))
+	d.Set("limits", flattenNomadQuotaLimits(quota["limits"]))
+	d.Set("used_cpu", getInt(quota, "usedCpu"))
+	d.Set("used_memory_mb", getInt(quota, "usedMemoryMb"))
+
+	return nil
+}
+
+func resourceNomadQuotaUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "quota_name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("limits") {
+		updateConfig := map[string]interface{}{
+			"limits": expandNomadQuotaLimits(d.Get("limits").([]interface{})),
+		}
+
+		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/nomad/cluster/%s/quota/%s", clusterId, name), updateConfig, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Nomad quota: %w", err))
+		}
+	}
+
+	return resourceNomadQuotaRead(ctx, d, meta)
+}
+
+func resourceNomadQuotaDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "quota_name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(fmt.Sprintf("/cloud/project/nomad/cluster/%s/quota/%s", clusterId, name), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete Nomad quota: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceNomadQuotaImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "quota_name")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func expandNomadQuotaLimits(l []interface{}) []map[string]interface{} {
+	limits := make([]map[string]interface{}, 0, len(l))
+	for _, item := range l {
+		raw, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		limits = append(limits, map[string]interface{}{
+			"region":   raw["region"].(string),
+			"cpu":      raw["cpu"].(int),
+			"memoryMb": raw["memory_mb"].(int),
+		})
+	}
+	return limits
+}
+
+func flattenNomadQuotaLimits(v interface{}) []interface{} {
+	items, ok := v.([]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+
+	limits := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		limit, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		limits = append(limits, map[string]interface{}{
+			"region":    getString(limit, "region"),
+			"cpu":       getInt(limit, "cpu"),
+			"memory_mb": getInt(limit, "memoryMb"),
+		})
+	}
+	return limits
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestNomadQuota_limitsMustBePositive(t *testing.T) {
+	raw := map[string]interface{}{
+		"cluster_id": "nomad-123",
+		"name":       "team-a",
+		"limits": []interface{}{
+			map[string]interface{}{
+				"region":    "global",
+				"cpu":       0,
+				"memory_mb": 1024,
+			},
+		},
+	}
+
+	diags := resourceNomadQuota().Validate(sdkterraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() {
+		t.Fatal("expected an error for a non-positive cpu limit")
+	}
+}
+
+func TestNomadQuotaRead_usage(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "name": "team-a",
+  "limits": [{"region": "global", "cpu": 4000, "memoryMb": 8192}],
+  "usedCpu": 1500,
+  "usedMemoryMb": 2048
+}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceNomadQuota().Schema, map[string]interface{}{})
+	d.SetId("nomad-123/team-a")
+
+	if diags := resourceNomadQuotaRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/nomad/cluster/nomad-123/quota/team-a" {
+		t.Errorf("unexpected request path: %s", got)
+	}
+	if got := d.Get("limits.0.memory_mb").(int); got != 8192 {
+		t.Errorf("expected memory_mb 8192, got %d", got)
+	}
+	if got := d.Get("used_cpu").(int); got != 1500 {
+		t.Errorf("expected used_cpu 1500, got %d", got)
+	}
+	if got := d.Get("used_memory_mb").(int); got != 2048 {
+		t.Errorf("expected used_memory_mb 2048, got %d", got)
+	}
+}
@@ -3,31 +3,48 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// packerTemplateUpdateKeys are the attributes resourcePackerTemplateUpdate
+// sends to the template besides tags, which it can update on their own.
+var packerTemplateUpdateKeys = []string{
+	"source_image", "builder", "builders", "provision", "provisioners",
+	"post_processor", "post_processors", "variables", "variable_spec",
+	"auto_build", "build_timeout", "register_image", "image_name",
+	"image_visibility",
+}
+
 func resourcePackerTemplate() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages a Packer template on OVH infrastructure for image building",
 
 		CreateContext: resourcePackerTemplateCreate,
 		ReadContext:   resourcePackerTemplateRead,
-		UpdateContext: resourcePackerTemplateUpdate,
+		UpdateContext: keepStateOnDryRun(resourcePackerTemplateUpdate),
 		DeleteContext: resourcePackerTemplateDelete,
+		CustomizeDiff: resourcePackerTemplateCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("/cloud/project/packer/template", "Packer template"),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the Packer template",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Packer template",
+				ValidateFunc: validateClusterName,
 			},
 			"region": {
 				Type:        schema.TypeString,
@@ -35,6 +52,7 @@ func resourcePackerTemplate() *schema.Resource {
 				ForceNew:    true,
 				Description: "OVH region for image building",
 			},
+			"project_id": projectIdSchema(),
 			"source_image": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -42,29 +60,99 @@ func resourcePackerTemplate() *schema.Resource {
 			},
 			"instance_type": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "OVH instance type for building",
+				Optional:    true,
+				Computed:    true,
+				Description: "OVH instance type for building, defaults to the provider default_instance_type",
+			},
+			"builder": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MinItems:     1,
+				Description:  "Packer builder",
+				AtLeastOneOf: []string{"builder", "builders"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Builder type, such as openstack or qemu",
+						},
+						"config": packerConfigSchema("builder"),
+					},
+				},
 			},
 			"builders": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "Packer builders configuration",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				Description:   "Packer builders configuration as JSON strings",
+				Deprecated:    "Use builder blocks instead",
+				ConflictsWith: []string{"builder"},
+				AtLeastOneOf:  []string{"builder", "builders"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
-			"provisioners": {
+			// Named provision as provisioner is a Terraform meta-argument.
+			"provision": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Packer provisioners configuration",
+				Description: "Packer provisioner, run in order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Provisioner type, such as shell or ansible",
+						},
+						"inline": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Commands run by the provisioner",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"script": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path of a script run by the provisioner",
+						},
+						"config": packerConfigSchema("provisioner"),
+					},
+				},
+			},
+			"provisioners": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "Packer provisioners configuration as JSON strings",
+				Deprecated:    "Use provision blocks instead",
+				ConflictsWith: []string{"provision"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
-			"post_processors": {
+			"post_processor": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Packer post-processors configuration",
+				Description: "Packer post-processor, run in order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Post-processor type, such as manifest or checksum",
+						},
+						"config": packerConfigSchema("post-processor"),
+					},
+				},
+			},
+			"post_processors": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Description:   "Packer post-processors configuration as JSON strings",
+				Deprecated:    "Use post_processor blocks instead",
+				ConflictsWith: []string{"post_processor"},
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -77,6 +165,7 @@ func resourcePackerTemplate() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"variable_spec": packerVariableSpecSchema(),
 			"auto_build": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -88,7 +177,7 @@ func resourcePackerTemplate() *schema.Resource {
 				Optional:     true,
 				Default:      3600,
 				Description:  "Build timeout in seconds",
-				ValidateFunc: validation.IntBetween(300, 7200),
+				ValidateFunc: validateIntBetween(300, 7200),
 			},
 			"web3_tools": {
 				Type:        schema.TypeBool,
@@ -102,6 +191,24 @@ func resourcePackerTemplate() *schema.Resource {
 				Default:     false,
 				Description: "Include Kata containers support",
 			},
+			"register_image": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Register the built image in the OVH image catalog",
+			},
+			"image_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the registered image, defaults to the template name",
+			},
+			"image_visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "private",
+				Description:  "Visibility of the registered image (private, shared)",
+				ValidateFunc: validation.StringInSlice([]string{"private", "shared"}, false),
+			},
 			"tags": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -125,11 +232,63 @@ func resourcePackerTemplate() *schema.Resource {
 				Computed:    true,
 				Description: "Generated image ID",
 			},
+			"artifacts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Images produced by the last build",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "OVH region the image was built in",
+						},
+						"image_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Image ID",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Image name",
+						},
+					},
+				},
+			},
+			"registered_image_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the images registered in the OVH image catalog, usable as source_image",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Template status",
 			},
+			"build_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the latest build, building while it is in progress",
+			},
+			"build_started_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the latest build started, in RFC 3339 format",
+			},
+			"build_finished_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the latest build finished, in RFC 3339 format. Empty while it is in progress",
+			},
+			"build_log_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the log of the latest build, streamed while it is in progress",
+			},
 		},
 	}
 }
@@ -138,24 +297,41 @@ func resourcePackerTemplateCreate(ctx context.Context, d *schema.ResourceData, m
 	config := meta.(*Config)
 	_ = diag.Diagnostics{}
 
+	instanceType, err := config.instanceType(d.Get("instance_type").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := config.projectID(d.Get("project_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	templateConfig := map[string]interface{}{
 		"name":           d.Get("name").(string),
 		"region":         d.Get("region").(string),
 		"sourceImage":    d.Get("source_image").(string),
-		"instanceType":   d.Get("instance_type").(string),
-		"builders":       d.Get("builders").([]interface{}),
-		"provisioners":   d.Get("provisioners").([]interface{}),
-		"postProcessors": d.Get("post_processors").([]interface{}),
+		"instanceType":   instanceType,
+		"builders":       expandPackerBuilders(d),
+		"provisioners":   expandPackerProvisioners(d),
+		"postProcessors": expandPackerPostProcessors(d),
 		"variables":      d.Get("variables"),
+		"variableSpec":   expandPackerVariableSpec(d.Get("variable_spec").([]interface{})),
 		"autoBuild":      d.Get("auto_build").(bool),
 		"buildTimeout":   d.Get("build_timeout").(int),
 		"web3Tools":      d.Get("web3_tools").(bool),
 		"kataSupport":    d.Get("kata_support").(bool),
 		"tags":           d.Get("tags"),
 	}
+	if projectId != "" {
+		templateConfig["projectId"] = projectId
+	}
+
+	if registration := expandPackerImageRegistration(d); registration != nil {
+		templateConfig["imageRegistration"] = registration
+	}
 
 	var result map[string]interface{}
-	err := config.OVHClient.Post("/cloud/project/packer/template", templateConfig, &result)
+	err = config.OVHClient.Post("/cloud/project/packer/template", templateConfig, &result)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to create Packer template: %w", err))
 	}
@@ -179,26 +355,40 @@ func resourcePackerTemplateRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(fmt.Errorf("failed to read Packer template: %w", err))
 	}
 
-	d.Set("name", template["name"])
-	d.Set("region", template["region"])
-	d.Set("source_image", template["sourceImage"])
-	d.Set("instance_type", template["instanceType"])
-	d.Set("builders", template["builders"])
-	d.Set("provisioners", template["provisioners"])
-	d.Set("post_processors", template["postProcessors"])
+	d.Set("name", getString(template, "name"))
+	d.Set("region", getString(template, "region"))
+	d.Set("source_image", getString(template, "sourceImage"))
+	d.Set("instance_type", getString(template, "instanceType"))
+	setProjectID(d, template)
+	d.Set("builder", flattenPackerBuilders(template))
+	d.Set("builders", getStringList(template, "builders"))
+	d.Set("provision", flattenPackerProvisioners(template))
+	d.Set("provisioners", getStringList(template, "provisioners"))
+	d.Set("post_processor", flattenPackerPostProcessors(template))
+	d.Set("post_processors", getStringList(template, "postProcessors"))
 	d.Set("variables", template["variables"])
-	d.Set("auto_build", template["autoBuild"])
-	d.Set("build_timeout", template["buildTimeout"])
-	d.Set("web3_tools", template["web3Tools"])
-	d.Set("kata_support", template["kataSupport"])
-	d.Set("template_id", template["templateId"])
-	d.Set("last_build_id", template["lastBuildId"])
-	d.Set("image_id", template["imageId"])
-	d.Set("status", template["status"])
-
-	if tags, ok := template["tags"].(map[string]interface{}); ok {
-		d.Set("tags", tags)
+	d.Set("variable_spec", flattenPackerVariableSpec(template))
+	d.Set("auto_build", getBool(template, "autoBuild"))
+	d.Set("build_timeout", getInt(template, "buildTimeout"))
+	d.Set("web3_tools", getBool(template, "web3Tools"))
+	d.Set("kata_support", getBool(template, "kataSupport"))
+	d.Set("template_id", getString(template, "templateId"))
+	d.Set("last_build_id", getString(template, "lastBuildId"))
+	d.Set("image_id", getString(template, "imageId"))
+	d.Set("artifacts", flattenPackerArtifacts(template))
+	d.Set("registered_image_ids", getStringList(template, "registeredImageIds"))
+
+	if registration, ok := template["imageRegistration"].(map[string]interface{}); ok {
+		d.Set("register_image", getBool(registration, "enabled"))
+		d.Set("image_name", getString(registration, "imageName"))
+		if visibility := getString(registration, "visibility"); visibility != "" {
+			d.Set("image_visibility", visibility)
+		}
 	}
+	d.Set("status", getString(template, "status"))
+	setPackerBuild(d, template)
+
+	d.Set("tags", flattenTags(template))
 
 	return nil
 }
@@ -209,30 +399,47 @@ func resourcePackerTemplateUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	templateId := d.Id()
 
-	if d.HasChanges("source_image", "builders", "provisioners", "post_processors", "variables", "auto_build", "build_timeout", "tags") {
+	if hasOnlyTagChanges(d, packerTemplateUpdateKeys...) {
+		if err := updateTags(config, fmt.Sprintf("/cloud/project/packer/template/%s", templateId), d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Packer template tags: %w", err))
+		}
+		return resourcePackerTemplateRead(ctx, d, meta)
+	}
+
+	if d.HasChange("tags") || d.HasChanges(packerTemplateUpdateKeys...) {
 		updateConfig := map[string]interface{}{}
 
 		if d.HasChange("source_image") {
 			updateConfig["sourceImage"] = d.Get("source_image").(string)
 		}
-		if d.HasChange("builders") {
-			updateConfig["builders"] = d.Get("builders").([]interface{})
+		if d.HasChanges("builder", "builders") {
+			updateConfig["builders"] = expandPackerBuilders(d)
 		}
-		if d.HasChange("provisioners") {
-			updateConfig["provisioners"] = d.Get("provisioners").([]interface{})
+		if d.HasChanges("provision", "provisioners") {
+			updateConfig["provisioners"] = expandPackerProvisioners(d)
 		}
-		if d.HasChange("post_processors") {
-			updateConfig["postProcessors"] = d.Get("post_processors").([]interface{})
+		if d.HasChanges("post_processor", "post_processors") {
+			updateConfig["postProcessors"] = expandPackerPostProcessors(d)
 		}
 		if d.HasChange("variables") {
 			updateConfig["variables"] = d.Get("variables")
 		}
+		if d.HasChange("variable_spec") {
+			updateConfig["variableSpec"] = expandPackerVariableSpec(d.Get("variable_spec").([]interface{}))
+		}
 		if d.HasChange("auto_build") {
 			updateConfig["autoBuild"] = d.Get("auto_build").(bool)
 		}
 		if d.HasChange("build_timeout") {
 			updateConfig["buildTimeout"] = d.Get("build_timeout").(int)
 		}
+		if d.HasChanges("register_image", "image_name", "image_visibility") {
+			registration := expandPackerImageRegistration(d)
+			if registration == nil {
+				registration = map[string]interface{}{"enabled": false}
+			}
+			updateConfig["imageRegistration"] = registration
+		}
 		if d.HasChange("tags") {
 			updateConfig["tags"] = d.Get("tags")
 		}
@@ -241,6 +448,12 @@ func resourcePackerTemplateUpdate(ctx context.Context, d *schema.ResourceData, m
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("failed to update Packer template: %w", err))
 		}
+
+		if d.HasChange("tags") {
+			if err := deleteRemovedTags(config, fmt.Sprintf("/cloud/project/packer/template/%s", templateId), d); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update Packer template: %w", err))
+			}
+		}
 	}
 
 	return resourcePackerTemplateRead(ctx, d, meta)
@@ -252,11 +465,198 @@ func resourcePackerTemplateDelete(ctx context.Context, d *schema.ResourceData, m
 
 	templateId := d.Id()
 
-	err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/packer/template/%s", templateId), nil)
+	path := fmt.Sprintf("/cloud/project/packer/template/%s", templateId)
+
+	err := config.OVHClient.Delete(path, nil)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete Packer template: %w", err))
 	}
 
+	if err := waitForClusterDeleted(ctx, config, path, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed waiting for Packer template deletion: %w", err))
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func resourcePackerTemplateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return validatePackerVariables(d)
+}
+
+// packerConfigSchema describes the free-form settings of a Packer builder,
+// provisioner or post-processor, passed through to Packer unchanged.
+func packerConfigSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Description: fmt.Sprintf("Additional %s settings", kind),
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// expandPackerBuilders returns the builder blocks as structured objects, or
+// the deprecated JSON strings when those are used instead.
+func expandPackerBuilders(d *schema.ResourceData) []interface{} {
+	blocks := d.Get("builder").([]interface{})
+	if len(blocks) == 0 {
+		return d.Get("builders").([]interface{})
+	}
+
+	builders := make([]interface{}, 0, len(blocks))
+	for _, b := range blocks {
+		raw := b.(map[string]interface{})
+		builders = append(builders, map[string]interface{}{
+			"type":   raw["type"].(string),
+			"config": raw["config"],
+		})
+	}
+	return builders
+}
+
+func expandPackerProvisioners(d *schema.ResourceData) []interface{} {
+	blocks := d.Get("provision").([]interface{})
+	if len(blocks) == 0 {
+		return d.Get("provisioners").([]interface{})
+	}
+
+	provisioners := make([]interface{}, 0, len(blocks))
+	for _, b := range blocks {
+		raw := b.(map[string]interface{})
+		provisioner := map[string]interface{}{
+			"type":   raw["type"].(string),
+			"config": raw["config"],
+		}
+		if inline := raw["inline"].([]interface{}); len(inline) > 0 {
+			provisioner["inline"] = inline
+		}
+		if script := raw["script"].(string); script != "" {
+			provisioner["script"] = script
+		}
+		provisioners = append(provisioners, provisioner)
+	}
+	return provisioners
+}
+
+func expandPackerPostProcessors(d *schema.ResourceData) []interface{} {
+	blocks := d.Get("post_processor").([]interface{})
+	if len(blocks) == 0 {
+		return d.Get("post_processors").([]interface{})
+	}
+
+	postProcessors := make([]interface{}, 0, len(blocks))
+	for _, b := range blocks {
+		raw := b.(map[string]interface{})
+		postProcessors = append(postProcessors, map[string]interface{}{
+			"type":   raw["type"].(string),
+			"config": raw["config"],
+		})
+	}
+	return postProcessors
+}
+
+// packerObjects returns the structured entries of a template list. Entries
+// created from the deprecated JSON strings come back as strings and are
+// skipped, as getStringList picks those up instead.
+func packerObjects(template map[string]interface{}, key string) []map[string]interface{} {
+	items, _ := template[key].([]interface{})
+
+	objects := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+func flattenPackerBuilders(template map[string]interface{}) []interface{} {
+	builders := []interface{}{}
+	for _, builder := range packerObjects(template, "builders") {
+		builders = append(builders, map[string]interface{}{
+			"type":   getString(builder, "type"),
+			"config": builder["config"],
+		})
+	}
+	return builders
+}
+
+func flattenPackerProvisioners(template map[string]interface{}) []interface{} {
+	provisioners := []interface{}{}
+	for _, provisioner := range packerObjects(template, "provisioners") {
+		provisioners = append(provisioners, map[string]interface{}{
+			"type":   getString(provisioner, "type"),
+			"inline": getStringList(provisioner, "inline"),
+			"script": getString(provisioner, "script"),
+			"config": provisioner["config"],
+		})
+	}
+	return provisioners
+}
+
+func flattenPackerPostProcessors(template map[string]interface{}) []interface{} {
+	postProcessors := []interface{}{}
+	for _, postProcessor := range packerObjects(template, "postProcessors") {
+		postProcessors = append(postProcessors, map[string]interface{}{
+			"type":   getString(postProcessor, "type"),
+			"config": postProcessor["config"],
+		})
+	}
+	return postProcessors
+}
+
+// expandPackerImageRegistration returns the image catalog registration
+// settings, or nil when built images are not registered.
+func expandPackerImageRegistration(d *schema.ResourceData) map[string]interface{} {
+	if !d.Get("register_image").(bool) {
+		return nil
+	}
+
+	registration := map[string]interface{}{
+		"enabled":    true,
+		"visibility": d.Get("image_visibility").(string),
+	}
+	if imageName := d.Get("image_name").(string); imageName != "" {
+		registration["imageName"] = imageName
+	}
+	return registration
+}
+
+// packerBuildInProgressStatuses are the statuses of a build that has not
+// finished yet.
+var packerBuildInProgressStatuses = []string{"pending", "queued", "running"}
+
+// setPackerBuild sets the build_* attributes from the latest build of a
+// template. A build in progress is reported as building, with no finish time,
+// so that a Read during an auto_build does not show the previous build.
+func setPackerBuild(d *schema.ResourceData, template map[string]interface{}) {
+	build, _ := template["latestBuild"].(map[string]interface{})
+
+	status := strings.ToLower(getString(build, "status"))
+	finishedAt := getString(build, "finishedAt")
+	for _, s := range packerBuildInProgressStatuses {
+		if status == s {
+			status = "building"
+			finishedAt = ""
+		}
+	}
+
+	d.Set("build_status", status)
+	d.Set("build_started_at", getString(build, "startedAt"))
+	d.Set("build_finished_at", finishedAt)
+	d.Set("build_log_url", getString(build, "logUrl"))
+}
+
+func flattenPackerArtifacts(template map[string]interface{}) []interface{} {
+	artifacts := []interface{}{}
+	for _, artifact := range packerObjects(template, "artifacts") {
+		artifacts = append(artifacts, map[string]interface{}{
+			"region":   getString(artifact, "region"),
+			"image_id": getString(artifact, "imageId"),
+			"name":     getString(artifact, "name"),
+		})
+	}
+	return artifacts
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testPackerTemplateRawConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":          "test-image",
+		"region":        "GRA",
+		"source_image":  "Ubuntu 22.04",
+		"instance_type": "b2-7",
+	}
+}
+
+func TestPackerTemplate_internalValidate(t *testing.T) {
+	if err := resourcePackerTemplate().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid schema: %s", err)
+	}
+}
+
+// TestPackerTemplate_builderValidation checks that exactly one of the builder
+// blocks and the deprecated builders strings is required
+func TestPackerTemplate_builderValidation(t *testing.T) {
+	builderBlock := []interface{}{
+		map[string]interface{}{"type": "openstack"},
+	}
+
+	cases := map[string]struct {
+		builder     []interface{}
+		builders    []interface{}
+		expectError bool
+	}{
+		"builder block":    {builder: builderBlock},
+		"legacy builders":  {builders: []interface{}{`{"type": "openstack"}`}},
+		"no builder":       {expectError: true},
+		"both forms given": {builder: builderBlock, builders: []interface{}{`{"type": "openstack"}`}, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testPackerTemplateRawConfig()
+			if tc.builder != nil {
+				raw["builder"] = tc.builder
+			}
+			if tc.builders != nil {
+				raw["builders"] = tc.builders
+			}
+
+			diags := resourcePackerTemplate().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if tc.expectError && !diags.HasError() {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && diags.HasError() {
+				t.Errorf("unexpected error: %v", diags)
+			}
+		})
+	}
+}
+
+// TestPackerTemplateCreate_typedBlocks checks that typed blocks are sent as
+// structured objects and read back into the same blocks
+func TestPackerTemplateCreate_typedBlocks(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "tpl-123"}`, nil)
+	mock.AddResponse(200, `{
+  "id": "tpl-123",
+  "builders": [{"type": "openstack", "config": {"flavor": "b2-7"}}],
+  "provisioners": [{"type": "shell", "inline": ["apt-get update"]}],
+  "postProcessors": [{"type": "manifest"}]
+}`, nil)
+
+	raw := testPackerTemplateRawConfig()
+	raw["builder"] = []interface{}{
+		map[string]interface{}{"type": "openstack", "config": map[string]interface{}{"flavor": "b2-7"}},
+	}
+	raw["provision"] = []interface{}{
+		map[string]interface{}{"type": "shell", "inline": []interface{}{"apt-get update"}},
+	}
+	raw["post_processor"] = []interface{}{
+		map[string]interface{}{"type": "manifest"},
+	}
+	d := schema.TestResourceDataRaw(t, resourcePackerTemplate().Schema, raw)
+
+	if diags := resourcePackerTemplateCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"builders":       []interface{}{map[string]interface{}{"type": "openstack", "config": map[string]interface{}{"flavor": "b2-7"}}},
+		"provisioners":   []interface{}{map[string]interface{}{"type": "shell", "inline": []interface{}{"apt-get update"}, "config": map[string]interface{}{}}},
+		"postProcessors": []interface{}{map[string]interface{}{"type": "manifest", "config": map[string]interface{}{}}},
+	}
+	for key, value := range expected {
+		if !reflect.DeepEqual(body[key], value) {
+			t.Errorf("expected %s to be %v, got %v", key, value, body[key])
+		}
+	}
+
+	if got := d.Get("builder.0.config.flavor").(string); got != "b2-7" {
+		t.Errorf("expected the builder config to be read back, got %q", got)
+	}
+	if got := d.Get("provision.0.inline.0").(string); got != "apt-get update" {
+		t.Errorf("expected the provisioner commands to be read back, got %q", got)
+	}
+	if got := d.Get("builders").([]interface{}); len(got) != 0 {
+		t.Errorf("expected no legacy builders, got %v", got)
+	}
+}
+
+func TestFlattenPackerBuilders_legacyStrings(t *testing.T) {
+	template := map[string]interface{}{
+		"builders": []interface{}{`{"type": "openstack"}`},
+	}
+
+	if got := flattenPackerBuilders(template); len(got) != 0 {
+		t.Errorf("expected legacy strings to be left to builders, got %v", got)
+	}
+	if got := getStringList(template, "builders"); !reflect.DeepEqual(got, []string{`{"type": "openstack"}`}) {
+		t.Errorf("unexpected legacy builders %v", got)
+	}
+}
+
+// TestPackerTemplateCreate_imageRegistration checks that image registration is
+// requested on create and that artifacts and registered images are read back
+func TestPackerTemplateCreate_imageRegistration(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "tpl-123"}`, nil)
+	mock.AddResponse(200, `{
+  "id": "tpl-123",
+  "builders": [{"type": "openstack"}],
+  "imageRegistration": {"enabled": true, "imageName": "nomad-base", "visibility": "shared"},
+  "artifacts": [
+    {"region": "GRA", "imageId": "img-gra", "name": "nomad-base"},
+    {"region": "SBG", "imageId": "img-sbg", "name": "nomad-base"}
+  ],
+  "registeredImageIds": ["img-gra", "img-sbg"]
+}`, nil)
+
+	raw := testPackerTemplateRawConfig()
+	raw["builder"] = []interface{}{map[string]interface{}{"type": "openstack"}}
+	raw["register_image"] = true
+	raw["image_name"] = "nomad-base"
+	raw["image_visibility"] = "shared"
+	d := schema.TestResourceDataRaw(t, resourcePackerTemplate().Schema, raw)
+
+	if diags := resourcePackerTemplateCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	expected := map[string]interface{}{"enabled": true, "imageName": "nomad-base", "visibility": "shared"}
+	if !reflect.DeepEqual(body["imageRegistration"], expected) {
+		t.Errorf("expected imageRegistration %v, got %v", expected, body["imageRegistration"])
+	}
+
+	if got := d.Get("artifacts.1.image_id").(string); got != "img-sbg" {
+		t.Errorf("expected the second artifact to be img-sbg, got %q", got)
+	}
+	if got := d.Get("registered_image_ids").([]interface{}); !reflect.DeepEqual(got, []interface{}{"img-gra", "img-sbg"}) {
+		t.Errorf("unexpected registered_image_ids %v", got)
+	}
+}
+
+// TestPackerTemplateRead_buildStatus checks that the latest build is read
+// back, and that a build in progress is reported as building
+func TestPackerTemplateRead_buildStatus(t *testing.T) {
+	cases := map[string]struct {
+		build      string
+		status     string
+		finishedAt string
+	}{
+		"finished build": {
+			build:      `{"status": "SUCCEEDED", "startedAt": "2026-10-01T10:00:00Z", "finishedAt": "2026-10-01T10:20:00Z", "logUrl": "https://logs.example.com/build-2"}`,
+			status:     "succeeded",
+			finishedAt: "2026-10-01T10:20:00Z",
+		},
+		"build in progress": {
+			build:  `{"status": "RUNNING", "startedAt": "2026-10-01T10:00:00Z", "logUrl": "https://logs.example.com/build-2"}`,
+			status: "building",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `{"id": "tpl-123", "status": "READY", "latestBuild": `+tc.build+`}`, nil)
+
+			d := schema.TestResourceDataRaw(t, resourcePackerTemplate().Schema, testPackerTemplateRawConfig())
+			d.SetId("tpl-123")
+
+			if diags := resourcePackerTemplateRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("build_status").(string); got != tc.status {
+				t.Errorf("expected build_status %q, got %q", tc.status, got)
+			}
+			if got := d.Get("build_started_at").(string); got != "2026-10-01T10:00:00Z" {
+				t.Errorf("unexpected build_started_at %q", got)
+			}
+			if got := d.Get("build_finished_at").(string); got != tc.finishedAt {
+				t.Errorf("expected build_finished_at %q, got %q", tc.finishedAt, got)
+			}
+			if got := d.Get("build_log_url").(string); got != "https://logs.example.com/build-2" {
+				t.Errorf("unexpected build_log_url %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePrivateNetwork() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an OVH private network in the vRack of a public cloud project, for clusters to talk to each other privately",
+
+		CreateContext: resourcePrivateNetworkCreate,
+		ReadContext:   resourcePrivateNetworkRead,
+		UpdateContext: keepStateOnDryRun(resourcePrivateNetworkUpdate),
+		DeleteContext: resourcePrivateNetworkDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": projectIdSchema(),
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the private network",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "OVH region of the private network, which must be the region of the clusters attached to it",
+			},
+			"vlan_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "VLAN ID of the network in the vRack, allocated by OVH when not set",
+				ValidateFunc: validateIntBetween(0, 4000),
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Address range of the network, such as 10.0.0.0/16, which its subnets are carved from",
+				ValidateFunc: validation.IsCIDRNetwork(8, 30),
+			},
+			"network_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the private network, to create subnets in and attach clusters to",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the private network, ACTIVE once it can be used",
+			},
+		},
+	}
+}
+
+// networkProjectID returns the project of a network resource: its
+// project_id, set on create, or else the provider ovh_project_id, as after
+// an import.
+func networkProjectID(config *Config, d *schema.ResourceData) (string, error) {
+	projectId, err := config.projectID(d.Get("project_id").(string))
+	if err != nil {
+		return "", err
+	}
+	if projectId == "" {
+		return "", fmt.Errorf("no OVH project configured, set project_id or the provider ovh_project_id")
+	}
+	return projectId, nil
+}
+
+// privateNetworkPath returns the API path of the private networks of
+// projectId, or of networkId among them.
+func privateNetworkPath(projectId, networkId string) string {
+	path := fmt.Sprintf("/cloud/project/%s/network/private", url.PathEscape(projectId))
+	if networkId != "" {
+		path += "/" + url.PathEscape(networkId)
+	}
+	return path
+}
+
+func resourcePrivateNetworkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	networkConfig := map[string]interface{}{
+		"name":    d.Get("name").(string),
+		"regions": []string{d.Get("region").(string)},
+		"cidr":    d.Get("cidr").(string),
+	}
+	if vlanId, ok := d.GetOk("vlan_id"); ok {
+		networkConfig["vlanId"] = vlanId.(int)
+	}
+
+	var result map[string]interface{}
+	err = config.OVHClient.Post(privateNetworkPath(projectId, ""), networkConfig, &result)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create private network: %w", err))
+	}
+
+	networkId := getString(result, "id")
+	if networkId == "" {
+		return diag.Errorf("failed to create private network: no network ID returned")
+	}
+	d.SetId(networkId)
+	d.Set("project_id", projectId)
+
+	err = waitForStatus(ctx, config, privateNetworkPath(projectId, networkId), "ACTIVE", d.Timeout(schema.TimeoutCreate), config.pollInterval(clusterReadyPollInterval))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("private network %s did not become active: %w", networkId, err))
+	}
+
+	return resourcePrivateNetworkRead(ctx, d, meta)
+}
+
+func resourcePrivateNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var network map[string]interface{}
+	err = config.OVHClient.Get(privateNetworkPath(projectId, d.Id()), &network)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read private network: %w", err))
+	}
+
+	d.Set("project_id", projectId)
+	d.Set("name", getString(network, "name"))
+	if regions, ok := network["regions"].([]interface{}); ok && len(regions) > 0 {
+		region, _ := regions[0].(map[string]interface{})
+		d.Set("region", getString(region, "region"))
+	}
+	d.Set("vlan_id", getInt(network, "vlanId"))
+	d.Set("cidr", getString(network, "cidr"))
+	d.Set("network_id", d.Id())
+	d.Set("status", getString(network, "status"))
+
+	return nil
+}
+
+func resourcePrivateNetworkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("name") {
+		updateConfig := map[string]interface{}{
+			"name": d.Get("name").(string),
+		}
+
+		err := config.OVHClient.Put(privateNetworkPath(projectId, d.Id()), updateConfig, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update private network: %w", err))
+		}
+	}
+
+	return resourcePrivateNetworkRead(ctx, d, meta)
+}
+
+func resourcePrivateNetworkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(privateNetworkPath(projectId, d.Id()), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete private network: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPrivateNetwork_internalValidate(t *testing.T) {
+	if err := resourcePrivateNetwork().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid schema: %s", err)
+	}
+}
+
+// TestPrivateNetworkCreate checks that a network is created in the provider
+// project and region, and is only read back once it is ACTIVE
+func TestPrivateNetworkCreate(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "pn-123", "status": "BUILDING"}`, nil)
+	mock.AddResponse(200, `{"id": "pn-123", "status": "BUILDING"}`, nil)
+	mock.AddResponse(200, `{"id": "pn-123", "status": "ACTIVE"}`, nil)
+	mock.AddResponse(200, `{"id": "pn-123", "name": "clusters", "vlanId": 42, "cidr": "10.0.0.0/16", "status": "ACTIVE", "regions": [{"region": "GRA", "status": "ACTIVE"}]}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourcePrivateNetwork().Schema, map[string]interface{}{
+		"name":    "clusters",
+		"region":  "GRA",
+		"vlan_id": 42,
+		"cidr":    "10.0.0.0/16",
+	})
+
+	config := mock.NewConfig(t)
+	config.ProjectID = "abc123"
+	config.PollInterval = 10 * time.Millisecond
+	if diags := resourcePrivateNetworkCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.Requests[0].URL.Path; got != "/cloud/project/abc123/network/private" {
+		t.Errorf("expected the network to be created in the provider project, got %s", got)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if !reflect.DeepEqual(body["regions"], []interface{}{"GRA"}) || body["vlanId"] != float64(42) || body["cidr"] != "10.0.0.0/16" {
+		t.Errorf("unexpected create body %v", body)
+	}
+
+	if got := mock.GetRequestCount(); got != 4 {
+		t.Errorf("expected the network to be polled until ACTIVE, got %d requests", got)
+	}
+	expected := map[string]interface{}{
+		"network_id": "pn-123",
+		"project_id": "abc123",
+		"region":     "GRA",
+		"vlan_id":    42,
+		"status":     "ACTIVE",
+	}
+	for key, value := range expected {
+		if got := d.Get(key); got != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, got)
+		}
+	}
+}
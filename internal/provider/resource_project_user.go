@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// projectUserRoles are the roles OVH grants to the users of a public cloud
+// project.
+var projectUserRoles = []string{
+	"administrator",
+	"ai_training_operator",
+	"ai_training_read",
+	"authentication",
+	"backup_operator",
+	"compute_operator",
+	"image_operator",
+	"infrastructure_supervisor",
+	"key-manager_operator",
+	"key-manager_read",
+	"network_operator",
+	"network_security_operator",
+	"objectstore_operator",
+	"volume_operator",
+}
+
+// projectUserDefaultRegion is the region openstack_rc is generated for when
+// region is not set, including after an import, which leaves it empty.
+const projectUserDefaultRegion = "GRA"
+
+func resourceProjectUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a user of an OVH public cloud project, whose credentials give clusters and the tools around them access to the project",
+
+		CreateContext: resourceProjectUserCreate,
+		ReadContext:   resourceProjectUserRead,
+		UpdateContext: keepStateOnDryRun(resourceProjectUserUpdate),
+		DeleteContext: resourceProjectUserDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": projectIdSchema(),
+			"description": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Description of the user, such as what its credentials are used by",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Roles of the user in the project, such as compute_operator or objectstore_operator",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(projectUserRoles, false),
+				},
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      projectUserDefaultRegion,
+				Description:  "OpenStack region openstack_rc is generated for, such as GRA11",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the user",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "OpenStack username of the user",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Password of the user. OVH only returns it on create, so it is empty after an import",
+			},
+			"openstack_rc": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "OpenStack RC file of the user for region, setting the OS_* environment variables of the OpenStack clients",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the user, ok once its credentials can be used",
+			},
+		},
+	}
+}
+
+// projectUserPath returns the API path of the users of projectId, or of
+// userId among them.
+func projectUserPath(projectId, userId string) string {
+	path := fmt.Sprintf("/cloud/project/%s/user", url.PathEscape(projectId))
+	if userId != "" {
+		path += "/" + url.PathEscape(userId)
+	}
+	return path
+}
+
+func resourceProjectUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userConfig := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"roles":       d.Get("roles").(*schema.Set).List(),
+	}
+
+	var result map[string]interface{}
+	err = config.OVHClient.Post(projectUserPath(projectId, ""), userConfig, &result)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create project user: %w", err))
+	}
+
+	// User IDs are numbers in the OVH API.
+	userId := getString(result, "id")
+	if id := getInt(result, "id"); userId == "" && id != 0 {
+		userId = strconv.Itoa(id)
+	}
+	if userId == "" {
+		return diag.Errorf("failed to create project user: no user ID returned")
+	}
+	d.SetId(userId)
+	d.Set("project_id", projectId)
+	setWriteOnceString(d, "password", result, "password")
+
+	err = waitForStatus(ctx, config, projectUserPath(projectId, userId), "ok", d.Timeout(schema.TimeoutCreate), config.pollInterval(clusterReadyPollInterval))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("project user %s did not become ok: %w", userId, err))
+	}
+
+	return resourceProjectUserRead(ctx, d, meta)
+}
+
+func resourceProjectUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var user map[string]interface{}
+	err = config.OVHClient.Get(projectUserPath(projectId, d.Id()), &user)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read project user: %w", err))
+	}
+
+	d.Set("project_id", projectId)
+	d.Set("user_id", d.Id())
+	d.Set("username", getString(user, "username"))
+	d.Set("description", getString(user, "description"))
+	d.Set("status", getString(user, "status"))
+	if items, ok := user["roles"].([]interface{}); ok {
+		roles := make([]string, 0, len(items))
+		for _, item := range items {
+			role, _ := item.(map[string]interface{})
+			if name := getString(role, "name"); name != "" {
+				roles = append(roles, name)
+			}
+		}
+		d.Set("roles", roles)
+	}
+	setWriteOnceString(d, "password", user, "password")
+
+	region := d.Get("region").(string)
+	if region == "" {
+		region = projectUserDefaultRegion
+	}
+	d.Set("region", region)
+
+	query := url.Values{"region": {region}, "version": {"v3"}}
+	var openrc map[string]interface{}
+	err = config.OVHClient.Get(projectUserPath(projectId, d.Id())+"/openrc?"+query.Encode(), &openrc)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read OpenStack RC file of project user: %w", err))
+	}
+	d.Set("openstack_rc", getString(openrc, "content"))
+
+	return nil
+}
+
+// resourceProjectUserUpdate only handles region, whose change generates the
+// OpenStack RC file again.
+func resourceProjectUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceProjectUserRead(ctx, d, meta)
+}
+
+func resourceProjectUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(projectUserPath(projectId, d.Id()), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete project user: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProjectUser_internalValidate(t *testing.T) {
+	if err := resourceProjectUser().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid schema: %s", err)
+	}
+}
+
+// TestProjectUserCreate checks that a user is created in the provider
+// project, keeps the password only returned on create and reads its
+// OpenStack RC file for region
+func TestProjectUserCreate(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": 4242, "username": "user-abc", "password": "s3cret", "status": "creating"}`, nil)
+	mock.AddResponse(200, `{"id": 4242, "status": "creating"}`, nil)
+	mock.AddResponse(200, `{"id": 4242, "status": "ok"}`, nil)
+	mock.AddResponse(200, `{"id": 4242, "username": "user-abc", "description": "vault", "status": "ok", "roles": [{"name": "compute_operator"}]}`, nil)
+	mock.AddResponse(200, `{"content": "export OS_USERNAME=user-abc"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceProjectUser().Schema, map[string]interface{}{
+		"description": "vault",
+		"roles":       []interface{}{"compute_operator"},
+		"region":      "SBG",
+	})
+
+	config := mock.NewConfig(t)
+	config.ProjectID = "abc123"
+	config.PollInterval = 10 * time.Millisecond
+	if diags := resourceProjectUserCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.Requests[0].URL.Path; got != "/cloud/project/abc123/user" {
+		t.Errorf("expected the user to be created in the provider project, got %s", got)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if roles, _ := body["roles"].([]interface{}); body["description"] != "vault" || len(roles) != 1 || roles[0] != "compute_operator" {
+		t.Errorf("unexpected create body %v", body)
+	}
+
+	openrc := mock.GetLastRequest()
+	if openrc.URL.Path != "/cloud/project/abc123/user/4242/openrc" || openrc.URL.Query().Get("region") != "SBG" {
+		t.Errorf("expected the OpenStack RC file to be read for SBG, got %s", openrc.URL)
+	}
+
+	expected := map[string]interface{}{
+		"user_id":      "4242",
+		"username":     "user-abc",
+		"password":     "s3cret",
+		"openstack_rc": "export OS_USERNAME=user-abc",
+		"status":       "ok",
+	}
+	for key, value := range expected {
+		if got := d.Get(key); got != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, got)
+		}
+	}
+}
+
+// TestProjectUserRead_imported checks that an imported user, whose region
+// is not in state, gets the OpenStack RC file of the default region
+func TestProjectUserRead_imported(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": 4242, "username": "user-abc", "description": "vault", "status": "ok", "roles": [{"name": "compute_operator"}]}`, nil)
+	mock.AddResponse(200, `{"content": "export OS_USERNAME=user-abc"}`, nil)
+
+	d := resourceProjectUser().Data(&sdkterraform.InstanceState{ID: "4242"})
+
+	config := mock.NewConfig(t)
+	config.ProjectID = "abc123"
+	if diags := resourceProjectUserRead(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.GetLastRequest().URL.Query().Get("region"); got != projectUserDefaultRegion {
+		t.Errorf("expected the OpenStack RC file to be read for %s, got %q", projectUserDefaultRegion, got)
+	}
+	if got := d.Get("region"); got != projectUserDefaultRegion {
+		t.Errorf("expected region to be %s, got %v", projectUserDefaultRegion, got)
+	}
+}
+
+// TestProjectUserDelete checks that deleting the resource deletes the user,
+// revoking its credentials
+func TestProjectUserDelete(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceProjectUser().Schema, map[string]interface{}{
+		"description": "vault",
+		"roles":       []interface{}{"compute_operator"},
+	})
+	d.SetId("4242")
+
+	config := mock.NewConfig(t)
+	config.ProjectID = "abc123"
+	if diags := resourceProjectUserDelete(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	req := mock.GetLastRequest()
+	if req.Method != http.MethodDelete || req.URL.Path != "/cloud/project/abc123/user/4242" {
+		t.Errorf("expected the user to be deleted, got %s %s", req.Method, req.URL.Path)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the ID to be cleared, got %q", d.Id())
+	}
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSubnet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a subnet of an OVH private network, giving the instances of clusters attached to it their private addresses",
+
+		CreateContext: resourceSubnetCreate,
+		ReadContext:   resourceSubnetRead,
+		DeleteContext: resourceSubnetDelete,
+
+		CustomizeDiff: resourceSubnetCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": projectIdSchema(),
+			"network_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the private network of the subnet",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "OVH region of the subnet, one of the regions of its network",
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Address range of the subnet, such as 10.0.1.0/24, within the cidr of its network",
+				ValidateFunc: validation.IsCIDRNetwork(8, 30),
+			},
+			"start": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "First address handed out by DHCP, defaults to the start of cidr",
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"end": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "Last address handed out by DHCP, defaults to the end of cidr",
+				ValidateFunc: validation.IsIPv4Address,
+			},
+			"dhcp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Hand out addresses of the subnet with DHCP",
+			},
+			"no_gateway": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Create the subnet without a gateway, for networks that never reach outside the vRack",
+			},
+			"subnet_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the subnet, to attach clusters to",
+			},
+			"gateway_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address of the gateway of the subnet, empty with no_gateway",
+			},
+		},
+	}
+}
+
+// subnetPath returns the API path of the subnets of a private network, or
+// of subnetId among them.
+func subnetPath(projectId, networkId, subnetId string) string {
+	path := privateNetworkPath(projectId, networkId) + "/subnet"
+	if subnetId != "" {
+		path += "/" + url.PathEscape(subnetId)
+	}
+	return path
+}
+
+func resourceSubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkId := d.Get("network_id").(string)
+
+	subnetConfig := map[string]interface{}{
+		"network":   d.Get("cidr").(string),
+		"region":    d.Get("region").(string),
+		"dhcp":      d.Get("dhcp").(bool),
+		"noGateway": d.Get("no_gateway").(bool),
+	}
+	if start, ok := d.GetOk("start"); ok {
+		subnetConfig["start"] = start.(string)
+	}
+	if end, ok := d.GetOk("end"); ok {
+		subnetConfig["end"] = end.(string)
+	}
+
+	var result map[string]interface{}
+	err = config.OVHClient.Post(subnetPath(projectId, networkId, ""), subnetConfig, &result)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create subnet: %w", err))
+	}
+
+	subnetId := getString(result, "id")
+	if subnetId == "" {
+		return diag.Errorf("failed to create subnet: no subnet ID returned")
+	}
+	d.SetId(fmt.Sprintf("%s/%s", networkId, subnetId))
+	d.Set("project_id", projectId)
+
+	return resourceSubnetRead(ctx, d, meta)
+}
+
+func resourceSubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	networkId, subnetId, err := parseTwoPartID(d.Id(), "network_id", "subnet_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var subnet map[string]interface{}
+	err = config.OVHClient.Get(subnetPath(projectId, networkId, subnetId), &subnet)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read subnet: %w", err))
+	}
+
+	d.Set("project_id", projectId)
+	d.Set("network_id", networkId)
+	d.Set("subnet_id", subnetId)
+	d.Set("cidr", getString(subnet, "cidr"))
+	d.Set("gateway_ip", getString(subnet, "gatewayIp"))
+	if pools, ok := subnet["ipPools"].([]interface{}); ok && len(pools) > 0 {
+		pool, _ := pools[0].(map[string]interface{})
+		d.Set("region", getString(pool, "region"))
+		d.Set("start", getString(pool, "start"))
+		d.Set("end", getString(pool, "end"))
+		d.Set("dhcp", getBool(pool, "dhcp"))
+	}
+
+	return nil
+}
+
+func resourceSubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	networkId, subnetId, err := parseTwoPartID(d.Id(), "network_id", "subnet_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := networkProjectID(config, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(subnetPath(projectId, networkId, subnetId), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete subnet: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceSubnetCustomizeDiff checks that the DHCP range of the subnet is in
+// its cidr and not reversed.
+func resourceSubnetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("cidr") || !d.NewValueKnown("start") || !d.NewValueKnown("end") {
+		return nil
+	}
+	prefix, err := netip.ParsePrefix(d.Get("cidr").(string))
+	if err != nil {
+		return nil
+	}
+
+	var addrs []netip.Addr
+	for _, key := range []string{"start", "end"} {
+		value := d.Get(key).(string)
+		if value == "" {
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil
+		}
+		if !prefix.Contains(addr) {
+			return fmt.Errorf("%s %s is not in cidr %s", key, value, prefix)
+		}
+		addrs = append(addrs, addr)
+	}
+
+	if len(addrs) == 2 && addrs[1].Less(addrs[0]) {
+		return fmt.Errorf("start %s is after end %s", addrs[0], addrs[1])
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSubnet_internalValidate(t *testing.T) {
+	if err := resourceSubnet().InternalValidate(nil, true); err != nil {
+		t.Fatalf("invalid schema: %s", err)
+	}
+}
+
+// TestSubnetCreate checks that a subnet is created in its network and that
+// its ID and gateway are read back
+func TestSubnetCreate(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "subnet-1"}`, nil)
+	mock.AddResponse(200, `{"id": "subnet-1", "cidr": "10.0.1.0/24", "gatewayIp": "10.0.1.1", "ipPools": [{"region": "GRA", "start": "10.0.1.2", "end": "10.0.1.254", "dhcp": true}]}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceSubnet().Schema, map[string]interface{}{
+		"network_id": "pn-123",
+		"region":     "GRA",
+		"cidr":       "10.0.1.0/24",
+	})
+
+	config := mock.NewConfig(t)
+	config.ProjectID = "abc123"
+	if diags := resourceSubnetCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.Requests[0].URL.Path; got != "/cloud/project/abc123/network/private/pn-123/subnet" {
+		t.Errorf("expected the subnet to be created in its network, got %s", got)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if body["network"] != "10.0.1.0/24" || body["dhcp"] != true || body["noGateway"] != false {
+		t.Errorf("unexpected create body %v", body)
+	}
+	if _, ok := body["start"]; ok {
+		t.Errorf("expected no start to be sent when unset, got %v", body)
+	}
+
+	if d.Id() != "pn-123/subnet-1" {
+		t.Errorf("expected ID pn-123/subnet-1, got %q", d.Id())
+	}
+	if got := d.Get("subnet_id").(string); got != "subnet-1" {
+		t.Errorf("expected subnet_id subnet-1, got %q", got)
+	}
+	if got := d.Get("gateway_ip").(string); got != "10.0.1.1" {
+		t.Errorf("expected gateway_ip 10.0.1.1, got %q", got)
+	}
+	if got := d.Get("start").(string); got != "10.0.1.2" {
+		t.Errorf("expected start 10.0.1.2, got %q", got)
+	}
+}
+
+// TestSubnet_dhcpRange checks that start and end are planned within cidr and
+// in order
+func TestSubnet_dhcpRange(t *testing.T) {
+	cases := map[string]struct {
+		start, end string
+		expectErr  string
+	}{
+		"in range":    {start: "10.0.1.10", end: "10.0.1.200"},
+		"start only":  {start: "10.0.1.10"},
+		"outside":     {start: "10.0.2.10", end: "10.0.1.200", expectErr: "start 10.0.2.10 is not in cidr"},
+		"reversed":    {start: "10.0.1.200", end: "10.0.1.10", expectErr: "is after end"},
+		"end outside": {start: "10.0.1.10", end: "10.0.2.1", expectErr: "end 10.0.2.1 is not in cidr"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"network_id": "pn-123",
+				"region":     "GRA",
+				"cidr":       "10.0.1.0/24",
+				"start":      tc.start,
+			}
+			if tc.end != "" {
+				raw["end"] = tc.end
+			}
+
+			_, err := resourceSubnet().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Fatalf("expected an error containing %q, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -3,31 +3,47 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// vaultClusterUpdateKeys are the attributes resourceVaultClusterUpdate sends
+// to the cluster besides tags, which it can update on their own.
+var vaultClusterUpdateKeys = []string{
+	"node_count", "security_groups", "ui_allowed_cidrs", "custom_domain",
+	"maintenance_window", "web3", "web3_secrets", "instance_tags",
+}
+
 func resourceVaultCluster() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages a Vault cluster on OVH infrastructure with enterprise features",
 
 		CreateContext: resourceVaultClusterCreate,
 		ReadContext:   resourceVaultClusterRead,
-		UpdateContext: resourceVaultClusterUpdate,
+		UpdateContext: keepStateOnDryRun(resourceVaultClusterUpdate),
 		DeleteContext: resourceVaultClusterDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: resourceVaultClusterCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("/cloud/project/vault/cluster", "Vault cluster"),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the Vault cluster",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Vault cluster",
+				ValidateFunc: validateClusterName,
 			},
 			"region": {
 				Type:        schema.TypeString,
@@ -35,22 +51,25 @@ func resourceVaultCluster() *schema.Resource {
 				ForceNew:    true,
 				Description: "OVH region for the cluster",
 			},
+			"project_id": projectIdSchema(),
 			"node_count": {
 				Type:         schema.TypeInt,
 				Required:     true,
 				Description:  "Number of Vault nodes",
-				ValidateFunc: validation.IntBetween(1, 7),
+				ValidateFunc: validateIntBetween(1, 7),
 			},
 			"instance_type": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "OVH instance type for Vault nodes",
+				Optional:    true,
+				Computed:    true,
+				Description: "OVH instance type for Vault nodes, defaults to the provider default_instance_type",
 			},
 			"storage_type": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				ForceNew:    true,
 				Default:     "consul",
-				Description: "Vault storage backend type",
+				Description: "Vault storage backend type. Changing it replaces the cluster, losing its data unless a snapshot is restored",
 				ValidateFunc: validation.StringInSlice([]string{
 					"consul", "raft", "etcd", "dynamodb",
 				}, false),
@@ -59,7 +78,19 @@ func resourceVaultCluster() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
-				Description: "Enable auto-unseal with OVH KMS",
+				Description: "Enable auto-unseal with OVH KMS. Changing it migrates the seal of the cluster, which requires allow_seal_migration",
+			},
+			"auto_unseal_key_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "key_id of the hashicorp_ovh_kms_key to auto-unseal with, in the region of the cluster. A key is provisioned when auto_unseal is true and none is given. Changing it migrates the seal of the cluster, which requires allow_seal_migration",
+			},
+			"allow_seal_migration": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Confirm that changes to auto_unseal and auto_unseal_key_id may migrate the seal of the cluster. A failed migration can make its data unrecoverable, so take a snapshot first",
 			},
 			"audit_enabled": {
 				Type:        schema.TypeBool,
@@ -80,25 +111,53 @@ func resourceVaultCluster() *schema.Resource {
 				Description: "Enable disaster recovery replication",
 			},
 			"web3_secrets": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Enable Web3 secrets engine",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable Web3 secrets engine",
+				Deprecated:    "Use the web3 block instead",
+				ConflictsWith: []string{"web3"},
 			},
+			"web3": web3Schema("web3_secrets"),
 			"kubernetes_auth": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
 				Description: "Enable Kubernetes authentication",
 			},
-			"tags": {
-				Type:        schema.TypeMap,
+			"security_groups": {
+				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "Tags to apply to cluster resources",
+				Description: "Security group IDs or names to attach to cluster nodes",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
+			"ui_allowed_cidrs":      uiAllowedCidrsSchema(),
+			"custom_domain":         customDomainSchema(),
+			"custom_endpoint":       customEndpointSchema(),
+			"connection_info":       connectionInfoSchema(),
+			"ca_certificate":        caCertificateSchema(),
+			"user_data":             userDataSchema(),
+			"additional_volumes":    additionalVolumesSchema(),
+			"maintenance_window":    maintenanceWindowSchema(),
+			"planned_action":        plannedActionSchema(),
+			"recreate_if_unhealthy": recreateIfUnhealthySchema(),
+			"error_on_existing":     errorOnExistingSchema(),
+			"replacement_strategy":  replacementStrategySchema(),
+			"previous_cluster_id":   previousClusterIdSchema(),
+			"previous_endpoint":     previousEndpointSchema(),
+			"ready":                 readySchema(),
+			"ready_at":              readyAtSchema(),
+			"wait_for_ready":        waitForReadyOnCreateSchema(),
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable deletion protection and detach dependent resources before deleting the cluster",
+			},
+			"tags":          tagsSchema(),
+			"instance_tags": instanceTagsSchema(),
 			"cluster_url": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -124,6 +183,19 @@ func resourceVaultCluster() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"default_security_group_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the security group created by OVH for the cluster",
+			},
+			"nodes":                           clusterNodesSchema(),
+			"user_data_hash":                  userDataHashSchema(),
+			"volume_ids":                      volumeIdsSchema(),
+			"last_operation_id":               lastOperationIdSchema(),
+			"next_maintenance_at":             nextMaintenanceAtSchema(),
+			"estimated_monthly_cost":          estimatedMonthlyCostSchema(),
+			"estimated_monthly_cost_currency": estimatedMonthlyCostCurrencySchema(),
+			"config_json":                     configJsonSchema(),
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -137,31 +209,83 @@ func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, met
 	config := meta.(*Config)
 	_ = diag.Diagnostics{}
 
+	instanceType, err := config.instanceType(d.Get("instance_type").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := config.projectID(d.Get("project_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	autoUnsealKeyId := d.Get("auto_unseal_key_id").(string)
+	if autoUnsealKeyId != "" {
+		if err := checkKMSKeyRegion(config, autoUnsealKeyId, d.Get("region").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	clusterConfig := map[string]interface{}{
 		"name":                   d.Get("name").(string),
 		"region":                 d.Get("region").(string),
 		"nodeCount":              d.Get("node_count").(int),
-		"instanceType":           d.Get("instance_type").(string),
+		"instanceType":           instanceType,
 		"storageType":            d.Get("storage_type").(string),
 		"autoUnseal":             d.Get("auto_unseal").(bool),
 		"auditEnabled":           d.Get("audit_enabled").(bool),
 		"performanceReplication": d.Get("performance_replication").(bool),
 		"disasterRecovery":       d.Get("disaster_recovery").(bool),
-		"web3Secrets":            d.Get("web3_secrets").(bool),
 		"kubernetesAuth":         d.Get("kubernetes_auth").(bool),
+		"securityGroups":         d.Get("security_groups").(*schema.Set).List(),
+		"uiAllowedCidrs":         d.Get("ui_allowed_cidrs"),
+		"customDomain":           d.Get("custom_domain").(string),
+		"additionalVolumes":      expandAdditionalVolumes(d.Get("additional_volumes").([]interface{})),
 		"tags":                   d.Get("tags"),
+		"instanceTags":           d.Get("instance_tags"),
+	}
+	if projectId != "" {
+		clusterConfig["projectId"] = projectId
+	}
+	if autoUnsealKeyId != "" {
+		clusterConfig["autoUnsealKeyId"] = autoUnsealKeyId
+	}
+
+	addLegacyBlock(clusterConfig, "web3", "web3Secrets", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_secrets").(bool)), false)
+
+	if window := expandMaintenanceWindow(d.Get("maintenance_window").([]interface{})); window != nil {
+		clusterConfig["maintenanceWindow"] = window
 	}
 
-	var result map[string]interface{}
-	err := config.OVHClient.Post("/cloud/project/vault/cluster", clusterConfig, &result)
+	setUserData(d, clusterConfig)
+
+	openUI := len(d.Get("ui_allowed_cidrs").([]interface{})) == 0
+
+	setPreviousCluster(ctx, config, d, "vault", projectId)
+	if diags := checkExistingCluster(ctx, config, d, "vault", "Vault", projectId); diags.HasError() {
+		return diags
+	}
+	if diags := checkInstanceQuota(ctx, config, d, "Vault", projectId, "node_count"); diags.HasError() {
+		return diags
+	}
+
+	result, err := createCluster(ctx, config, d, "vault", projectId, clusterConfig)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to create Vault cluster: %w", err))
+		return createClusterError("Vault", projectId, err)
 	}
 
-	clusterId := result["id"].(string)
+	clusterId, err := createdClusterId("Vault", result)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId(clusterId)
+	setConfigJson(d, clusterConfig)
+	setLastOperationId(d, result)
 
-	return resourceVaultClusterRead(ctx, d, meta)
+	diags := waitForReadyAfterCreate(ctx, config, d, "Vault", "vault")
+	diags = append(diags, readAfterCreate(ctx, d, meta, "Vault", resourceVaultClusterRead)...)
+	if openUI {
+		diags = append(diags, openUIWarning("Vault"))
+	}
+	return diags
 }
 
 func resourceVaultClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -176,33 +300,46 @@ func resourceVaultClusterRead(ctx context.Context, d *schema.ResourceData, meta
 		d.SetId("")
 		return diag.FromErr(fmt.Errorf("failed to read Vault cluster: %w", err))
 	}
-
-	d.Set("name", cluster["name"])
-	d.Set("region", cluster["region"])
-	d.Set("node_count", cluster["nodeCount"])
-	d.Set("instance_type", cluster["instanceType"])
-	d.Set("storage_type", cluster["storageType"])
-	d.Set("auto_unseal", cluster["autoUnseal"])
-	d.Set("audit_enabled", cluster["auditEnabled"])
-	d.Set("performance_replication", cluster["performanceReplication"])
-	d.Set("disaster_recovery", cluster["disasterRecovery"])
-	d.Set("web3_secrets", cluster["web3Secrets"])
-	d.Set("kubernetes_auth", cluster["kubernetesAuth"])
-	d.Set("cluster_url", cluster["clusterUrl"])
-	d.Set("ui_url", cluster["uiUrl"])
-	d.Set("status", cluster["status"])
-
-	if rootToken, ok := cluster["rootToken"].(string); ok {
-		d.Set("root_token", rootToken)
+	if err := completeClusterNodes(config, "vault", clusterId, cluster); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read nodes of Vault cluster: %w", err))
 	}
 
-	if unsealKeys, ok := cluster["unsealKeys"].([]interface{}); ok {
-		d.Set("unseal_keys", unsealKeys)
-	}
+	d.Set("name", getString(cluster, "name"))
+	d.Set("region", getString(cluster, "region"))
+	d.Set("node_count", getInt(cluster, "nodeCount"))
+	d.Set("instance_type", getString(cluster, "instanceType"))
+	setProjectID(d, cluster)
+	d.Set("storage_type", getString(cluster, "storageType"))
+	d.Set("auto_unseal", getBool(cluster, "autoUnseal"))
+	d.Set("auto_unseal_key_id", getString(cluster, "autoUnsealKeyId"))
+	d.Set("audit_enabled", getBool(cluster, "auditEnabled"))
+	d.Set("performance_replication", getBool(cluster, "performanceReplication"))
+	d.Set("disaster_recovery", getBool(cluster, "disasterRecovery"))
+	d.Set("web3_secrets", getBool(cluster, "web3Secrets"))
+	setLegacyBlock(d, "web3", "web3_secrets", cluster, flattenWeb3)
+	d.Set("kubernetes_auth", getBool(cluster, "kubernetesAuth"))
+	d.Set("cluster_url", getString(cluster, "clusterUrl"))
+	d.Set("ui_url", getString(cluster, "uiUrl"))
+	d.Set("status", getString(cluster, "status"))
+	setClusterReadiness(d, "vault", cluster)
+	d.Set("nodes", flattenClusterNodes(cluster))
+	setAdditionalVolumes(d, cluster)
+	setMaintenanceWindow(d, cluster)
+	setEstimatedMonthlyCost(d, config, "node_count")
+	d.Set("security_groups", getStringList(cluster, "securityGroups"))
+	d.Set("ui_allowed_cidrs", getStringList(cluster, "uiAllowedCidrs"))
+	d.Set("custom_domain", getString(cluster, "customDomain"))
+	d.Set("custom_endpoint", getString(cluster, "customEndpoint"))
+	setConnectionInfo(d, "vault", cluster)
+	refreshPreviousCluster(config, d, "vault")
+	setClusterCertificates(config, d, "vault", clusterId, false)
+	d.Set("default_security_group_id", getString(cluster, "defaultSecurityGroupId"))
 
-	if tags, ok := cluster["tags"].(map[string]interface{}); ok {
-		d.Set("tags", tags)
-	}
+	setWriteOnceString(d, "root_token", cluster, "rootToken")
+	setWriteOnceStringList(d, "unseal_keys", cluster, "unsealKeys")
+
+	d.Set("tags", flattenTags(cluster))
+	d.Set("instance_tags", flattenInstanceTags(cluster))
 
 	return nil
 }
@@ -213,20 +350,64 @@ func resourceVaultClusterUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	clusterId := d.Id()
 
-	if d.HasChanges("node_count", "tags") {
+	if hasOnlyTagChanges(d, vaultClusterUpdateKeys...) && !d.HasChanges("auto_unseal", "auto_unseal_key_id") {
+		if err := updateClusterTags(ctx, config, "vault", clusterId, d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Vault cluster tags: %w", err))
+		}
+		mergeConfigJson(d, map[string]interface{}{"tags": d.Get("tags")})
+		return resourceVaultClusterRead(ctx, d, meta)
+	}
+
+	if d.HasChange("tags") || d.HasChanges(vaultClusterUpdateKeys...) {
 		updateConfig := map[string]interface{}{}
 
 		if d.HasChange("node_count") {
 			updateConfig["nodeCount"] = d.Get("node_count").(int)
 		}
+		if d.HasChange("security_groups") {
+			updateConfig["securityGroups"] = d.Get("security_groups").(*schema.Set).List()
+		}
+		if d.HasChange("ui_allowed_cidrs") {
+			updateConfig["uiAllowedCidrs"] = d.Get("ui_allowed_cidrs")
+		}
+		if d.HasChange("custom_domain") {
+			updateConfig["customDomain"] = d.Get("custom_domain").(string)
+		}
+		if d.HasChange("maintenance_window") {
+			updateConfig["maintenanceWindow"] = expandMaintenanceWindow(d.Get("maintenance_window").([]interface{}))
+		}
+		if d.HasChanges("web3", "web3_secrets") {
+			addLegacyBlock(updateConfig, "web3", "web3Secrets", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_secrets").(bool)), true)
+		}
 		if d.HasChange("tags") {
 			updateConfig["tags"] = d.Get("tags")
 		}
+		if d.HasChange("instance_tags") {
+			updateConfig["instanceTags"] = d.Get("instance_tags")
+		}
 
-		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/vault/cluster/%s", clusterId), updateConfig, nil)
+		result, err := updateCluster(ctx, config, "vault", clusterId, updateConfig, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("failed to update Vault cluster: %w", err))
 		}
+		operationId := setLastOperationId(d, result)
+		mergeConfigJson(d, updateConfig)
+
+		if d.HasChange("tags") {
+			if err := deleteRemovedTags(config, fmt.Sprintf("/cloud/project/vault/cluster/%s", clusterId), d); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update Vault cluster: %w", err))
+			}
+		}
+
+		if err := waitForClusterOperation(ctx, config, "vault", clusterId, operationId); err != nil {
+			return diag.FromErr(fmt.Errorf("cluster update failed: %w", err))
+		}
+	}
+
+	if d.HasChanges("auto_unseal", "auto_unseal_key_id") {
+		if err := migrateVaultSeal(ctx, config, d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Vault cluster: %w", err))
+		}
 	}
 
 	return resourceVaultClusterRead(ctx, d, meta)
@@ -238,7 +419,7 @@ func resourceVaultClusterDelete(ctx context.Context, d *schema.ResourceData, met
 
 	clusterId := d.Id()
 
-	err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/vault/cluster/%s", clusterId), nil)
+	err := deleteCluster(ctx, config, "vault", clusterId, d.Get("force_destroy").(bool), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete Vault cluster: %w", err))
 	}
@@ -246,3 +427,47 @@ func resourceVaultClusterDelete(ctx context.Context, d *schema.ResourceData, met
 	d.SetId("")
 	return nil
 }
+
+func resourceVaultClusterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateAutoUnsealKey(d, meta); err != nil {
+		return err
+	}
+
+	if err := planRecreateIfUnhealthy(d); err != nil {
+		return err
+	}
+
+	if err := planBlueGreenReplacement(d); err != nil {
+		return err
+	}
+
+	if err := planSealMigration(d); err != nil {
+		return err
+	}
+
+	if err := planConfigJson(d, append([]string{"auto_unseal", "auto_unseal_key_id"}, vaultClusterUpdateKeys...)...); err != nil {
+		return err
+	}
+
+	if err := validateAdditionalVolumes(d); err != nil {
+		return err
+	}
+
+	if d.Get("disaster_recovery").(bool) && d.Get("node_count").(int) < 3 {
+		return fmt.Errorf("disaster_recovery requires node_count to be at least 3, got %d", d.Get("node_count").(int))
+	}
+
+	if err := planNodeCountChange(ctx, d, "Vault", "node_count", "node_count"); err != nil {
+		return err
+	}
+
+	if err := planDataLossOnReplace(d, "storage_type"); err != nil {
+		return err
+	}
+
+	if err := planInstanceQuota(ctx, d, meta, "Vault", "node_count"); err != nil {
+		return err
+	}
+
+	return planEstimatedMonthlyCost(d, meta, "node_count")
+}
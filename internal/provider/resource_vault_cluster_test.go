@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testVaultClusterRawConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":          "test-vault",
+		"region":        "GRA",
+		"node_count":    3,
+		"instance_type": "c2-15",
+	}
+}
+
+// TestVaultCluster_disasterRecoveryNodeCount checks that disaster recovery
+// is rejected at plan time on clusters with fewer than 3 nodes
+func TestVaultCluster_disasterRecoveryNodeCount(t *testing.T) {
+	cases := map[string]struct {
+		nodeCount        int
+		disasterRecovery bool
+		expectError      bool
+	}{
+		"dr with 3 nodes":    {nodeCount: 3, disasterRecovery: true},
+		"dr with 1 node":     {nodeCount: 1, disasterRecovery: true, expectError: true},
+		"no dr with 1 node":  {nodeCount: 1, disasterRecovery: false},
+		"dr with 2 nodes":    {nodeCount: 2, disasterRecovery: true, expectError: true},
+		"dr with many nodes": {nodeCount: 7, disasterRecovery: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testVaultClusterRawConfig()
+			raw["node_count"] = tc.nodeCount
+			raw["disaster_recovery"] = tc.disasterRecovery
+
+			_, err := resourceVaultCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestVaultCluster_autoUnsealKey checks that auto_unseal_key_id is rejected
+// at plan time without auto_unseal or for a key of another region
+func TestVaultCluster_autoUnsealKey(t *testing.T) {
+	cases := map[string]struct {
+		autoUnseal bool
+		keyRegion  string
+		expectErr  bool
+	}{
+		"key in cluster region": {autoUnseal: true, keyRegion: "GRA"},
+		"key in another region": {autoUnseal: true, keyRegion: "SBG", expectErr: true},
+		"auto_unseal disabled":  {autoUnseal: false, keyRegion: "GRA", expectErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			// CustomizeDiff runs twice per Diff, and the catalog of the cost
+			// estimate is only fetched the first time.
+			key := `{"id": "kms-123", "region": "` + tc.keyRegion + `"}`
+			mock.AddResponse(200, key, nil)
+			mock.AddResponse(200, testPublicCloudCatalog, nil)
+			mock.AddResponse(200, key, nil)
+
+			config := mock.NewConfig(t)
+			config.Endpoint = "ovh-eu"
+
+			raw := testVaultClusterRawConfig()
+			raw["auto_unseal"] = tc.autoUnseal
+			raw["auto_unseal_key_id"] = "kms-123"
+
+			_, err := resourceVaultCluster().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), config)
+			if tc.expectErr && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestVaultClusterCreate_autoUnsealKey checks that the key region is checked
+// again on create, as a key created in the same apply is unknown at plan time
+func TestVaultClusterCreate_autoUnsealKey(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "kms-123", "region": "GRA"}`, nil)
+	mock.AddResponse(200, `[]`, nil)
+	mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY", "autoUnseal": true, "autoUnsealKeyId": "kms-123"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["auto_unseal_key_id"] = "kms-123"
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+	if diags := resourceVaultClusterCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.Requests[0].URL.Path; got != "/cloud/project/kms/key/kms-123" {
+		t.Errorf("expected the key to be read first, got %s", got)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[2]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if got := body["autoUnsealKeyId"]; got != "kms-123" {
+		t.Errorf("expected autoUnsealKeyId kms-123, got %v", got)
+	}
+	if got := d.Get("auto_unseal_key_id").(string); got != "kms-123" {
+		t.Errorf("expected auto_unseal_key_id kms-123, got %q", got)
+	}
+}
+
+func TestVaultCluster_uiAllowedCidrsValidation(t *testing.T) {
+	raw := testVaultClusterRawConfig()
+	raw["ui_allowed_cidrs"] = []interface{}{"203.0.113.0/24", "10.0.0.1"}
+
+	diags := resourceVaultCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+	if !diags.HasError() {
+		t.Fatal("expected an error for an entry that is not a CIDR block")
+	}
+}
+
+// TestVaultClusterCreate_openUIWarning checks that creating a Vault cluster
+// without ui_allowed_cidrs warns that its UI is open
+func TestVaultClusterCreate_openUIWarning(t *testing.T) {
+	cases := map[string]struct {
+		cidrs       []interface{}
+		expectWarns int
+	}{
+		"open":       {expectWarns: 1},
+		"restricted": {cidrs: []interface{}{"203.0.113.0/24"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+			mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY"}`, nil)
+
+			raw := testVaultClusterRawConfig()
+			if tc.cidrs != nil {
+				raw["ui_allowed_cidrs"] = tc.cidrs
+			}
+			d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+			diags := resourceVaultClusterCreate(context.Background(), d, mock.NewConfig(t))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if len(diags) != tc.expectWarns {
+				t.Errorf("expected %d warnings, got %v", tc.expectWarns, diags)
+			}
+		})
+	}
+}
+
+// TestVaultClusterCreate_defaultInstanceType checks that the provider
+// default_instance_type is used when the resource does not set instance_type
+func TestVaultClusterCreate_defaultInstanceType(t *testing.T) {
+	cases := map[string]struct {
+		instanceType string
+		expected     string
+	}{
+		"default":  {expected: "b2-7"},
+		"resource": {instanceType: "c2-15", expected: "c2-15"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, `[]`, nil)
+			mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+			mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY"}`, nil)
+
+			raw := testVaultClusterRawConfig()
+			delete(raw, "instance_type")
+			if tc.instanceType != "" {
+				raw["instance_type"] = tc.instanceType
+			}
+			d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+			config := mock.NewConfig(t)
+			config.DefaultInstanceType = "b2-7"
+			if diags := resourceVaultClusterCreate(context.Background(), d, config); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal([]byte(mock.RequestBodies[1]), &body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+			if got := body["instanceType"]; got != tc.expected {
+				t.Errorf("expected instanceType %q, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		raw := testVaultClusterRawConfig()
+		delete(raw, "instance_type")
+		d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+		if diags := resourceVaultClusterCreate(context.Background(), d, &Config{}); !diags.HasError() {
+			t.Fatal("expected an error without instance_type or default_instance_type")
+		}
+	})
+}
+
+// TestVaultClusterCreate_projectID checks that project_id overrides the
+// provider ovh_project_id and falls back to it when unset
+func TestVaultClusterCreate_projectID(t *testing.T) {
+	cases := map[string]struct {
+		projectId string
+		expected  string
+		create    int
+	}{
+		"provider": {expected: "abc123", create: 2},
+		"resource": {projectId: "def456", expected: "def456", create: 3},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			if tc.projectId != "" {
+				mock.AddResponse(200, `{"project_id": "def456", "status": "ok"}`, nil)
+			}
+			mock.AddResponse(200, `[]`, nil)
+			mock.AddResponse(200, `[]`, nil)
+			mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+			mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY", "projectId": "`+tc.expected+`"}`, nil)
+
+			raw := testVaultClusterRawConfig()
+			if tc.projectId != "" {
+				raw["project_id"] = tc.projectId
+			}
+			d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+			config := mock.NewConfig(t)
+			config.ProjectID = "abc123"
+			if diags := resourceVaultClusterCreate(context.Background(), d, config); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := mock.Requests[tc.create]; got.Method != http.MethodPost || got.URL.Path != "/cloud/project/vault/cluster" {
+				t.Fatalf("expected the cluster to be created by request %d, got %s %s", tc.create, got.Method, got.URL.Path)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal([]byte(mock.RequestBodies[tc.create]), &body); err != nil {
+				t.Fatalf("failed to decode request body: %s", err)
+			}
+			if got := body["projectId"]; got != tc.expected {
+				t.Errorf("expected projectId %q, got %v", tc.expected, got)
+			}
+			if got := d.Get("project_id").(string); got != tc.expected {
+				t.Errorf("expected project_id %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestVaultClusterCreate_unknownProject checks that a project_id that does
+// not exist fails before the cluster is created
+func TestVaultClusterCreate_unknownProject(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(404, `{"class": "Client::NotFound", "message": "This service does not exist"}`, nil)
+
+	raw := testVaultClusterRawConfig()
+	raw["project_id"] = "missing"
+	d := schema.TestResourceDataRaw(t, resourceVaultCluster().Schema, raw)
+
+	diags := resourceVaultClusterCreate(context.Background(), d, mock.NewConfig(t))
+	if !diags.HasError() {
+		t.Fatal("expected an error for an unknown project")
+	}
+	if got := mock.GetRequestCount(); got != 1 {
+		t.Errorf("expected no cluster to be created, got %d requests", got)
+	}
+}
+
+// TestVaultCluster_storageTypeForceNew checks that changing the storage
+// backend replaces the cluster, as Vault cannot migrate it in place, and shows
+// in planned_action that its data is lost
+func TestVaultCluster_storageTypeForceNew(t *testing.T) {
+	state := &sdkterraform.InstanceState{
+		ID: "vault-123",
+		Attributes: map[string]string{
+			"id":            "vault-123",
+			"name":          "test-vault",
+			"region":        "GRA",
+			"node_count":    "3",
+			"instance_type": "c2-15",
+			"storage_type":  "consul",
+			"auto_unseal":   "true",
+		},
+	}
+	raw := testVaultClusterRawConfig()
+	raw["storage_type"] = "raft"
+
+	// Terraform sends the prior state along, as the replacement is planned
+	// without it.
+	rawState, err := state.AttrsAsObjectValue(resourceVaultCluster().CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("unexpected state error: %s", err)
+	}
+	state.RawState = rawState
+
+	diff, err := resourceVaultCluster().Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected a storage_type change to replace the cluster")
+	}
+	if planned := diff.Attributes["planned_action"]; planned == nil || !strings.Contains(planned.New, "storage_type consul -> raft: replaces the cluster, which loses its data") {
+		t.Errorf("expected planned_action to show the data loss, got %v", planned)
+	}
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVaultPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an ACL policy on a Vault cluster running on OVH infrastructure",
+
+		CreateContext: resourceVaultPolicyCreate,
+		ReadContext:   resourceVaultPolicyRead,
+		UpdateContext: keepStateOnDryRun(resourceVaultPolicyUpdate),
+		DeleteContext: resourceVaultPolicyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVaultPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Vault cluster",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the policy, root and default are reserved by Vault",
+				ValidateFunc: validation.All(
+					validation.StringIsNotEmpty,
+					validation.StringDoesNotContainAny("/"),
+					validation.StringNotInSlice([]string{"root", "default"}, true),
+				),
+			},
+			"policy": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Policy document in HCL",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+	}
+}
+
+func resourceVaultPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId := d.Get("cluster_id").(string)
+	name := d.Get("name").(string)
+
+	policyConfig := map[string]interface{}{
+		"name":   name,
+		"policy": d.Get("policy").(string),
+	}
+
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/vault/cluster/%s/policy", clusterId), policyConfig, nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create Vault policy: %w", err))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterId, name))
+
+	return resourceVaultPolicyRead(ctx, d, meta)
+}
+
+func resourceVaultPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "policy_name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var policy map[string]interface{}
+	err = config.OVHClient.Get(fmt.Sprintf("/cloud/project/vault/cluster/%s/policy/%s", clusterId, name), &policy)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(fmt.Errorf("failed to read Vault policy: %w", err))
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("name", getString(policy, "name"))
+	d.Set("policy", getString(policy, "policy"))
+
+	return nil
+}
+
+func resourceVaultPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "policy_name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("policy") {
+		updateConfig := map[string]interface{}{
+			"policy": d.Get("policy").(string),
+		}
+
+		err := config.OVHClient.Put(fmt.Sprintf("/cloud/project/vault/cluster/%s/policy/%s", clusterId, name), updateConfig, nil)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Vault policy: %w", err))
+		}
+	}
+
+	return resourceVaultPolicyRead(ctx, d, meta)
+}
+
+func resourceVaultPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "policy_name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = config.OVHClient.Delete(fmt.Sprintf("/cloud/project/vault/cluster/%s/policy/%s", clusterId, name), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to delete Vault policy: %w", err))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceVaultPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	clusterId, name, err := parseTwoPartID(d.Id(), "cluster_id", "policy_name")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("cluster_id", clusterId)
+	d.Set("name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
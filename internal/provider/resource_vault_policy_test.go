@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testVaultPolicyBody = `path "secret/data/app/*" {
+  capabilities = ["read"]
+}
+`
+
+func TestVaultPolicy_validation(t *testing.T) {
+	cases := map[string]struct {
+		name   string
+		policy string
+		valid  bool
+	}{
+		"app policy":     {name: "app-read", policy: testVaultPolicyBody, valid: true},
+		"reserved root":  {name: "root", policy: testVaultPolicyBody},
+		"reserved mixed": {name: "Default", policy: testVaultPolicyBody},
+		"empty name":     {name: "", policy: testVaultPolicyBody},
+		"blank policy":   {name: "app-read", policy: "  \n"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cluster_id": "vault-123",
+				"name":       tc.name,
+				"policy":     tc.policy,
+			}
+
+			diags := resourceVaultPolicy().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if diags.HasError() == tc.valid {
+				t.Errorf("expected valid=%t, got diagnostics %v", tc.valid, diags)
+			}
+		})
+	}
+}
+
+func TestVaultPolicy_import(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceVaultPolicy().Schema, map[string]interface{}{})
+	d.SetId("vault-123/app-read")
+
+	results, err := resourceVaultPolicyImport(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := results[0].Get("cluster_id").(string); got != "vault-123" {
+		t.Errorf("expected cluster_id vault-123, got %q", got)
+	}
+	if got := results[0].Get("name").(string); got != "app-read" {
+		t.Errorf("expected name app-read, got %q", got)
+	}
+}
+
+func TestVaultPolicyRead(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"name": "app-read", "policy": "path \"secret/data/app/*\" {\n  capabilities = [\"read\"]\n}\n"}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceVaultPolicy().Schema, map[string]interface{}{})
+	d.SetId("vault-123/app-read")
+
+	if diags := resourceVaultPolicyRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("policy").(string); got != testVaultPolicyBody {
+		t.Errorf("unexpected policy %q", got)
+	}
+}
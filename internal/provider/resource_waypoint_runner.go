@@ -3,31 +3,47 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// waypointRunnerUpdateKeys are the attributes resourceWaypointRunnerUpdate
+// sends to the runner besides tags, which it can update on their own.
+var waypointRunnerUpdateKeys = []string{
+	"capacity", "labels", "on_demand_profile", "web3", "web3_deployments",
+}
+
 func resourceWaypointRunner() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manages a Waypoint runner on OVH infrastructure for application deployment",
 
 		CreateContext: resourceWaypointRunnerCreate,
 		ReadContext:   resourceWaypointRunnerRead,
-		UpdateContext: resourceWaypointRunnerUpdate,
+		UpdateContext: keepStateOnDryRun(resourceWaypointRunnerUpdate),
 		DeleteContext: resourceWaypointRunnerDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: resourceWaypointRunnerCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByName("/cloud/project/waypoint/runner", "Waypoint runner"),
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the Waypoint runner",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Waypoint runner",
+				ValidateFunc: validateClusterName,
 			},
 			"region": {
 				Type:        schema.TypeString,
@@ -35,10 +51,12 @@ func resourceWaypointRunner() *schema.Resource {
 				ForceNew:    true,
 				Description: "OVH region for the runner",
 			},
+			"project_id": projectIdSchema(),
 			"instance_type": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "OVH instance type for the runner",
+				Optional:    true,
+				Computed:    true,
+				Description: "OVH instance type for the runner, defaults to the provider default_instance_type",
 			},
 			"runner_type": {
 				Type:        schema.TypeString,
@@ -54,7 +72,7 @@ func resourceWaypointRunner() *schema.Resource {
 				Optional:     true,
 				Default:      10,
 				Description:  "Maximum concurrent jobs",
-				ValidateFunc: validation.IntBetween(1, 100),
+				ValidateFunc: validateIntBetween(1, 100),
 			},
 			"docker_enabled": {
 				Type:        schema.TypeBool,
@@ -75,10 +93,23 @@ func resourceWaypointRunner() *schema.Resource {
 				Description: "Enable Nomad support",
 			},
 			"web3_deployments": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Enable Web3 application deployments",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable Web3 application deployments",
+				Deprecated:    "Use the web3 block instead",
+				ConflictsWith: []string{"web3"},
+			},
+			"web3":              web3Schema("web3_deployments"),
+			"on_demand_profile": onDemandProfileSchema(),
+			"labels": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Description:  "Labels Waypoint projects use to target jobs to this runner",
+				ValidateFunc: validateRunnerLabels,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"tags": {
 				Type:        schema.TypeMap,
@@ -88,6 +119,11 @@ func resourceWaypointRunner() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"profile_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Waypoint runner profile created from on_demand_profile",
+			},
 			"runner_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -104,6 +140,14 @@ func resourceWaypointRunner() *schema.Resource {
 				Computed:    true,
 				Description: "Runner endpoint URL",
 			},
+			"matched_projects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Waypoint projects whose runner profile currently targets this runner",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -117,21 +161,39 @@ func resourceWaypointRunnerCreate(ctx context.Context, d *schema.ResourceData, m
 	config := meta.(*Config)
 	_ = diag.Diagnostics{}
 
+	instanceType, err := config.instanceType(d.Get("instance_type").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectId, err := config.projectID(d.Get("project_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	runnerConfig := map[string]interface{}{
 		"name":              d.Get("name").(string),
 		"region":            d.Get("region").(string),
-		"instanceType":      d.Get("instance_type").(string),
+		"instanceType":      instanceType,
 		"runnerType":        d.Get("runner_type").(string),
 		"capacity":          d.Get("capacity").(int),
 		"dockerEnabled":     d.Get("docker_enabled").(bool),
 		"kubernetesEnabled": d.Get("kubernetes_enabled").(bool),
 		"nomadEnabled":      d.Get("nomad_enabled").(bool),
-		"web3Deployments":   d.Get("web3_deployments").(bool),
+		"labels":            d.Get("labels"),
 		"tags":              d.Get("tags"),
 	}
+	if projectId != "" {
+		runnerConfig["projectId"] = projectId
+	}
+
+	addLegacyBlock(runnerConfig, "web3", "web3Deployments", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_deployments").(bool)), false)
+
+	if profile := expandOnDemandProfile(d.Get("on_demand_profile").([]interface{})); profile != nil {
+		runnerConfig["onDemandProfile"] = profile
+	}
 
 	var result map[string]interface{}
-	err := config.OVHClient.Post("/cloud/project/waypoint/runner", runnerConfig, &result)
+	err = config.OVHClient.Post("/cloud/project/waypoint/runner", runnerConfig, &result)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to create Waypoint runner: %w", err))
 	}
@@ -155,23 +217,27 @@ func resourceWaypointRunnerRead(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(fmt.Errorf("failed to read Waypoint runner: %w", err))
 	}
 
-	d.Set("name", runner["name"])
-	d.Set("region", runner["region"])
-	d.Set("instance_type", runner["instanceType"])
-	d.Set("runner_type", runner["runnerType"])
-	d.Set("capacity", runner["capacity"])
-	d.Set("docker_enabled", runner["dockerEnabled"])
-	d.Set("kubernetes_enabled", runner["kubernetesEnabled"])
-	d.Set("nomad_enabled", runner["nomadEnabled"])
-	d.Set("web3_deployments", runner["web3Deployments"])
-	d.Set("runner_id", runner["runnerId"])
-	d.Set("token", runner["token"])
-	d.Set("endpoint", runner["endpoint"])
-	d.Set("status", runner["status"])
-
-	if tags, ok := runner["tags"].(map[string]interface{}); ok {
-		d.Set("tags", tags)
-	}
+	d.Set("name", getString(runner, "name"))
+	d.Set("region", getString(runner, "region"))
+	d.Set("instance_type", getString(runner, "instanceType"))
+	setProjectID(d, runner)
+	d.Set("runner_type", getString(runner, "runnerType"))
+	d.Set("capacity", getInt(runner, "capacity"))
+	d.Set("docker_enabled", getBool(runner, "dockerEnabled"))
+	d.Set("kubernetes_enabled", getBool(runner, "kubernetesEnabled"))
+	d.Set("nomad_enabled", getBool(runner, "nomadEnabled"))
+	d.Set("web3_deployments", getBool(runner, "web3Deployments"))
+	setLegacyBlock(d, "web3", "web3_deployments", runner, flattenWeb3)
+	d.Set("on_demand_profile", flattenOnDemandProfile(runner))
+	d.Set("profile_id", getString(runner, "profileId"))
+	d.Set("runner_id", getString(runner, "runnerId"))
+	setWriteOnceString(d, "token", runner, "token")
+	d.Set("endpoint", getString(runner, "endpoint"))
+	d.Set("labels", flattenRunnerLabels(runner))
+	d.Set("matched_projects", getStringList(runner, "matchedProjects"))
+	d.Set("status", getString(runner, "status"))
+
+	d.Set("tags", flattenTags(runner))
 
 	return nil
 }
@@ -182,12 +248,28 @@ func resourceWaypointRunnerUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	runnerId := d.Id()
 
-	if d.HasChanges("capacity", "tags") {
+	if hasOnlyTagChanges(d, waypointRunnerUpdateKeys...) {
+		if err := updateTags(config, fmt.Sprintf("/cloud/project/waypoint/runner/%s", runnerId), d); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update Waypoint runner tags: %w", err))
+		}
+		return resourceWaypointRunnerRead(ctx, d, meta)
+	}
+
+	if d.HasChange("tags") || d.HasChanges(waypointRunnerUpdateKeys...) {
 		updateConfig := map[string]interface{}{}
 
 		if d.HasChange("capacity") {
 			updateConfig["capacity"] = d.Get("capacity").(int)
 		}
+		if d.HasChange("labels") {
+			updateConfig["labels"] = d.Get("labels")
+		}
+		if d.HasChange("on_demand_profile") {
+			updateConfig["onDemandProfile"] = expandOnDemandProfile(d.Get("on_demand_profile").([]interface{}))
+		}
+		if d.HasChanges("web3", "web3_deployments") {
+			addLegacyBlock(updateConfig, "web3", "web3Deployments", expandWeb3(d.Get("web3").([]interface{}), d.Get("web3_deployments").(bool)), true)
+		}
 		if d.HasChange("tags") {
 			updateConfig["tags"] = d.Get("tags")
 		}
@@ -196,6 +278,12 @@ func resourceWaypointRunnerUpdate(ctx context.Context, d *schema.ResourceData, m
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("failed to update Waypoint runner: %w", err))
 		}
+
+		if d.HasChange("tags") {
+			if err := deleteRemovedTags(config, fmt.Sprintf("/cloud/project/waypoint/runner/%s", runnerId), d); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to update Waypoint runner: %w", err))
+			}
+		}
 	}
 
 	return resourceWaypointRunnerRead(ctx, d, meta)
@@ -207,11 +295,62 @@ func resourceWaypointRunnerDelete(ctx context.Context, d *schema.ResourceData, m
 
 	runnerId := d.Id()
 
-	err := config.OVHClient.Delete(fmt.Sprintf("/cloud/project/waypoint/runner/%s", runnerId), nil)
+	path := fmt.Sprintf("/cloud/project/waypoint/runner/%s", runnerId)
+
+	err := config.OVHClient.Delete(path, nil)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("failed to delete Waypoint runner: %w", err))
 	}
 
+	if err := waitForClusterDeleted(ctx, config, path, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed waiting for Waypoint runner deletion: %w", err))
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func resourceWaypointRunnerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return validateOnDemandProfile(d)
+}
+
+var (
+	runnerLabelKeyPattern   = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]{0,61}[a-zA-Z0-9])?$`)
+	runnerLabelValuePattern = regexp.MustCompile(`^[^\x00-\x1f]{0,255}$`)
+)
+
+// validateRunnerLabels checks runner labels against Waypoint's constraints:
+// keys of at most 63 letters, numbers, dots, underscores, slashes and hyphens,
+// starting and ending with a letter or number and outside the reserved
+// waypoint/ prefix, and values of at most 255 printable characters.
+func validateRunnerLabels(v interface{}, k string) (ws []string, errors []error) {
+	labels, ok := v.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be map", k))
+		return
+	}
+
+	for key, value := range labels {
+		if !runnerLabelKeyPattern.MatchString(key) {
+			errors = append(errors, fmt.Errorf("%s key %q must be 1 to 63 letters, numbers, dots, underscores, slashes or hyphens, starting and ending with a letter or number", k, key))
+			continue
+		}
+		if strings.HasPrefix(key, "waypoint/") {
+			errors = append(errors, fmt.Errorf("%s key %q uses the reserved waypoint/ prefix", k, key))
+			continue
+		}
+		if s, ok := value.(string); ok && !runnerLabelValuePattern.MatchString(s) {
+			errors = append(errors, fmt.Errorf("%s value for %q must be at most 255 printable characters", k, key))
+		}
+	}
+	return
+}
+
+// flattenRunnerLabels returns the labels of a runner, or an empty map when it
+// has none, so that labels removed on the OVH side also disappear from state.
+func flattenRunnerLabels(runner map[string]interface{}) map[string]interface{} {
+	if labels, ok := runner["labels"].(map[string]interface{}); ok {
+		return labels
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testWaypointRunnerRawConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":          "test-runner",
+		"region":        "GRA",
+		"instance_type": "b2-7",
+	}
+}
+
+func TestWaypointRunner_labelsValidation(t *testing.T) {
+	cases := map[string]struct {
+		labels      map[string]interface{}
+		expectError bool
+	}{
+		"valid":             {labels: map[string]interface{}{"env": "prod", "team.io/owner": "platform", "gpu": ""}},
+		"invalid character": {labels: map[string]interface{}{"env name": "prod"}, expectError: true},
+		"leading dot":       {labels: map[string]interface{}{".env": "prod"}, expectError: true},
+		"key too long":      {labels: map[string]interface{}{strings.Repeat("a", 64): "prod"}, expectError: true},
+		"reserved prefix":   {labels: map[string]interface{}{"waypoint/runner": "x"}, expectError: true},
+		"value too long":    {labels: map[string]interface{}{"env": strings.Repeat("a", 256)}, expectError: true},
+		"control character": {labels: map[string]interface{}{"env": "prod\n"}, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testWaypointRunnerRawConfig()
+			raw["labels"] = tc.labels
+
+			diags := resourceWaypointRunner().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if tc.expectError && !diags.HasError() {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && diags.HasError() {
+				t.Errorf("unexpected error: %v", diags)
+			}
+		})
+	}
+}
+
+func TestWaypointRunnerCreate_labels(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "runner-123"}`, nil)
+	mock.AddResponse(200, `{"id": "runner-123", "name": "test-runner", "labels": {"env": "prod"}, "matchedProjects": ["web", "api"]}`, nil)
+
+	raw := testWaypointRunnerRawConfig()
+	raw["runner_type"] = "on-demand"
+	raw["labels"] = map[string]interface{}{"env": "prod"}
+	d := schema.TestResourceDataRaw(t, resourceWaypointRunner().Schema, raw)
+
+	if diags := resourceWaypointRunnerCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	if expected := map[string]interface{}{"env": "prod"}; !reflect.DeepEqual(body["labels"], expected) {
+		t.Errorf("expected labels to be %v, got %v", expected, body["labels"])
+	}
+
+	if got := d.Get("matched_projects").([]interface{}); !reflect.DeepEqual(got, []interface{}{"web", "api"}) {
+		t.Errorf("expected matched_projects [web api], got %v", got)
+	}
+}
+
+// TestWaypointRunner_onDemandProfileRunnerType checks that on_demand_profile
+// is rejected at plan time on runners that are not on-demand
+func TestWaypointRunner_onDemandProfileRunnerType(t *testing.T) {
+	cases := map[string]struct {
+		runnerType  string
+		expectError bool
+	}{
+		"on-demand":  {runnerType: "on-demand"},
+		"static":     {runnerType: "static", expectError: true},
+		"kubernetes": {runnerType: "kubernetes", expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testWaypointRunnerRawConfig()
+			raw["runner_type"] = tc.runnerType
+			raw["on_demand_profile"] = []interface{}{
+				map[string]interface{}{"plugin_type": "docker"},
+			}
+
+			_, err := resourceWaypointRunner().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(raw), nil)
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestWaypointRunnerCreate_onDemandProfile(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "runner-123"}`, nil)
+	mock.AddResponse(200, `{"id": "runner-123", "name": "test-runner", "runnerType": "on-demand", "profileId": "profile-456",
+		"onDemandProfile": {"pluginType": "nomad", "pluginConfig": {"datacenter": "gra"}, "defaultEnv": {"LOG_LEVEL": "debug"}}}`, nil)
+
+	raw := testWaypointRunnerRawConfig()
+	raw["runner_type"] = "on-demand"
+	raw["on_demand_profile"] = []interface{}{
+		map[string]interface{}{
+			"plugin_type":   "nomad",
+			"plugin_config": map[string]interface{}{"datacenter": "gra"},
+			"default_env":   map[string]interface{}{"LOG_LEVEL": "debug"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, resourceWaypointRunner().Schema, raw)
+
+	if diags := resourceWaypointRunnerCreate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[0]), &body); err != nil {
+		t.Fatalf("failed to decode request body: %s", err)
+	}
+	expected := map[string]interface{}{
+		"pluginType":   "nomad",
+		"pluginConfig": map[string]interface{}{"datacenter": "gra"},
+		"defaultEnv":   map[string]interface{}{"LOG_LEVEL": "debug"},
+	}
+	if !reflect.DeepEqual(body["onDemandProfile"], expected) {
+		t.Errorf("expected onDemandProfile to be %v, got %v", expected, body["onDemandProfile"])
+	}
+
+	if got := d.Get("profile_id").(string); got != "profile-456" {
+		t.Errorf("expected profile_id profile-456, got %q", got)
+	}
+	if got := d.Get("on_demand_profile.0.default_env.LOG_LEVEL").(string); got != "debug" {
+		t.Errorf("expected the profile to be read back, got %q", got)
+	}
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// sweepTimeout bounds how long a sweeper waits for a single resource to be deleted.
+const sweepTimeout = 30 * time.Minute
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	// Nomad clusters can reference Vault and Consul clusters through
+	// vault_cluster_id and consul_cluster_id, so they are swept first.
+	clusterDependencies := map[string][]string{
+		"nomad":    nil,
+		"vault":    {"hashicorp_ovh_nomad_cluster"},
+		"consul":   {"hashicorp_ovh_nomad_cluster"},
+		"boundary": nil,
+	}
+
+	for service, dependencies := range clusterDependencies {
+		service := service
+		resource.AddTestSweepers(fmt.Sprintf("hashicorp_ovh_%s_cluster", service), &resource.Sweeper{
+			Name:         fmt.Sprintf("hashicorp_ovh_%s_cluster", service),
+			Dependencies: dependencies,
+			F: func(region string) error {
+				return sweepResources(region, fmt.Sprintf("/cloud/project/%s/cluster", service), func(ctx context.Context, config *Config, id string) error {
+					return deleteCluster(ctx, config, service, id, true, sweepTimeout)
+				})
+			},
+		})
+	}
+
+	resource.AddTestSweepers("hashicorp_ovh_waypoint_runner", &resource.Sweeper{
+		Name: "hashicorp_ovh_waypoint_runner",
+		F: func(region string) error {
+			return sweepResources(region, "/cloud/project/waypoint/runner", deleteAndWait("/cloud/project/waypoint/runner"))
+		},
+	})
+
+	resource.AddTestSweepers("hashicorp_ovh_packer_template", &resource.Sweeper{
+		Name: "hashicorp_ovh_packer_template",
+		F: func(region string) error {
+			return sweepResources(region, "/cloud/project/packer/template", deleteAndWait("/cloud/project/packer/template"))
+		},
+	})
+
+	// Vault clusters can auto-unseal with a KMS key, so they are swept first.
+	resource.AddTestSweepers("hashicorp_ovh_kms_key", &resource.Sweeper{
+		Name:         "hashicorp_ovh_kms_key",
+		Dependencies: []string{"hashicorp_ovh_vault_cluster"},
+		F: func(region string) error {
+			return sweepResources(region, "/cloud/project/kms/key", deleteAndWait("/cloud/project/kms/key"))
+		},
+	})
+}
+
+// isSweepable reports whether an API object was created by the acceptance
+// tests, either by its name prefix or by the test tag.
+func isSweepable(item map[string]interface{}) bool {
+	if strings.HasPrefix(getString(item, "name"), TestResourcePrefix) {
+		return true
+	}
+
+	tags, _ := item["tags"].(map[string]interface{})
+	return tags[TestTagKey] == TestTagValue
+}
+
+// sweepResources lists the objects under listPath and deletes those in region
+// left behind by the acceptance tests. The region "all" matches every region.
+func sweepResources(region, listPath string, del func(ctx context.Context, config *Config, id string) error) error {
+	config, err := sharedTestConfig()
+	if err != nil {
+		return err
+	}
+
+	var items []map[string]interface{}
+	if err := config.OVHClient.Get(listPath, &items); err != nil {
+		return fmt.Errorf("failed to list %s: %w", listPath, err)
+	}
+
+	var errs []string
+	for _, item := range items {
+		if !isSweepable(item) {
+			continue
+		}
+		if region != "all" && !strings.EqualFold(getString(item, "region"), region) {
+			continue
+		}
+
+		id := getString(item, "id")
+		log.Printf("[INFO] Sweeping %s/%s (%s)", listPath, id, getString(item, "name"))
+		if err := del(context.Background(), config, id); err != nil {
+			errs = append(errs, fmt.Sprintf("%s/%s: %s", listPath, id, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to sweep %d resources:\n%s", len(errs), strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// deleteAndWait deletes an object under basePath and waits for it to be gone.
+func deleteAndWait(basePath string) func(ctx context.Context, config *Config, id string) error {
+	return func(ctx context.Context, config *Config, id string) error {
+		path := fmt.Sprintf("%s/%s", basePath, id)
+		if err := config.OVHClient.Delete(path, nil); err != nil {
+			return err
+		}
+		return waitForClusterDeleted(ctx, config, path, sweepTimeout)
+	}
+}
+
+func TestIsSweepable(t *testing.T) {
+	cases := map[string]struct {
+		item     map[string]interface{}
+		expected bool
+	}{
+		"test prefix": {item: map[string]interface{}{"name": TestResourcePrefix + "-nomad-abc"}, expected: true},
+		"test tag":    {item: map[string]interface{}{"name": "nomad", "tags": map[string]interface{}{TestTagKey: TestTagValue}}, expected: true},
+		"real":        {item: map[string]interface{}{"name": "production-nomad", "tags": map[string]interface{}{"env": "prod"}}},
+		"no tags":     {item: map[string]interface{}{"name": "production-nomad"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isSweepable(tc.item); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
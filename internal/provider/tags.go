@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func tagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Description: "Tags on the cluster record, as metadata of the logical cluster. They are not applied to its instances, see instance_tags",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+func instanceTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Description: "Tags propagated to the OVH Public Cloud instances of the cluster, to filter them or break down their billing",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// flattenTags returns the tags of an API object, or an empty map when it has
+// none, so that tags removed on the OVH side also disappear from state.
+func flattenTags(m map[string]interface{}) map[string]interface{} {
+	if tags, ok := m["tags"].(map[string]interface{}); ok {
+		return tags
+	}
+	return map[string]interface{}{}
+}
+
+// flattenInstanceTags returns the instance tags of an API cluster object, or
+// an empty map when it has none.
+func flattenInstanceTags(m map[string]interface{}) map[string]interface{} {
+	if tags, ok := m["instanceTags"].(map[string]interface{}); ok {
+		return tags
+	}
+	return map[string]interface{}{}
+}
+
+// removedTagKeys returns the keys present in the previous tags but not in the
+// planned ones, sorted for a stable request order.
+func removedTagKeys(d *schema.ResourceData) []string {
+	old, new := d.GetChange("tags")
+	oldTags, _ := old.(map[string]interface{})
+	newTags, _ := new.(map[string]interface{})
+
+	removed := []string{}
+	for key := range oldTags {
+		if _, ok := newTags[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// deleteRemovedTags deletes the tags removed from configuration. OVH merges
+// the tags sent on update into the existing ones, so removed keys have to be
+// deleted explicitly under path.
+func deleteRemovedTags(config *Config, path string, d *schema.ResourceData) error {
+	for _, key := range removedTagKeys(d) {
+		err := config.OVHClient.Delete(fmt.Sprintf("%s/tag/%s", path, url.PathEscape(key)), nil)
+		if err != nil && !isOVHErrorCode(err, http.StatusNotFound) {
+			return fmt.Errorf("failed to remove tag %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// hasOnlyTagChanges reports whether tags changed while none of keys did, in
+// which case the tags endpoint can be used instead of a full update.
+func hasOnlyTagChanges(d *schema.ResourceData, keys ...string) bool {
+	return d.HasChange("tags") && !d.HasChanges(keys...)
+}
+
+// updateTags replaces the tags of the object under path through its tags
+// endpoint. Unlike a full update it does not roll the cluster nodes, and as it
+// replaces the whole set removed keys do not need deleting one by one.
+func updateTags(config *Config, path string, d *schema.ResourceData) error {
+	return config.OVHClient.Put(path+"/tags", map[string]interface{}{"tags": d.Get("tags")}, nil)
+}
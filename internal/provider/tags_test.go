@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestWaypointRunnerUpdate_removedTag checks that a tag removed alongside
+// another change is deleted on the OVH side and disappears from state
+func TestWaypointRunnerUpdate_removedTag(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "runner-123", "name": "test-runner", "tags": {"Environment": "staging"}}`, nil)
+
+	r := resourceWaypointRunner()
+	state := &sdkterraform.InstanceState{
+		ID: "runner-123",
+		Attributes: map[string]string{
+			"id":               "runner-123",
+			"name":             "test-runner",
+			"capacity":         "10",
+			"tags.%":           "2",
+			"tags.Environment": "test",
+			"tags.ManagedBy":   "terraform",
+		},
+	}
+	config := sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "test-runner",
+		"capacity": 20,
+		"tags":     map[string]interface{}{"Environment": "staging"},
+	})
+
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	if diags := resourceWaypointRunnerUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	deleteRequest := mock.Requests[1]
+	if deleteRequest.Method != http.MethodDelete || deleteRequest.URL.Path != "/cloud/project/waypoint/runner/runner-123/tag/ManagedBy" {
+		t.Errorf("expected the removed tag to be deleted, got %s %s", deleteRequest.Method, deleteRequest.URL.Path)
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	if _, ok := tags["ManagedBy"]; ok {
+		t.Error("expected tags.ManagedBy to be removed from state")
+	}
+	if tags["Environment"] != "staging" {
+		t.Errorf("expected tags.Environment to be staging, got %v", tags["Environment"])
+	}
+}
+
+// TestVaultClusterUpdate_tagsOnly checks that a change limited to tags goes
+// through the tags endpoint rather than a full cluster update
+func TestVaultClusterUpdate_tagsOnly(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "nodeCount": 3, "status": "READY", "tags": {"Environment": "staging"}}`, nil)
+
+	r := resourceVaultCluster()
+	state := &sdkterraform.InstanceState{
+		ID: "vault-123",
+		Attributes: map[string]string{
+			"id":               "vault-123",
+			"name":             "test-vault",
+			"region":           "GRA",
+			"node_count":       "3",
+			"instance_type":    "c2-15",
+			"auto_unseal":      "true",
+			"tags.%":           "2",
+			"tags.Environment": "test",
+			"tags.ManagedBy":   "terraform",
+		},
+	}
+	raw := testVaultClusterRawConfig()
+	raw["tags"] = map[string]interface{}{"Environment": "staging"}
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	if diags := resourceVaultClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := mock.GetRequestCount(); got != 4 {
+		t.Fatalf("expected the READY check, the tags update, a read and a CA certificate read, got %d requests", got)
+	}
+	tagsRequest := mock.Requests[1]
+	if tagsRequest.Method != http.MethodPut || tagsRequest.URL.Path != "/cloud/project/vault/cluster/vault-123/tags" {
+		t.Errorf("expected the tags endpoint to be used, got %s %s", tagsRequest.Method, tagsRequest.URL.Path)
+	}
+	if body := mock.RequestBodies[1]; body != `{"tags":{"Environment":"staging"}}` {
+		t.Errorf("expected the full tag set to be sent, got %s", body)
+	}
+}
+
+// TestVaultClusterUpdate_instanceTags checks that instance_tags are sent as
+// instanceTags in a cluster update, separately from the cluster tags
+func TestVaultClusterUpdate_instanceTags(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "nodeCount": 3, "status": "READY", "tags": {"Environment": "test"}, "instanceTags": {"CostCenter": "42"}}`, nil)
+
+	r := resourceVaultCluster()
+	state := &sdkterraform.InstanceState{
+		ID: "vault-123",
+		Attributes: map[string]string{
+			"id":               "vault-123",
+			"name":             "test-vault",
+			"region":           "GRA",
+			"node_count":       "3",
+			"instance_type":    "c2-15",
+			"auto_unseal":      "true",
+			"status":           "READY",
+			"tags.%":           "1",
+			"tags.Environment": "test",
+		},
+	}
+	raw := testVaultClusterRawConfig()
+	raw["tags"] = map[string]interface{}{"Environment": "test"}
+	raw["instance_tags"] = map[string]interface{}{"CostCenter": "42"}
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	if diags := resourceVaultClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	updateRequest := mock.Requests[1]
+	if updateRequest.Method != http.MethodPut || updateRequest.URL.Path != "/cloud/project/vault/cluster/vault-123" {
+		t.Fatalf("expected a cluster update, got %s %s", updateRequest.Method, updateRequest.URL.Path)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(mock.RequestBodies[1]), &body); err != nil {
+		t.Fatalf("failed to decode update body: %s", err)
+	}
+	if _, ok := body["tags"]; ok {
+		t.Errorf("expected the unchanged cluster tags not to be sent, got %v", body["tags"])
+	}
+	if tags, _ := body["instanceTags"].(map[string]interface{}); tags["CostCenter"] != "42" {
+		t.Errorf("expected instanceTags.CostCenter 42, got %v", body["instanceTags"])
+	}
+	if got := d.Get("instance_tags").(map[string]interface{}); got["CostCenter"] != "42" {
+		t.Errorf("expected instance_tags.CostCenter 42 in state, got %v", got)
+	}
+}
+
+func TestFlattenTags(t *testing.T) {
+	if got := flattenTags(map[string]interface{}{"id": "runner-123"}); len(got) != 0 {
+		t.Errorf("expected no tags for an object without tags, got %v", got)
+	}
+}
@@ -1,19 +1,24 @@
 package provider
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/ovh/go-ovh/ovh"
 )
 
 // TestAccProviderFactories contains the provider factory for acceptance tests
@@ -27,6 +32,11 @@ const (
 	DefaultTestTimeout = 30 * time.Minute
 	DefaultTestRegion  = "eu-west-1"
 	DefaultTestZone    = "eu-west-1a"
+
+	// DefaultCheckAttempts and DefaultCheckDelay configure RetryCheck in the
+	// existence checks, waiting up to about two minutes in total.
+	DefaultCheckAttempts = 6
+	DefaultCheckDelay    = 4 * time.Second
 )
 
 // Test environment variables
@@ -128,9 +138,85 @@ func TestAccPreCheckOptional(t *testing.T, envVars ...string) {
 	}
 }
 
-// TestAccCheckResourceExists is a generic function to check if a resource exists
-func TestAccCheckResourceExists(resourceName string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
+// testResourcePaths maps each resource type to the API collection its objects
+// live in. Nested resources add the kind of child object under their cluster,
+// whose ID is the first part of the resource ID.
+var testResourcePaths = map[string]struct{ base, child string }{
+	"hashicorp_ovh_nomad_cluster":    {base: "/cloud/project/nomad/cluster"},
+	"hashicorp_ovh_nomad_namespace":  {base: "/cloud/project/nomad/cluster", child: "namespace"},
+	"hashicorp_ovh_nomad_quota":      {base: "/cloud/project/nomad/cluster", child: "quota"},
+	"hashicorp_ovh_nomad_job":        {base: "/cloud/project/nomad/cluster", child: "job"},
+	"hashicorp_ovh_vault_cluster":    {base: "/cloud/project/vault/cluster"},
+	"hashicorp_ovh_vault_policy":     {base: "/cloud/project/vault/cluster", child: "policy"},
+	"hashicorp_ovh_consul_cluster":   {base: "/cloud/project/consul/cluster"},
+	"hashicorp_ovh_consul_intention": {base: "/cloud/project/consul/cluster", child: "intention"},
+	"hashicorp_ovh_consul_kv":        {base: "/cloud/project/consul/cluster", child: "kv"},
+	"hashicorp_ovh_consul_service":   {base: "/cloud/project/consul/cluster", child: "service"},
+	"hashicorp_ovh_boundary_cluster": {base: "/cloud/project/boundary/cluster"},
+	"hashicorp_ovh_waypoint_runner":  {base: "/cloud/project/waypoint/runner"},
+	"hashicorp_ovh_packer_template":  {base: "/cloud/project/packer/template"},
+	"hashicorp_ovh_kms_key":          {base: "/cloud/project/kms/key"},
+	"hashicorp_ovh_private_network":  {base: "/cloud/project/" + TestOVHProjectID + "/network/private"},
+	"hashicorp_ovh_subnet":           {base: "/cloud/project/" + TestOVHProjectID + "/network/private", child: "subnet"},
+	"hashicorp_ovh_project_user":     {base: "/cloud/project/" + TestOVHProjectID + "/user"},
+}
+
+// testResourcePath returns the API path of the object behind a resource.
+func testResourcePath(resourceType, id string) (string, error) {
+	paths, ok := testResourcePaths[resourceType]
+	if !ok {
+		return "", fmt.Errorf("no API path known for resource type %s", resourceType)
+	}
+	if paths.child == "" {
+		return fmt.Sprintf("%s/%s", paths.base, id), nil
+	}
+
+	clusterId, rest, ok := strings.Cut(id, "/")
+	if !ok {
+		return "", fmt.Errorf("unexpected ID %q for resource type %s", id, resourceType)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", paths.base, clusterId, paths.child, rest), nil
+}
+
+// sharedTestConfig builds a provider Config from the OVH_* environment
+// variables used by the acceptance tests, for sweepers and checks that call
+// the API directly.
+func sharedTestConfig() (*Config, error) {
+	if TestOVHEndpoint == "" {
+		return nil, fmt.Errorf("OVH_ENDPOINT must be set")
+	}
+
+	client, err := ovh.NewClient(TestOVHEndpoint, TestOVHApplicationKey, TestOVHSecret, TestOVHConsumerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OVH client: %w", err)
+	}
+
+	return &Config{
+		OVHClient: newLockedClient(client),
+		Endpoint:  TestOVHEndpoint,
+		ProjectID: TestOVHProjectID,
+	}, nil
+}
+
+// testAccGetClient returns a Config for acceptance test checks. Like
+// resource.Test, it skips the test unless TF_ACC is set.
+func testAccGetClient(t *testing.T) *Config {
+	t.Helper()
+
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("Acceptance tests skipped unless env 'TF_ACC' set")
+	}
+
+	config, err := sharedTestConfig()
+	if err != nil {
+		t.Fatalf("failed to configure OVH client for acceptance tests: %s", err)
+	}
+	return config
+}
+
+// TestAccCheckResourceExists checks that the object behind a resource can be read from the API
+func TestAccCheckResourceExists(config *Config, resourceName string) resource.TestCheckFunc {
+	return RetryCheck(func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
 		if !ok {
 			return fmt.Errorf("resource not found: %s", resourceName)
@@ -140,28 +226,67 @@ func TestAccCheckResourceExists(resourceName string) resource.TestCheckFunc {
 			return fmt.Errorf("no ID is set for resource: %s", resourceName)
 		}
 
+		path, err := testResourcePath(rs.Type, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		var object map[string]interface{}
+		if err := config.OVHClient.Get(path, &object); err != nil {
+			return fmt.Errorf("failed to read %s: %w", resourceName, err)
+		}
+
 		return nil
+	}, DefaultCheckAttempts, DefaultCheckDelay)
+}
+
+// RetryCheck re-runs f until it passes or attempts runs have failed, doubling
+// delay after each failure. It absorbs the OVH API's eventual consistency, such
+// as a new cluster not being listed yet or still reporting a stale status.
+func RetryCheck(f resource.TestCheckFunc, attempts int, delay time.Duration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var err error
+		for attempt := 1; attempt <= attempts; attempt++ {
+			if err = f(s); err == nil {
+				return nil
+			}
+			if attempt < attempts {
+				time.Sleep(delay)
+				delay *= 2
+			}
+		}
+		return fmt.Errorf("check still failing after %d attempts: %w", attempts, err)
 	}
 }
 
-// TestAccCheckResourceDestroy is a generic function to check if a resource is destroyed
-func TestAccCheckResourceDestroy(resourceType string) resource.TestCheckFunc {
+// TestAccCheckResourceDestroy checks that the API answers 404 for every resource of the given type
+func TestAccCheckResourceDestroy(config *Config, resourceType string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		for _, rs := range s.RootModule().Resources {
 			if rs.Type != resourceType {
 				continue
 			}
 
-			if rs.Primary.ID != "" {
+			path, err := testResourcePath(rs.Type, rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			var object map[string]interface{}
+			err = config.OVHClient.Get(path, &object)
+			if err == nil {
 				return fmt.Errorf("resource %s still exists with ID: %s", resourceType, rs.Primary.ID)
 			}
+			if !isOVHErrorCode(err, http.StatusNotFound) {
+				return fmt.Errorf("failed to check %s %s was destroyed: %w", resourceType, rs.Primary.ID, err)
+			}
 		}
 		return nil
 	}
 }
 
-// TestAccCheckResourceDisappears simulates a resource disappearing outside of Terraform
-func TestAccCheckResourceDisappears(resourceName string) resource.TestCheckFunc {
+// TestAccCheckResourceDisappears deletes the object behind a resource outside of Terraform
+func TestAccCheckResourceDisappears(config *Config, resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
 		if !ok {
@@ -172,9 +297,15 @@ func TestAccCheckResourceDisappears(resourceName string) resource.TestCheckFunc
 			return fmt.Errorf("no ID is set for resource: %s", resourceName)
 		}
 
-		// In a real implementation, you would make an API call to delete the resource
-		// For testing purposes, we simulate the deletion
-		return nil
+		path, err := testResourcePath(rs.Type, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := config.OVHClient.Delete(path, nil); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", resourceName, err)
+		}
+		return waitForClusterDeleted(context.Background(), config, path, DefaultTestTimeout)
 	}
 }
 
@@ -221,11 +352,15 @@ func TestAccCheckResourceTags(resourceName string, expectedTags map[string]strin
 	}
 }
 
-// MockHTTPServer creates a mock HTTP server for testing
+// MockHTTPServer creates a mock HTTP server for testing. It is safe for
+// concurrent requests; read Requests directly only once they have completed.
 type MockHTTPServer struct {
 	*httptest.Server
-	Requests  []*http.Request
-	Responses []MockResponse
+	Requests      []*http.Request
+	RequestBodies []string
+	Responses     []MockResponse
+
+	mu sync.Mutex
 }
 
 // MockResponse represents a mock HTTP response
@@ -233,6 +368,8 @@ type MockResponse struct {
 	StatusCode int
 	Body       string
 	Headers    map[string]string
+	// Delay holds the response back, to simulate a slow API.
+	Delay time.Duration
 }
 
 // NewMockHTTPServer creates a new mock HTTP server
@@ -243,11 +380,30 @@ func NewMockHTTPServer() *MockHTTPServer {
 	}
 
 	mock.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The OVH client syncs its clock before the first signed call; answer
+		// that here so queued responses only cover the requests under test.
+		// The suffix also matches base URLs with a path, as service_endpoints.
+		if strings.HasSuffix(r.URL.Path, "/auth/time") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(strconv.FormatInt(time.Now().Unix(), 10)))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		mock.mu.Lock()
 		mock.Requests = append(mock.Requests, r)
+		mock.RequestBodies = append(mock.RequestBodies, string(body))
 
+		var response *MockResponse
 		if len(mock.Responses) > 0 {
-			response := mock.Responses[0]
+			response = &mock.Responses[0]
 			mock.Responses = mock.Responses[1:]
+		}
+		mock.mu.Unlock()
+
+		if response != nil {
+			time.Sleep(response.Delay)
 
 			for key, value := range response.Headers {
 				w.Header().Set(key, value)
@@ -270,6 +426,8 @@ func (m *MockHTTPServer) AddResponse(statusCode int, body string, headers map[st
 	}
 	headers["Content-Type"] = "application/json"
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.Responses = append(m.Responses, MockResponse{
 		StatusCode: statusCode,
 		Body:       body,
@@ -277,19 +435,42 @@ func (m *MockHTTPServer) AddResponse(statusCode int, body string, headers map[st
 	})
 }
 
+// AddDelayedResponse adds a mock response to the queue which is only sent
+// after delay
+func (m *MockHTTPServer) AddDelayedResponse(statusCode int, body string, delay time.Duration) {
+	m.AddResponse(statusCode, body, nil)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Responses[len(m.Responses)-1].Delay = delay
+}
+
 // GetRequestCount returns the number of requests received
 func (m *MockHTTPServer) GetRequestCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return len(m.Requests)
 }
 
 // GetLastRequest returns the last request received
 func (m *MockHTTPServer) GetLastRequest() *http.Request {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if len(m.Requests) == 0 {
 		return nil
 	}
 	return m.Requests[len(m.Requests)-1]
 }
 
+// NewConfig returns a provider Config whose OVH client talks to the mock server
+func (m *MockHTTPServer) NewConfig(t *testing.T) *Config {
+	client, err := ovh.NewClient(m.URL, "test-app-key", "test-app-secret", "test-consumer-key")
+	if err != nil {
+		t.Fatalf("failed to create OVH client for mock server: %s", err)
+	}
+	return &Config{OVHClient: newLockedClient(client)}
+}
+
 
 
 // TestProviderConfig generates a provider configuration for testing
@@ -442,6 +623,22 @@ data "hashicorp_ovh_consul_clusters" "test" {}
 `, TestProviderConfig())
 }
 
+func TestDataSourceWaypointRunnersConfig() string {
+	return fmt.Sprintf(`
+%s
+
+data "hashicorp_ovh_waypoint_runners" "test" {}
+`, TestProviderConfig())
+}
+
+func TestDataSourcePackerTemplatesConfig() string {
+	return fmt.Sprintf(`
+%s
+
+data "hashicorp_ovh_packer_templates" "test" {}
+`, TestProviderConfig())
+}
+
 // ValidationHelper provides common validation functions
 type ValidationHelper struct {
 	t *testing.T
@@ -454,19 +651,7 @@ func NewValidationHelper(t *testing.T) *ValidationHelper {
 
 // ValidateResourceName checks if a resource name is valid
 func (vh *ValidationHelper) ValidateResourceName(name string) error {
-	if len(name) < 3 || len(name) > 50 {
-		return fmt.Errorf("resource name must be between 3 and 50 characters")
-	}
-
-	matched, err := regexp.MatchString("^[a-zA-Z][a-zA-Z0-9-]*$", name)
-	if err != nil {
-		return err
-	}
-	if !matched {
-		return fmt.Errorf("resource name must start with a letter and contain only letters, numbers, and hyphens")
-	}
-
-	return nil
+	return checkResourceName("resource name", name)
 }
 
 // ValidateRegion checks if a region is valid
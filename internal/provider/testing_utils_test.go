@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestRetryCheck(t *testing.T) {
+	cases := map[string]struct {
+		failures      int
+		attempts      int
+		expectError   bool
+		expectedCalls int
+	}{
+		"passes first time":  {failures: 0, attempts: 3, expectedCalls: 1},
+		"passes after retry": {failures: 2, attempts: 3, expectedCalls: 3},
+		"attempts exhausted": {failures: 5, attempts: 3, expectError: true, expectedCalls: 3},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			check := RetryCheck(func(s *terraform.State) error {
+				calls++
+				if calls <= tc.failures {
+					return fmt.Errorf("stale status")
+				}
+				return nil
+			}, tc.attempts, time.Millisecond)
+
+			err := check(&terraform.State{})
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryCheck_backoff(t *testing.T) {
+	var calls []time.Time
+	check := RetryCheck(func(s *terraform.State) error {
+		calls = append(calls, time.Now())
+		return fmt.Errorf("not listed yet")
+	}, 3, 20*time.Millisecond)
+
+	if err := check(&terraform.State{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if second := calls[2].Sub(calls[1]); second < 40*time.Millisecond {
+		t.Errorf("expected the second delay to be doubled to at least 40ms, got %s", second)
+	}
+}
+
+func TestTestResourcePath(t *testing.T) {
+	cases := map[string]struct {
+		resourceType string
+		id           string
+		expected     string
+		expectError  bool
+	}{
+		"cluster":      {resourceType: "hashicorp_ovh_vault_cluster", id: "vault-123", expected: "/cloud/project/vault/cluster/vault-123"},
+		"nested":       {resourceType: "hashicorp_ovh_nomad_namespace", id: "nomad-123/team-a", expected: "/cloud/project/nomad/cluster/nomad-123/namespace/team-a"},
+		"nested path":  {resourceType: "hashicorp_ovh_consul_kv", id: "consul-123/config/app", expected: "/cloud/project/consul/cluster/consul-123/kv/config/app"},
+		"malformed ID": {resourceType: "hashicorp_ovh_vault_policy", id: "vault-123", expectError: true},
+		"unknown type": {resourceType: "hashicorp_ovh_unknown", id: "x", expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := testResourcePath(tc.resourceType, tc.id)
+			if tc.expectError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func testStateWithResource(resourceType, id string) *terraform.State {
+	return &terraform.State{
+		Modules: []*terraform.ModuleState{
+			{
+				Path: []string{"root"},
+				Resources: map[string]*terraform.ResourceState{
+					resourceType + ".test": {
+						Type:    resourceType,
+						Primary: &terraform.InstanceState{ID: id},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestCheckResourceDestroy_callsAPI checks that destruction is confirmed by
+// a 404 from the API rather than by the state alone
+func TestCheckResourceDestroy_callsAPI(t *testing.T) {
+	cases := map[string]struct {
+		statusCode  int
+		expectError bool
+	}{
+		"deleted":      {statusCode: 404},
+		"still exists": {statusCode: 200, expectError: true},
+		"api error":    {statusCode: 500, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(tc.statusCode, `{"id": "vault-123"}`, nil)
+
+			check := TestAccCheckResourceDestroy(mock.NewConfig(t), "hashicorp_ovh_vault_cluster")
+			err := check(testStateWithResource("hashicorp_ovh_vault_cluster", "vault-123"))
+			if tc.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/vault/cluster/vault-123" {
+				t.Errorf("unexpected request path %s", got)
+			}
+		})
+	}
+}
+
+func TestCheckResourceExists_callsAPI(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123"}`, nil)
+
+	check := TestAccCheckResourceExists(mock.NewConfig(t), "hashicorp_ovh_vault_cluster.test")
+	if err := check(testStateWithResource("hashicorp_ovh_vault_cluster", "vault-123")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if got := mock.GetLastRequest().URL.Path; got != "/cloud/project/vault/cluster/vault-123" {
+		t.Errorf("unexpected request path %s", got)
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// planSealMigration checks a change of the seal of an existing Vault cluster,
+// auto_unseal or auto_unseal_key_id, which is applied by migrating the seal.
+// A failed migration can leave Vault unable to unseal its data, so it must be
+// confirmed with allow_seal_migration. When auto_unseal changes without
+// auto_unseal_key_id, the key is provisioned or dropped by OVH and only known
+// after the migration.
+func planSealMigration(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.HasChanges("auto_unseal", "auto_unseal_key_id") || replacesVaultCluster(d) {
+		return nil
+	}
+
+	if !d.Get("allow_seal_migration").(bool) {
+		return fmt.Errorf("changing auto_unseal or auto_unseal_key_id migrates the seal of the Vault cluster, which can make its data unrecoverable if it fails. Take a snapshot of the cluster, then set allow_seal_migration = true to confirm the migration")
+	}
+
+	if d.HasChange("auto_unseal") && !d.HasChange("auto_unseal_key_id") {
+		return d.SetNewComputed("auto_unseal_key_id")
+	}
+	return nil
+}
+
+// replacesVaultCluster reports whether d replaces the Vault cluster, whose
+// replacement is created with the new seal and needs no migration: a ForceNew
+// attribute changes, or planRecreateIfUnhealthy or planBlueGreenReplacement
+// planned the replacement.
+func replacesVaultCluster(d *schema.ResourceDiff) bool {
+	for key, s := range resourceVaultCluster().Schema {
+		if s.ForceNew && d.HasChange(key) {
+			return true
+		}
+	}
+	return d.HasChange("status") || (isBlueGreen(d) && d.HasChange("instance_type"))
+}
+
+// migrateVaultSeal moves a Vault cluster to the seal set by auto_unseal and
+// auto_unseal_key_id. OVH restarts the nodes one by one with both the old and
+// the new seal configured, so that each can unseal with the old one and
+// rewrap its keys with the new one, then waits for the cluster to be
+// unsealed by the new seal. Migrating to Shamir returns new unseal keys,
+// which are only returned in full here and are stored in unseal_keys.
+//
+// When the migration fails the previous state is kept, so that the next
+// apply migrates again. Once OVH has migrated the seal, new unseal keys are
+// the only way to unseal the cluster, so they are kept even when it then
+// fails to unseal.
+func migrateVaultSeal(ctx context.Context, config *Config, d *schema.ResourceData) error {
+	clusterId := d.Id()
+
+	if err := waitForClusterReady(ctx, config, "vault", clusterId); err != nil {
+		d.Partial(true)
+		return fmt.Errorf("cluster is not ready for seal migration: %w", err)
+	}
+
+	autoUnseal := d.Get("auto_unseal").(bool)
+	migration := map[string]interface{}{
+		"autoUnseal": autoUnseal,
+	}
+	if keyId := d.Get("auto_unseal_key_id").(string); autoUnseal && keyId != "" {
+		if err := checkKMSKeyRegion(config, keyId, d.Get("region").(string)); err != nil {
+			d.Partial(true)
+			return err
+		}
+		migration["autoUnsealKeyId"] = keyId
+	}
+
+	var result map[string]interface{}
+	err := config.OVHClient.Post(fmt.Sprintf("/cloud/project/vault/cluster/%s/seal/migrate", clusterId), migration, &result)
+	if err != nil {
+		d.Partial(true)
+		return fmt.Errorf("failed to migrate the seal: %w", err)
+	}
+	if err := waitForClusterOperation(ctx, config, "vault", clusterId, getString(result, "operationId")); err != nil {
+		d.Partial(true)
+		return fmt.Errorf("failed to migrate the seal: %w", err)
+	}
+
+	keys := getStringList(result, "unsealKeys")
+	if err := waitForClusterReady(ctx, config, "vault", clusterId); err != nil {
+		if len(keys) > 0 {
+			d.Set("unseal_keys", keys)
+		} else {
+			d.Partial(true)
+		}
+		return fmt.Errorf("cluster did not unseal after seal migration: %w", err)
+	}
+
+	if len(keys) > 0 {
+		d.Set("unseal_keys", keys)
+	}
+	mergeConfigJson(d, map[string]interface{}{
+		"autoUnseal":      autoUnseal,
+		"autoUnsealKeyId": migration["autoUnsealKeyId"],
+	})
+	return nil
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testVaultClusterSealState is a READY Vault cluster without auto-unseal
+func testVaultClusterSealState() *sdkterraform.InstanceState {
+	return &sdkterraform.InstanceState{
+		ID: "vault-123",
+		Attributes: map[string]string{
+			"id":                 "vault-123",
+			"name":               "test-vault",
+			"region":             "GRA",
+			"node_count":         "3",
+			"instance_type":      "c2-15",
+			"storage_type":       "consul",
+			"auto_unseal":        "false",
+			"auto_unseal_key_id": "",
+			"status":             "READY",
+		},
+	}
+}
+
+// TestVaultClusterUpdate_sealMigration checks that enabling auto-unseal
+// migrates the seal of the cluster in place and waits for it to unseal again
+func TestVaultClusterUpdate_sealMigration(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"id": "kms-456", "region": "GRA"}`, nil)
+	mock.AddResponse(200, `{"operationId": "op-1"}`, nil)
+	mock.AddResponse(200, `{"id": "op-1", "status": "DONE"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"id": "vault-123", "name": "test-vault", "status": "READY", "autoUnseal": true, "autoUnsealKeyId": "kms-456"}`, nil)
+
+	r := resourceVaultCluster()
+	state := testVaultClusterSealState()
+	raw := testVaultClusterRawConfig()
+	raw["auto_unseal"] = true
+	raw["auto_unseal_key_id"] = "kms-456"
+	raw["allow_seal_migration"] = true
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatal("expected the seal to be migrated in place")
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	if diags := resourceVaultClusterUpdate(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []struct{ method, path string }{
+		{http.MethodGet, "/cloud/project/vault/cluster/vault-123"},
+		{http.MethodGet, "/cloud/project/kms/key/kms-456"},
+		{http.MethodPost, "/cloud/project/vault/cluster/vault-123/seal/migrate"},
+		{http.MethodGet, "/cloud/project/vault/cluster/vault-123/operation/op-1"},
+		{http.MethodGet, "/cloud/project/vault/cluster/vault-123"},
+		{http.MethodGet, "/cloud/project/vault/cluster/vault-123"},
+	}
+	if len(mock.Requests) < len(expected) {
+		t.Fatalf("expected at least %d requests, got %d", len(expected), len(mock.Requests))
+	}
+	for i, e := range expected {
+		if r := mock.Requests[i]; r.Method != e.method || r.URL.Path != e.path {
+			t.Errorf("request %d: expected %s %s, got %s %s", i, e.method, e.path, r.Method, r.URL.Path)
+		}
+	}
+	if body := mock.RequestBodies[2]; body != `{"autoUnseal":true,"autoUnsealKeyId":"kms-456"}` {
+		t.Errorf("expected the migration to the new key, got %s", body)
+	}
+	if got := d.Get("auto_unseal_key_id").(string); got != "kms-456" {
+		t.Errorf("expected auto_unseal_key_id kms-456, got %q", got)
+	}
+}
+
+// TestVaultClusterUpdate_sealMigrationFailure checks that a failed migration
+// keeps the previous seal in state, so that the next apply migrates again
+func TestVaultClusterUpdate_sealMigrationFailure(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "vault-123", "status": "READY"}`, nil)
+	mock.AddResponse(200, `{"id": "kms-456", "region": "GRA"}`, nil)
+	mock.AddResponse(409, `{"message": "Seal migration failed"}`, nil)
+
+	r := resourceVaultCluster()
+	state := testVaultClusterSealState()
+	raw := testVaultClusterRawConfig()
+	raw["auto_unseal"] = true
+	raw["auto_unseal_key_id"] = "kms-456"
+	raw["allow_seal_migration"] = true
+
+	diff, err := r.Diff(context.Background(), state, sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("unexpected error building resource data: %s", err)
+	}
+
+	if diags := resourceVaultClusterUpdate(context.Background(), d, mock.NewConfig(t)); !diags.HasError() {
+		t.Fatal("expected an error")
+	}
+	attributes := d.State().Attributes
+	if attributes["auto_unseal"] != "false" || attributes["auto_unseal_key_id"] != "" {
+		t.Errorf("expected the previous seal to be kept, got auto_unseal %q and auto_unseal_key_id %q", attributes["auto_unseal"], attributes["auto_unseal_key_id"])
+	}
+}
+
+// TestVaultCluster_sealMigrationRequiresConfirmation checks that a change of
+// the seal is rejected at plan time unless allow_seal_migration is set, and
+// that a key provisioned by OVH is only known after the migration
+func TestVaultCluster_sealMigrationRequiresConfirmation(t *testing.T) {
+	raw := testVaultClusterRawConfig()
+	raw["auto_unseal"] = true
+
+	_, err := resourceVaultCluster().Diff(context.Background(), testVaultClusterSealState(), sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err == nil {
+		t.Fatal("expected an error without allow_seal_migration")
+	}
+
+	raw["allow_seal_migration"] = true
+	diff, err := resourceVaultCluster().Diff(context.Background(), testVaultClusterSealState(), sdkterraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatalf("unexpected diff error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Error("expected the seal to be migrated in place")
+	}
+	if attr := diff.Attributes["auto_unseal_key_id"]; attr == nil || !attr.NewComputed {
+		t.Error("expected auto_unseal_key_id to be computed by the migration")
+	}
+}
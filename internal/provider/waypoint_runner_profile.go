@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// onDemandProfileSchema describes how an on-demand runner launches the
+// ephemeral runners executing each job.
+func onDemandProfileSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Launch profile of the ephemeral runners started for each job, only valid when runner_type is on-demand",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"plugin_type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Platform ephemeral runners are launched on (docker, kubernetes, nomad)",
+					ValidateFunc: validation.StringInSlice([]string{"docker", "kubernetes", "nomad"}, false),
+				},
+				"plugin_config": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Description: "Configuration of the launch plugin, such as the image or namespace to use",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"default_env": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Description: "Environment variables set on every ephemeral runner",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateOnDemandProfile checks that on_demand_profile is only set on
+// on-demand runners.
+func validateOnDemandProfile(d *schema.ResourceDiff) error {
+	runnerType := d.Get("runner_type").(string)
+	if len(d.Get("on_demand_profile").([]interface{})) > 0 && runnerType != "on-demand" {
+		return fmt.Errorf("on_demand_profile can only be set when runner_type is on-demand, got %s", runnerType)
+	}
+	return nil
+}
+
+// expandOnDemandProfile returns nil when no profile is configured.
+func expandOnDemandProfile(l []interface{}) map[string]interface{} {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	raw := l[0].(map[string]interface{})
+	return map[string]interface{}{
+		"pluginType":   raw["plugin_type"].(string),
+		"pluginConfig": raw["plugin_config"],
+		"defaultEnv":   raw["default_env"],
+	}
+}
+
+func flattenOnDemandProfile(runner map[string]interface{}) []interface{} {
+	profile, ok := runner["onDemandProfile"].(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+
+	item := map[string]interface{}{
+		"plugin_type":   getString(profile, "pluginType"),
+		"plugin_config": map[string]interface{}{},
+		"default_env":   map[string]interface{}{},
+	}
+	if pluginConfig, ok := profile["pluginConfig"].(map[string]interface{}); ok {
+		item["plugin_config"] = pluginConfig
+	}
+	if defaultEnv, ok := profile["defaultEnv"].(map[string]interface{}); ok {
+		item["default_env"] = defaultEnv
+	}
+	return []interface{}{item}
+}
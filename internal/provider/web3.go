@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// web3Schema returns the web3 block shared by the cluster and runner
+// resources. legacyField is the older boolean it replaces on that resource.
+func web3Schema(legacyField string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		Description:   "Web3 blockchain integration settings",
+		ConflictsWith: []string{legacyField},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Enable Web3 integration",
+				},
+				"chain_id": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Description:  "EVM chain ID of the target network, such as 1 for Ethereum mainnet",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"rpc_endpoints": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "RPC endpoints used to reach the chain",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "ws", "wss"}),
+					},
+				},
+				"wallet_integration": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Enable wallet integration",
+				},
+			},
+		},
+	}
+}
+
+// expandWeb3 builds the web3 payload from the web3 block. When the block is
+// not set, a true legacy boolean maps to an enabled block with no settings.
+func expandWeb3(l []interface{}, legacyEnabled bool) map[string]interface{} {
+	return expandLegacyBlock(l, legacyEnabled, map[string]interface{}{"enabled": true}, func(raw map[string]interface{}) map[string]interface{} {
+		web3 := map[string]interface{}{
+			"enabled":           raw["enabled"].(bool),
+			"walletIntegration": raw["wallet_integration"].(bool),
+			"rpcEndpoints":      raw["rpc_endpoints"].([]interface{}),
+		}
+		if chainId := raw["chain_id"].(int); chainId > 0 {
+			web3["chainId"] = chainId
+		}
+		return web3
+	})
+}
+
+func flattenWeb3(web3 map[string]interface{}) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":            getBool(web3, "enabled"),
+			"chain_id":           getInt(web3, "chainId"),
+			"rpc_endpoints":      getStringList(web3, "rpcEndpoints"),
+			"wallet_integration": getBool(web3, "walletIntegration"),
+		},
+	}
+}
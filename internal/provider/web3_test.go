@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestExpandWeb3(t *testing.T) {
+	if got := expandWeb3(nil, false); got != nil {
+		t.Errorf("expected no web3 payload, got %v", got)
+	}
+	if got := expandWeb3(nil, true); !reflect.DeepEqual(got, map[string]interface{}{"enabled": true}) {
+		t.Errorf("expected the legacy boolean to map to an enabled block, got %v", got)
+	}
+
+	block := []interface{}{
+		map[string]interface{}{
+			"enabled":            true,
+			"chain_id":           137,
+			"rpc_endpoints":      []interface{}{"https://polygon-rpc.com"},
+			"wallet_integration": true,
+		},
+	}
+	expected := map[string]interface{}{
+		"enabled":           true,
+		"chainId":           137,
+		"rpcEndpoints":      []interface{}{"https://polygon-rpc.com"},
+		"walletIntegration": true,
+	}
+	if got := expandWeb3(block, false); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWeb3Schema_chainIdValidation(t *testing.T) {
+	cases := map[string]struct {
+		chainId     int
+		expectError bool
+	}{
+		"mainnet":  {chainId: 1},
+		"zero":     {chainId: 0, expectError: true},
+		"negative": {chainId: -5, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := testVaultClusterRawConfig()
+			raw["web3"] = []interface{}{
+				map[string]interface{}{"chain_id": tc.chainId},
+			}
+
+			diags := resourceVaultCluster().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			if tc.expectError && !diags.HasError() {
+				t.Error("expected an error")
+			}
+			if !tc.expectError && diags.HasError() {
+				t.Errorf("unexpected error: %v", diags)
+			}
+		})
+	}
+}
+
+// TestWaypointRunnerRead_web3LegacyBoolean checks that a runner configured with
+// web3_deployments does not gain a web3 block on refresh
+func TestWaypointRunnerRead_web3LegacyBoolean(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{
+  "id": "runner-123",
+  "name": "test-runner",
+  "web3Deployments": true,
+  "web3": {"enabled": true}
+}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceWaypointRunner().Schema, map[string]interface{}{
+		"name":             "test-runner",
+		"web3_deployments": true,
+	})
+	d.SetId("runner-123")
+
+	if diags := resourceWaypointRunnerRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("web3").([]interface{}); len(got) != 0 {
+		t.Errorf("expected no web3 block, got %v", got)
+	}
+	if !d.Get("web3_deployments").(bool) {
+		t.Error("expected web3_deployments to remain true")
+	}
+}
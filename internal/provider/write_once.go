@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Secrets such as Vault root tokens or Consul gossip keys are only returned
+// in full by the OVH API when they are created or rotated; later reads return
+// them empty or masked. The helpers below keep the value already in state in
+// that case, so a refresh does not wipe secrets other resources reference.
+
+// isMaskedSecret reports whether s is absent or masked, such as "********".
+func isMaskedSecret(s string) bool {
+	return strings.Trim(s, "*") == ""
+}
+
+// setWriteOnceString sets key from the string field of an API object unless
+// the API returned it empty or masked.
+func setWriteOnceString(d *schema.ResourceData, key string, m map[string]interface{}, field string) {
+	if value := getString(m, field); !isMaskedSecret(value) {
+		d.Set(key, value)
+	}
+}
+
+// setWriteOnceStringList is like setWriteOnceString for lists of secrets,
+// keeping the state value when any element is missing or masked.
+func setWriteOnceStringList(d *schema.ResourceData, key string, m map[string]interface{}, field string) {
+	values := getStringList(m, field)
+	if len(values) == 0 {
+		return
+	}
+	for _, value := range values {
+		if isMaskedSecret(value) {
+			return
+		}
+	}
+	d.Set(key, values)
+}
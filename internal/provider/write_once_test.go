@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestVaultClusterRead_keepsWriteOnceSecrets checks that a plain refresh
+// keeps the root token and unseal keys the API no longer returns
+func TestVaultClusterRead_keepsWriteOnceSecrets(t *testing.T) {
+	cases := map[string]string{
+		"omitted": `{"id": "vault-123", "name": "test-vault", "status": "READY"}`,
+		"empty":   `{"id": "vault-123", "name": "test-vault", "status": "READY", "rootToken": "", "unsealKeys": []}`,
+		"masked":  `{"id": "vault-123", "name": "test-vault", "status": "READY", "rootToken": "********", "unsealKeys": ["****", "****"]}`,
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := NewMockHTTPServer()
+			defer mock.Close()
+
+			mock.AddResponse(200, body, nil)
+
+			state := &sdkterraform.InstanceState{
+				ID: "vault-123",
+				Attributes: map[string]string{
+					"id":            "vault-123",
+					"auto_unseal":   "true",
+					"root_token":    "hvs.root",
+					"unseal_keys.#": "2",
+					"unseal_keys.0": "key-1",
+					"unseal_keys.1": "key-2",
+				},
+			}
+			d := resourceVaultCluster().Data(state)
+
+			if diags := resourceVaultClusterRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("root_token").(string); got != "hvs.root" {
+				t.Errorf("expected root_token to survive the refresh, got %q", got)
+			}
+			if got := d.Get("unseal_keys").([]interface{}); !reflect.DeepEqual(got, []interface{}{"key-1", "key-2"}) {
+				t.Errorf("expected unseal_keys to survive the refresh, got %v", got)
+			}
+		})
+	}
+}
+
+func TestWaypointRunnerRead_keepsToken(t *testing.T) {
+	mock := NewMockHTTPServer()
+	defer mock.Close()
+
+	mock.AddResponse(200, `{"id": "runner-123", "name": "test-runner", "token": ""}`, nil)
+
+	d := schema.TestResourceDataRaw(t, resourceWaypointRunner().Schema, testWaypointRunnerRawConfig())
+	d.SetId("runner-123")
+	d.Set("token", "runner-token")
+
+	if diags := resourceWaypointRunnerRead(context.Background(), d, mock.NewConfig(t)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("token").(string); got != "runner-token" {
+		t.Errorf("expected token to survive the refresh, got %q", got)
+	}
+}
+
+func TestSetWriteOnceString_updatesNewValue(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceConsulCluster().Schema, testConsulClusterRawConfig())
+	d.Set("gossip_key", "old-key")
+
+	setWriteOnceString(d, "gossip_key", map[string]interface{}{"gossipKey": "new-key"}, "gossipKey")
+
+	if got := d.Get("gossip_key").(string); got != "new-key" {
+		t.Errorf("expected a value returned in full to be stored, got %q", got)
+	}
+}